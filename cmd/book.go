@@ -2,9 +2,13 @@ package cmd
 
 import (
 	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/i18n"
 	"cosoft-cli/internal/services"
+	"cosoft-cli/shared/models"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -27,7 +31,7 @@ var bookCmd = &cobra.Command{
 		}
 
 		if nbUsers > 2 {
-			fmt.Println("Too many users, defaulting to 2.")
+			fmt.Println(i18n.T("cli.book.too_many"))
 			nbUsers = 2
 		}
 
@@ -55,30 +59,41 @@ var bookCmd = &cobra.Command{
 			}
 		}
 
-		if parsedTime.Before(time.Now()) {
-			fmt.Println("The date needs to be in the future")
+		duration, err := cmd.Flags().GetInt("duration")
+		if err != nil {
+			fmt.Println(err)
 			os.Exit(1)
 		}
 
-		if parsedTime.Minute()%15 != 0 {
-			fmt.Println("Time needs to be rounded to a quarter")
+		featureNames, err := cmd.Flags().GetStringSlice("feature")
+		if err != nil {
+			fmt.Println(err)
 			os.Exit(1)
 		}
 
-		duration, err := cmd.Flags().GetInt("duration")
+		features, err := parseFeatures(featureNames)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
 
-		if duration <= 0 || duration%15 != 0 {
-			fmt.Println("Duration must be a multiple of 15")
+		request := services.BookingRequest{
+			Capacity: nbUsers,
+			Duration: duration,
+			Name:     name,
+			DateTime: parsedTime,
+			Features: features,
+		}
+
+		if err := request.Validate(); err != nil {
+			fmt.Println(err)
 			os.Exit(1)
 		}
 
-		// Hard limit for duration
-		if duration > 120 {
-			duration = 120
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
 		}
 
 		s, err := services.NewService()
@@ -88,7 +103,7 @@ var bookCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		t, err := s.NonInteractiveBooking(nbUsers, duration, name, parsedTime)
+		t, err := s.NonInteractiveBooking(nbUsers, duration, name, parsedTime, features, dryRun)
 
 		if err != nil {
 			fmt.Println(err)
@@ -99,6 +114,34 @@ var bookCmd = &cobra.Command{
 	},
 }
 
+// parseFeatures reads the --feature flags.
+func parseFeatures(names []string) ([]models.Feature, error) {
+	features := make([]models.Feature, 0, len(names))
+
+	for _, name := range names {
+		feature, ok := models.ParseFeature(name)
+
+		if !ok {
+			return nil, errors.New(i18n.T("cli.book.unknown_feature", name, featureList()))
+		}
+
+		features = append(features, feature)
+	}
+
+	return features, nil
+}
+
+// featureList lists the features --feature accepts.
+func featureList() string {
+	names := make([]string, len(models.Features))
+
+	for i, f := range models.Features {
+		names[i] = string(f)
+	}
+
+	return strings.Join(names, ", ")
+}
+
 func init() {
 	bookCmd.Flags().IntP(
 		"capacity",
@@ -128,5 +171,18 @@ func init() {
 		"Duration of the booking in minutes (Must be a multiple of 15 minutes)",
 	)
 
+	bookCmd.Flags().StringSliceP(
+		"feature",
+		"f",
+		nil,
+		"Equipment the room must have: screen, whiteboard or videoconference. Can be repeated, or comma separated",
+	)
+
+	bookCmd.Flags().Bool(
+		"dry-run",
+		false,
+		"Show the room, time and cost that would be booked, without paying",
+	)
+
 	rootCmd.AddCommand(bookCmd)
 }
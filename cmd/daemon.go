@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/services"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var daemonCmd = &cobra.Command{
+	Use:     "daemon",
+	Short:   "Run in the background and raise a desktop notification before each reservation",
+	PreRunE: requireAuth,
+	Run: func(cmd *cobra.Command, args []string) {
+		s, err := services.NewService()
+
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		lead, err := cmd.Flags().GetInt("lead")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		if cmd.Flags().Changed("lead") {
+			if err := s.SetReminderLeadTime(lead); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		} else {
+			user, err := s.GetAuthData()
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+
+			lead = user.ReminderLeadTime
+		}
+
+		if lead <= 0 {
+			fmt.Println(i18n.T("cli.daemon.disabled"))
+			os.Exit(1)
+		}
+
+		fmt.Println(i18n.T("cli.daemon.watching", lead))
+
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		for ; true; <-ticker.C {
+			reservations, err := s.PendingReminders(time.Duration(lead) * time.Minute)
+
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+
+			for _, r := range reservations {
+				location, _ := common.LoadLocalTime()
+				start, _ := time.ParseInLocation("2006-01-02T15:04:05", r.Start, location)
+
+				err := common.Notify(
+					"Cosoft",
+					i18n.T("cli.daemon.starts", r.ItemName, i18n.Default().Time(start)),
+				)
+
+				if err != nil {
+					log.Println(err)
+					continue
+				}
+
+				if err := s.MarkReminderSent(r.OrderResourceRentId); err != nil {
+					log.Println(err)
+				}
+			}
+		}
+	},
+}
+
+func init() {
+	daemonCmd.Flags().IntP(
+		"lead",
+		"l",
+		0,
+		"How many minutes before a reservation to be notified (saved for next runs, 0 disables reminders)",
+	)
+
+	rootCmd.AddCommand(daemonCmd)
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/services"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var reservationsCmd = &cobra.Command{
+	Use:     "reservations",
+	Short:   "List your upcoming reservations",
+	PreRunE: requireAuth,
+	Run: func(cmd *cobra.Command, args []string) {
+		s, err := services.NewService()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		user, err := s.GetAuthData()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		apiClient := api.NewApi()
+		bookings, err := apiClient.GetFutureBookings(user.WAuth, user.WAuthRefresh)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		location, _ := common.LoadLocalTime()
+		l := i18n.Default()
+		headers := []string{l.T("cli.table.id"), l.T("cli.table.room"), l.T("cli.table.start"), l.T("cli.table.end")}
+		rows := make([][]string, len(bookings.Data))
+
+		for i, r := range bookings.Data {
+			start, _ := time.ParseInLocation("2006-01-02T15:04:05", r.Start, location)
+			end, _ := time.ParseInLocation("2006-01-02T15:04:05", r.End, location)
+
+			rows[i] = []string{
+				r.OrderResourceRentId,
+				r.ItemName,
+				l.DateTime(start),
+				l.Time(end),
+			}
+		}
+
+		fmt.Println(common.CreateTable(headers, rows))
+	},
+}
+
+var extendCmd = &cobra.Command{
+	Use:     "extend <id>",
+	Short:   "Extend a reservation in the same room",
+	Args:    cobra.ExactArgs(1),
+	PreRunE: requireAuth,
+	Run: func(cmd *cobra.Command, args []string) {
+		resizeReservation(cmd, args[0], 1)
+	},
+}
+
+var shortenCmd = &cobra.Command{
+	Use:     "shorten <id>",
+	Short:   "End a reservation early, getting the unused credits back",
+	Args:    cobra.ExactArgs(1),
+	PreRunE: requireAuth,
+	Run: func(cmd *cobra.Command, args []string) {
+		resizeReservation(cmd, args[0], -1)
+	},
+}
+
+var cancelCmd = &cobra.Command{
+	Use:     "cancel <id>",
+	Short:   "Cancel a reservation",
+	Args:    cobra.ExactArgs(1),
+	PreRunE: requireAuth,
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		s, err := services.NewService()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		reservation, credits, err := s.CancelReservation(args[0], dryRun)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		location, _ := common.LoadLocalTime()
+		start, _ := time.ParseInLocation("2006-01-02T15:04:05", reservation.Start, location)
+		end, _ := time.ParseInLocation("2006-01-02T15:04:05", reservation.End, location)
+		l := i18n.Default()
+		slot := fmt.Sprintf("%s → %s", l.DateTime(start), l.Time(end))
+
+		if dryRun {
+			fmt.Println(l.T("cli.reservations.dry_run_cancel", reservation.ItemName, slot, credits))
+			return
+		}
+
+		fmt.Println(l.T("cli.reservations.cancelled", reservation.ItemName, slot, credits))
+	},
+}
+
+// resizeReservation moves the reservation's end by the --by flag, in the
+// direction given by sign.
+func resizeReservation(cmd *cobra.Command, id string, sign int) {
+	by, err := cmd.Flags().GetInt("by")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if by <= 0 {
+		fmt.Println(i18n.T("cli.reservations.by_positive"))
+		os.Exit(1)
+	}
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	s, err := services.NewService()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	reservation, credits, err := s.ResizeReservation(id, sign*by, dryRun)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	location, _ := common.LoadLocalTime()
+	end, _ := time.ParseInLocation("2006-01-02T15:04:05", reservation.End, location)
+	end = end.Add(time.Duration(sign*by) * time.Minute)
+	l := i18n.Default()
+
+	if dryRun {
+		if credits >= 0 {
+			fmt.Println(l.T("cli.reservations.dry_run_spend", reservation.ItemName, l.Time(end), credits))
+		} else {
+			fmt.Println(l.T("cli.reservations.dry_run_refund", reservation.ItemName, l.Time(end), math.Abs(credits)))
+		}
+
+		return
+	}
+
+	if credits >= 0 {
+		fmt.Println(l.T("cli.reservations.spent", reservation.ItemName, l.Time(end), credits))
+	} else {
+		fmt.Println(l.T("cli.reservations.refunded", reservation.ItemName, l.Time(end), math.Abs(credits)))
+	}
+}
+
+func init() {
+	extendCmd.Flags().Int("by", 30, "How many minutes to add (Must be a multiple of 15 minutes)")
+	shortenCmd.Flags().Int("by", 15, "How many minutes to remove (Must be a multiple of 15 minutes)")
+
+	for _, c := range []*cobra.Command{cancelCmd, extendCmd, shortenCmd} {
+		c.Flags().Bool("dry-run", false, "Show what would change, without booking or cancelling anything")
+	}
+
+	reservationsCmd.AddCommand(cancelCmd, extendCmd, shortenCmd)
+	rootCmd.AddCommand(reservationsCmd)
+}
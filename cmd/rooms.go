@@ -2,11 +2,13 @@ package cmd
 
 import (
 	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/services"
+	"cosoft-cli/internal/settings"
 	"cosoft-cli/internal/storage"
 	"fmt"
 	"log"
-	"os"
-	"strconv"
+	"slices"
 
 	"github.com/spf13/cobra"
 )
@@ -14,36 +16,52 @@ import (
 var roomsCmd = &cobra.Command{
 	Use:   "rooms",
 	Short: "List all rooms in HUB612",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		// Only fetching the rooms again needs an account.
+		if refresh, _ := cmd.Flags().GetBool("refresh"); refresh {
+			return requireAuth(cmd, args)
+		}
+
+		return nil
+	},
 	Run: func(cmd *cobra.Command, args []string) {
-		configDir, err := os.UserConfigDir()
+		refresh, err := cmd.Flags().GetBool("refresh")
 
 		if err != nil {
 			log.Fatal(err)
 		}
 
-		path := fmt.Sprintf("%s/cosoft/data.db", configDir)
-
-		store, err := storage.NewStore(path)
+		s, err := services.NewService()
 
 		if err != nil {
 			log.Fatal(err)
 		}
 
-		rooms, err := store.GetRooms()
+		if refresh {
+			changes, err := s.RefreshRooms()
+
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printRoomChanges(changes)
+		}
+
+		rooms, err := s.GetRooms()
 
 		if err != nil {
 			log.Fatal(err)
 		}
 
-		headers := []string{"NAME", "CAPACITY", "PRICE"}
+		headers := []string{i18n.T("cli.table.name"), i18n.T("cli.table.capacity"), i18n.T("cli.table.price")}
 
 		rows := make([][]string, len(rooms))
 
 		for i, room := range rooms {
 			rows[i] = []string{
 				room.Name,
-				strconv.Itoa(room.MaxUsers) + " person(s)",
-				strconv.FormatFloat(room.Price, 'g', 5, 64) + " credits",
+				i18n.T("cli.people", room.MaxUsers),
+				i18n.T("cli.credits", room.Price),
 			}
 		}
 
@@ -51,6 +69,40 @@ var roomsCmd = &cobra.Command{
 	},
 }
 
+// printRoomChanges prints what fetching the rooms again changed, flagging
+// the new prices of the favorite rooms.
+func printRoomChanges(changes []storage.RoomChange) {
+	l := i18n.Default()
+
+	if len(changes) == 0 {
+		fmt.Println(l.T("cli.rooms.unchanged"))
+		return
+	}
+
+	var favorites []storage.RoomChange
+
+	if config, err := settings.LoadConfig(); err == nil {
+		favorites = services.FavoritePriceChanges(changes, config.FavoriteRooms)
+	}
+
+	for _, c := range changes {
+		if slices.Contains(favorites, c) {
+			fmt.Println(services.DescribeFavoritePriceChange(c, l))
+			continue
+		}
+
+		fmt.Println(services.DescribeRoomChange(c, l))
+	}
+
+	fmt.Println()
+}
+
 func init() {
+	roomsCmd.Flags().Bool(
+		"refresh",
+		false,
+		"Fetch the rooms from Cosoft again, and list what changed",
+	)
+
 	rootCmd.AddCommand(roomsCmd)
 }
@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"cosoft-cli/internal/api/sandbox"
+	"cosoft-cli/internal/i18n"
 	"cosoft-cli/internal/services"
 	"cosoft-cli/internal/settings"
+	"cosoft-cli/internal/theme"
 	"cosoft-cli/internal/ui"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -22,15 +26,34 @@ var rootCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		ui := ui.NewUI()
 		if err := ui.StartApp("landing", true); err != nil {
-			fmt.Printf("Error: %v\n", err)
+			fmt.Println(i18n.T("cli.error", err))
 		}
 	},
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if sandboxMode, _ := cmd.Flags().GetBool("sandbox"); sandboxMode {
+			os.Setenv("COSOFT_SANDBOX", "1")
+		}
+
+		if sandbox.Enabled() {
+			if err := startSandbox(); err != nil {
+				log.Fatal(err)
+			}
+		}
+
 		err := settings.EnsureDatabaseExists()
 
 		if err != nil {
 			log.Fatal(err)
 		}
+
+		if err := loadTheme(); err != nil {
+			log.Fatal(err)
+		}
+
+		// The language picked in the settings, else LANG.
+		if s, err := services.NewService(); err == nil {
+			i18n.SetDefault(s.Locale())
+		}
 	},
 }
 
@@ -43,6 +66,51 @@ func Execute() {
 
 func init() {
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	rootCmd.PersistentFlags().Bool("sandbox", false, "Use a simulated Cosoft with fake rooms and credits, any email and password work")
+}
+
+// loadTheme applies the theme of the user's configuration, without colors
+// when NO_COLOR is set.
+func loadTheme() error {
+	config, err := settings.LoadConfig()
+
+	if err != nil {
+		return err
+	}
+
+	t, err := theme.New(config.Theme, theme.NoColorEnv())
+
+	if err != nil {
+		return err
+	}
+
+	theme.Set(t)
+
+	return nil
+}
+
+// startSandbox serves the simulated Cosoft for as long as the command runs,
+// with the bookings and credits left by the previous commands.
+func startSandbox() error {
+	path, err := settings.SandboxStatePath()
+
+	if err != nil {
+		return err
+	}
+
+	backend, err := sandbox.Persisted(path)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := sandbox.Start(backend); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, i18n.T("cli.sandbox"))
+
+	return nil
 }
 
 func requireAuth(cmd *cobra.Command, args []string) error {
@@ -68,7 +136,7 @@ func requireAuth(cmd *cobra.Command, args []string) error {
 
 	// Check if token is actually present (login succeeded)
 	if user == nil || user.JwtToken == "" {
-		return fmt.Errorf("authentication cancelled or failed")
+		return errors.New(i18n.T("cli.auth_failed"))
 	}
 
 	return authService.SaveAuthData(user)
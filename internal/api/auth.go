@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"net/http"
 	"strings"
 )
 
@@ -37,7 +36,7 @@ func (a *Api) Login(payload *LoginPayload) (*UserResponse, error) {
 		log.Fatal(err)
 	}
 
-	resp, err := http.Post(
+	resp, err := newClient().Post(
 		apiUrl+"/users/login",
 		"application/json",
 		bytes.NewBuffer(jsonValues),
@@ -140,6 +139,10 @@ func (a *Api) GetCredits(wAuth, wAuthRefresh string) (float64, error) {
 
 	defer resp.Body.Close()
 
+	if err := checkStatus(resp); err != nil {
+		return 0, err
+	}
+
 	response := AuthPayload{}
 
 	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -35,6 +36,10 @@ func (a *Api) GetFutureBookings(wAuth, wAuthRefresh string) (*FutureBookingsResp
 
 	defer resp.Body.Close()
 
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+
 	response := FutureBookingsResponse{}
 
 	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
@@ -78,6 +83,10 @@ func (a *Api) GetAllRooms(wAuth, wAuthRefresh string) ([]models.Room, error) {
 
 	defer resp.Body.Close()
 
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+
 	response := AvailableRoomsResponse{}
 
 	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
@@ -104,7 +113,7 @@ func (a *Api) GetAvailableRooms(
 		return nil, err
 	}
 
-	client := &http.Client{}
+	client := newClient()
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Cookie", fmt.Sprintf("w_auth=%s; w_auth_refresh=%s", wAuth, wAuthRefresh))
@@ -117,6 +126,10 @@ func (a *Api) GetAvailableRooms(
 
 	defer resp.Body.Close()
 
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+
 	response := AvailableRoomsResponse{}
 
 	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
@@ -139,18 +152,20 @@ func (a *Api) BookRoom(wAuth, wAuthRefresh string, payload CosoftBookingPayload)
 		return err
 	}
 
-	client := &http.Client{}
+	client := newClient()
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Cookie", fmt.Sprintf("w_auth=%s; w_auth_refresh=%s", wAuth, wAuthRefresh))
 
-	_, err = client.Do(req)
+	resp, err := client.Do(req)
 
 	if err != nil {
 		return err
 	}
 
-	return nil
+	defer resp.Body.Close()
+
+	return checkStatus(resp)
 }
 
 func (a *Api) CancelBooking(wAuth, wAuthRefresh, bookingId string) error {
@@ -176,13 +191,15 @@ func (a *Api) CancelBooking(wAuth, wAuthRefresh, bookingId string) error {
 		return err
 	}
 
-	_, err = client.Do(req)
+	resp, err := client.Do(req)
 
 	if err != nil {
 		return err
 	}
 
-	return nil
+	defer resp.Body.Close()
+
+	return checkStatus(resp)
 }
 
 func (a *Api) prepareRoomAvailabilityRequest(payload CosoftAvailabilityPayload) (*http.Request, error) {
@@ -301,15 +318,7 @@ func (a *Api) getRoomsInfoFromResponse(response AvailableRoomsResponse) ([]model
 			continue
 		}
 
-		mr := models.Room{
-			Id:      room.Id,
-			Name:    room.Name,
-			NbUsers: room.NbUsers,
-			Price:   room.Prices[0].Credits,
-			Image:   room.Image.Url,
-		}
-
-		rooms = append(rooms, mr)
+		rooms = append(rooms, room.toRoom())
 	}
 
 	for _, room := range response.UnvisitedItems {
@@ -319,20 +328,34 @@ func (a *Api) getRoomsInfoFromResponse(response AvailableRoomsResponse) ([]model
 			continue
 		}
 
-		mr := models.Room{
-			Id:      room.Id,
-			Name:    room.Name,
-			NbUsers: room.NbUsers,
-			Price:   room.Prices[0].Credits,
-			Image:   room.Image.Url,
-		}
-
-		rooms = append(rooms, mr)
+		rooms = append(rooms, room.toRoom())
 	}
 
 	return rooms, nil
 }
 
+// toRoom keeps what the CLI and the bot use of the room.
+func (room RoomResponse) toRoom() models.Room {
+	var equipment []string
+
+	for _, e := range room.Equipments {
+		if name := strings.TrimSpace(e.Name); name != "" {
+			equipment = append(equipment, name)
+		}
+	}
+
+	return models.Room{
+		Id:          room.Id,
+		Name:        room.Name,
+		NbUsers:     room.NbUsers,
+		Price:       room.Prices[0].Credits,
+		Image:       room.Image.Url,
+		Description: strings.TrimSpace(room.Description),
+		Floor:       strings.TrimSpace(string(room.Floor)),
+		Equipment:   equipment,
+	}
+}
+
 func randomStringGenerator(length int) string {
 	b := make([]byte, length+2)
 	_, _ = rand.Read(b)
@@ -49,7 +49,7 @@ func (a *Api) GetRoomBusyTime(
 		return nil, err
 	}
 
-	client := &http.Client{}
+	client := newClient()
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Cookie", fmt.Sprintf("w_auth=%s; w_auth_refresh=%s", wAuth, wAuthRefresh))
@@ -61,6 +61,10 @@ func (a *Api) GetRoomBusyTime(
 
 	defer resp.Body.Close()
 
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
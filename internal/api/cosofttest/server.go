@@ -0,0 +1,67 @@
+// Package cosofttest starts the sandbox's simulated Cosoft API for tests, so
+// that nothing reaches hub612.cosoft.fr.
+package cosofttest
+
+import (
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/api/sandbox"
+	"net/http/httptest"
+	"testing"
+)
+
+// Endpoints which can be made to fail with Fail.
+const (
+	Login        = sandbox.Login
+	Auth         = sandbox.Auth
+	Logout       = sandbox.Logout
+	Reservations = sandbox.Reservations
+	Items        = sandbox.Items
+	BusyTimes    = sandbox.BusyTimes
+	Payment      = sandbox.Payment
+	Cancel       = sandbox.Cancel
+)
+
+// DefaultRooms are free at all times.
+var DefaultRooms = []sandbox.Room{
+	{Id: "5d1f2c4e-0000-4000-8000-000000000001", Name: "Salle Bleue", NbUsers: 2, Price: 10, Floor: "1", Equipment: []string{"Écran", "Tableau blanc"}},
+	{Id: "5d1f2c4e-0000-4000-8000-000000000002", Name: "Salle Verte", NbUsers: 1, Price: 6, Floor: "1"},
+	{Id: "5d1f2c4e-0000-4000-8000-000000000003", Name: "Salle Rouge", NbUsers: 2, Price: 12, Floor: "2", Description: "Équipée pour la visioconférence."},
+}
+
+type Server struct {
+	*sandbox.Backend
+	*httptest.Server
+}
+
+// NewServer starts a fake Cosoft API with DefaultRooms and 100 credits,
+// and points the api package to it until the test ends.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	backend, err := sandbox.New(sandbox.Options{
+		Rooms:     DefaultRooms,
+		Credits:   100,
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Email:     "jane.doe@example.com",
+		Password:  "password",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{
+		Backend: backend,
+		Server:  httptest.NewServer(backend),
+	}
+
+	previous := api.SetBaseUrl(s.URL)
+
+	t.Cleanup(func() {
+		api.SetBaseUrl(previous)
+		s.Close()
+	})
+
+	return s
+}
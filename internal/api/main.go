@@ -1,6 +1,9 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -8,6 +11,9 @@ import (
 
 type Api struct{}
 
+// transport carries every request made to Cosoft.
+var transport = http.DefaultTransport
+
 var (
 	apiUrl     = "https://hub612.cosoft.fr/v2/api/api"
 	spaceId    = "a4928a70-38c1-42b9-96f9-b2dd00db5b02"
@@ -18,6 +24,93 @@ func NewApi() *Api {
 	return &Api{}
 }
 
+// SetBaseUrl points every client to another Cosoft API, such as a fake one in
+// tests, and returns the previous URL so it can be restored.
+func SetBaseUrl(url string) string {
+	previous := apiUrl
+	apiUrl = url
+
+	return previous
+}
+
+// SetTransport makes every client send its requests through rt, such as one
+// recording metrics, and returns the previous transport so it can be restored.
+func SetTransport(rt http.RoundTripper) http.RoundTripper {
+	previous := transport
+	transport = rt
+
+	return previous
+}
+
+func newClient() *http.Client {
+	return &http.Client{Transport: transport}
+}
+
+// Ping tells whether Cosoft answers, whatever the answer.
+func (a *Api) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", apiUrl, nil)
+
+	if err != nil {
+		return err
+	}
+
+	resp, err := newClient().Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("cosoft error %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// ErrUnauthorized matches the errors of the requests Cosoft refused for
+// their tokens, such as expired ones.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// statusError is an error status answered by Cosoft, along with its message.
+type statusError struct {
+	code    int
+	message string
+}
+
+func (e *statusError) Error() string {
+	if e.message != "" {
+		return fmt.Sprintf("cosoft error %d: %s", e.code, e.message)
+	}
+
+	return fmt.Sprintf("cosoft error %d", e.code)
+}
+
+func (e *statusError) Is(target error) bool {
+	return target == ErrUnauthorized && e.code == http.StatusUnauthorized
+}
+
+// checkStatus turns an error status into an error, using the message Cosoft
+// sends along when there is one.
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode < 400 {
+		return nil
+	}
+
+	var body struct {
+		Message string `json:"Message"`
+	}
+
+	err := &statusError{code: resp.StatusCode}
+
+	if json.NewDecoder(resp.Body).Decode(&body) == nil {
+		err.message = body.Message
+	}
+
+	return err
+}
+
 func (a *Api) prepareHeaderCookies(
 	wAuth, wAuthRefresh, method, endpoint string,
 	payload io.Reader,
@@ -28,7 +121,7 @@ func (a *Api) prepareHeaderCookies(
 		return nil, nil, err
 	}
 
-	client := &http.Client{}
+	client := newClient()
 
 	// A token and a refresh token need to be added in the request's header to make an authenticated request.
 	req.Header.Set("Content-Type", "application/json")
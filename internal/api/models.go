@@ -2,6 +2,7 @@ package api
 
 import (
 	"cosoft-cli/shared/models"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -42,11 +43,58 @@ type RoomImage struct {
 }
 
 type RoomResponse struct {
-	Id      string          `json:"Id"`
-	Name    string          `json:"Name"`
-	NbUsers int             `json:"NbUsers"`
-	Prices  []PriceResponse `json:"Prices"`
-	Image   RoomImage       `json:"Image"`
+	Id          string          `json:"Id"`
+	Name        string          `json:"Name"`
+	NbUsers     int             `json:"NbUsers"`
+	Prices      []PriceResponse `json:"Prices"`
+	Image       RoomImage       `json:"Image"`
+	Description string          `json:"Description"`
+	Floor       LenientString   `json:"Floor"`
+	Equipments  []RoomEquipment `json:"Equipments"`
+}
+
+// RoomEquipment is an equipment of a room, which Cosoft sends either as its
+// name or as an object.
+type RoomEquipment struct {
+	Name string `json:"Name"`
+}
+
+func (e *RoomEquipment) UnmarshalJSON(data []byte) error {
+	var name string
+
+	if json.Unmarshal(data, &name) == nil {
+		e.Name = name
+		return nil
+	}
+
+	type plain RoomEquipment
+
+	return json.Unmarshal(data, (*plain)(e))
+}
+
+// LenientString decodes a JSON string, number or null as a string, for the
+// fields Cosoft doesn't always send with the same type. A room's details
+// shouldn't prevent listing the rooms.
+type LenientString string
+
+func (s *LenientString) UnmarshalJSON(data []byte) error {
+	var text string
+
+	if json.Unmarshal(data, &text) == nil {
+		*s = LenientString(text)
+		return nil
+	}
+
+	var number json.Number
+
+	if err := json.Unmarshal(data, &number); err != nil {
+		*s = ""
+		return nil
+	}
+
+	*s = LenientString(number.String())
+
+	return nil
 }
 
 type AvailableRoomsResponse struct {
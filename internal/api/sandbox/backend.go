@@ -0,0 +1,604 @@
+// Package sandbox simulates the Cosoft API in memory, optionally saved to a
+// file: login, authentication, rooms and their availability, busy times,
+// payment and cancellation, answering with the same JSON as hub612.cosoft.fr.
+// It backs the --sandbox mode and the tests.
+package sandbox
+
+import (
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/common"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Endpoints which can be made to fail with Fail.
+const (
+	Login        = "login"
+	Auth         = "auth"
+	Logout       = "logout"
+	Reservations = "reservations"
+	Items        = "items"
+	BusyTimes    = "busytimes"
+	Payment      = "payment"
+	Cancel       = "cancel"
+)
+
+const dateFormat = "2006-01-02T15:04:05"
+
+type Room struct {
+	Id      string
+	Name    string
+	NbUsers int
+	// Price is in credits per hour.
+	Price       float64
+	Image       string
+	Description string
+	Floor       string
+	Equipment   []string
+}
+
+// Booking is a room's reservation, either made by the user through the API,
+// or by somebody else with Occupy.
+type Booking struct {
+	Id     string
+	RoomId string
+	Start  time.Time
+	End    time.Time
+	// Credits is the hourly price paid, as reported by Cosoft.
+	Credits float64
+	Mine    bool
+}
+
+type failure struct {
+	status int
+	// remaining is how many requests still fail, 0 meaning all of them.
+	remaining int
+}
+
+type Options struct {
+	Rooms     []Room
+	Credits   float64
+	FirstName string
+	LastName  string
+	// Email and Password are the credentials accepted by the login, any
+	// credentials being accepted when AnyLogin is set.
+	Email    string
+	Password string
+	AnyLogin bool
+	// Token and Refresh are the cookies handed out on login, random when
+	// empty. Fixed ones keep a stored login valid from one run to another.
+	Token   string
+	Refresh string
+}
+
+// Backend is an http.Handler answering like the Cosoft API.
+type Backend struct {
+	FirstName string
+	LastName  string
+	Email     string
+	Password  string
+
+	mu       sync.Mutex
+	handler  http.Handler
+	anyLogin bool
+	credits  float64
+	rooms    []Room
+	bookings []Booking
+	failures map[string]failure
+	delays   map[string]time.Duration
+	token    string
+	refresh  string
+	location *time.Location
+	// statePath is where the user's bookings and credits are saved, see
+	// Persisted.
+	statePath string
+}
+
+func New(options Options) (*Backend, error) {
+	location, err := common.LoadLocalTime()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Backend{
+		FirstName: options.FirstName,
+		LastName:  options.LastName,
+		Email:     options.Email,
+		Password:  options.Password,
+		anyLogin:  options.AnyLogin,
+		credits:   options.Credits,
+		rooms:     append([]Room{}, options.Rooms...),
+		failures:  map[string]failure{},
+		delays:    map[string]time.Duration{},
+		token:     options.Token,
+		refresh:   options.Refresh,
+		location:  location,
+	}
+
+	if s.token == "" {
+		s.token = randomToken()
+	}
+
+	if s.refresh == "" {
+		s.refresh = randomToken()
+	}
+
+	s.handler = s.routes()
+
+	return s, nil
+}
+
+func (s *Backend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// SetRooms replaces the rooms of the coworking space.
+func (s *Backend) SetRooms(rooms ...Room) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rooms = rooms
+}
+
+func (s *Backend) SetCredits(credits float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.credits = credits
+}
+
+func (s *Backend) Credits() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.credits
+}
+
+// Occupy books roomId for somebody else, so it shows up in busy times and
+// isn't available anymore.
+func (s *Backend) Occupy(roomId string, start, end time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.bookings = append(s.bookings, Booking{
+		Id:     uuid.NewString(),
+		RoomId: roomId,
+		Start:  start,
+		End:    end,
+	})
+}
+
+// Bookings returns the test user's bookings, sorted by start.
+func (s *Backend) Bookings() []Booking {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var mine []Booking
+
+	for _, b := range s.bookings {
+		if b.Mine {
+			mine = append(mine, b)
+		}
+	}
+
+	sort.Slice(mine, func(i, j int) bool {
+		return mine[i].Start.Before(mine[j].Start)
+	})
+
+	return mine
+}
+
+// Fail makes endpoint answer with status until Fail is called again with 0.
+func (s *Backend) Fail(endpoint string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if status == 0 {
+		delete(s.failures, endpoint)
+		return
+	}
+
+	s.failures[endpoint] = failure{status: status}
+}
+
+// FailOnce makes the next request to endpoint answer with status.
+func (s *Backend) FailOnce(endpoint string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures[endpoint] = failure{status: status, remaining: 1}
+}
+
+// Delay makes endpoint answer after d, like a slow Cosoft, until Delay is
+// called again with 0.
+func (s *Backend) Delay(endpoint string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d == 0 {
+		delete(s.delays, endpoint)
+		return
+	}
+
+	s.delays[endpoint] = d
+}
+
+// LoginResponse is what a successful login returns, handy to store a user
+// without going through the login.
+func (s *Backend) LoginResponse() *api.UserResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.user()
+}
+
+func (s *Backend) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /users/login", s.handle(Login, false, s.login))
+	mux.HandleFunc("GET /users/auth", s.handle(Auth, false, s.auth))
+	mux.HandleFunc("POST /users/logout", s.handle(Logout, true, s.logout))
+	mux.HandleFunc("GET /Reservations/get-current-and-incoming", s.handle(Reservations, true, s.reservations))
+	mux.HandleFunc("POST /CoworkingSpace/{space}/category/{category}/items", s.handle(Items, true, s.items))
+	mux.HandleFunc("POST /CoworkingSpace/{space}/category/{category}/item/{item}/busytimes", s.handle(BusyTimes, true, s.busyTimes))
+	mux.HandleFunc("POST /Payment/pay", s.handle(Payment, true, s.pay))
+	mux.HandleFunc("POST /Reservation/cancel-order", s.handle(Cancel, true, s.cancel))
+
+	return mux
+}
+
+// handle serializes the requests, injects delays and failures, and checks
+// the auth cookies when authenticated.
+func (s *Backend) handle(endpoint string, authenticated bool, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		delay := s.delays[endpoint]
+		s.mu.Unlock()
+
+		// Other requests are answered meanwhile.
+		time.Sleep(delay)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if f, ok := s.failures[endpoint]; ok {
+			if f.remaining == 1 {
+				delete(s.failures, endpoint)
+			}
+
+			writeJSON(w, f.status, map[string]string{"Message": fmt.Sprintf("%s failure injected", endpoint)})
+			return
+		}
+
+		if err := s.load(); err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"Message": err.Error()})
+			return
+		}
+
+		if authenticated && !s.authenticated(r) {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"Message": "Unauthorized"})
+			return
+		}
+
+		h(w, r)
+	}
+}
+
+func (s *Backend) authenticated(r *http.Request) bool {
+	token, err := r.Cookie("w_auth")
+
+	return err == nil && token.Value == s.token
+}
+
+func (s *Backend) user() *api.UserResponse {
+	return &api.UserResponse{
+		JwtToken:     s.token,
+		RefreshToken: s.refresh,
+		Id:           "8f0a5b52-0000-4000-8000-000000000000",
+		FirstName:    s.FirstName,
+		LastName:     s.LastName,
+		Email:        s.Email,
+		Credits:      s.credits,
+	}
+}
+
+func (s *Backend) login(w http.ResponseWriter, r *http.Request) {
+	var credentials struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"Message": err.Error()})
+		return
+	}
+
+	if !s.anyLogin && (credentials.Email != s.Email || credentials.Password != s.Password) {
+		writeJSON(w, http.StatusOK, api.AuthPayload{IsAuth: false, Message: "Identifiants incorrects"})
+		return
+	}
+
+	if s.anyLogin {
+		s.Email = credentials.Email
+	}
+
+	http.SetCookie(w, &http.Cookie{Name: "w_auth", Value: s.token, Path: "/", HttpOnly: true})
+	http.SetCookie(w, &http.Cookie{Name: "w_auth_refresh", Value: s.refresh, Path: "/", HttpOnly: true})
+
+	writeJSON(w, http.StatusOK, api.AuthPayload{IsAuth: true, User: s.user()})
+}
+
+func (s *Backend) auth(w http.ResponseWriter, r *http.Request) {
+	if !s.authenticated(r) {
+		writeJSON(w, http.StatusOK, api.AuthPayload{IsAuth: false})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, api.AuthPayload{IsAuth: true, User: s.user()})
+}
+
+func (s *Backend) logout(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Backend) reservations(w http.ResponseWriter, r *http.Request) {
+	perPage, err := strconv.Atoi(r.URL.Query().Get("PerPage"))
+	if err != nil || perPage <= 0 {
+		perPage = 5
+	}
+
+	now := time.Now()
+	var data []api.Reservation
+
+	for _, b := range s.bookings {
+		if !b.Mine || !b.End.After(now) {
+			continue
+		}
+
+		data = append(data, api.Reservation{
+			OrderResourceRentId: b.Id,
+			ItemName:            s.room(b.RoomId).Name,
+			Start:               b.Start.In(s.location).Format(dateFormat),
+			End:                 b.End.In(s.location).Format(dateFormat),
+			Credits:             b.Credits,
+		})
+	}
+
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].Start < data[j].Start
+	})
+
+	total := len(data)
+
+	if len(data) > perPage {
+		data = data[:perPage]
+	}
+
+	writeJSON(w, http.StatusOK, api.FutureBookingsResponse{Total: total, Data: data})
+}
+
+// items lists every room, or the ones available for the capacity and
+// datewithhours query parameters when given.
+func (s *Backend) items(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	rooms := s.rooms
+
+	if raw := query.Get("datewithhours"); raw != "" {
+		var period api.DateTimePayload
+
+		if err := json.Unmarshal([]byte(raw), &period); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"Message": err.Error()})
+			return
+		}
+
+		start, errStart := time.Parse(time.RFC3339, period.Start)
+		end, errEnd := time.Parse(time.RFC3339, period.End)
+
+		if errStart != nil || errEnd != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"Message": "invalid datewithhours"})
+			return
+		}
+
+		capacity, _ := strconv.Atoi(query.Get("capacity"))
+		rooms = nil
+
+		for _, room := range s.rooms {
+			if room.NbUsers >= capacity && s.free(room.Id, start, end) {
+				rooms = append(rooms, room)
+			}
+		}
+	}
+
+	response := api.AvailableRoomsResponse{
+		VisitedItems:   []api.RoomResponse{},
+		UnvisitedItems: make([]api.RoomResponse, 0, len(rooms)),
+	}
+
+	for _, room := range rooms {
+		response.UnvisitedItems = append(response.UnvisitedItems, api.RoomResponse{
+			Id:          room.Id,
+			Name:        room.Name,
+			NbUsers:     room.NbUsers,
+			Prices:      []api.PriceResponse{{Credits: room.Price}},
+			Image:       api.RoomImage{Url: room.Image},
+			Description: room.Description,
+			Floor:       api.LenientString(room.Floor),
+			Equipments:  equipments(room.Equipment),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// equipments describes the equipment as Cosoft does.
+func equipments(names []string) []api.RoomEquipment {
+	equipments := make([]api.RoomEquipment, len(names))
+
+	for i, name := range names {
+		equipments[i] = api.RoomEquipment{Name: name}
+	}
+
+	return equipments
+}
+
+func (s *Backend) busyTimes(w http.ResponseWriter, r *http.Request) {
+	var filter struct {
+		StartDate time.Time `json:"startDate"`
+		EndDate   time.Time `json:"endDate"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&filter); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"Message": err.Error()})
+		return
+	}
+
+	roomId := r.PathValue("item")
+	data := []map[string]string{}
+
+	for _, b := range s.bookings {
+		if b.RoomId != roomId || !b.Start.Before(filter.EndDate) || !b.End.After(filter.StartDate) {
+			continue
+		}
+
+		data = append(data, map[string]string{
+			"Title": "Réservé",
+			"Start": b.Start.In(s.location).Format(dateFormat),
+			"End":   b.End.In(s.location).Format(dateFormat),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"data": data})
+}
+
+func (s *Backend) pay(w http.ResponseWriter, r *http.Request) {
+	var payload api.RoomBookingPayload
+
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"Message": err.Error()})
+		return
+	}
+
+	if len(payload.Cart) != 1 || len(payload.Cart[0].DateTime) != 1 {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"Message": "one item expected in the cart"})
+		return
+	}
+
+	item := payload.Cart[0]
+	room := s.room(item.ItemId)
+
+	if room == nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"Message": "Item not found"})
+		return
+	}
+
+	start, errStart := time.Parse(time.RFC3339, item.DateTime[0].Start)
+	end, errEnd := time.Parse(time.RFC3339, item.DateTime[0].End)
+
+	if errStart != nil || errEnd != nil || !end.After(start) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"Message": "invalid period"})
+		return
+	}
+
+	if !s.free(room.Id, start, end) {
+		writeJSON(w, http.StatusConflict, map[string]string{"Message": "Item is not available"})
+		return
+	}
+
+	cost := room.Price * end.Sub(start).Hours()
+
+	if cost > s.credits {
+		writeJSON(w, http.StatusPaymentRequired, map[string]string{"Message": "Not enough credits"})
+		return
+	}
+
+	s.credits -= cost
+	s.bookings = append(s.bookings, Booking{
+		Id:      uuid.NewString(),
+		RoomId:  room.Id,
+		Start:   start,
+		End:     end,
+		Credits: room.Price,
+		Mine:    true,
+	})
+
+	if err := s.save(); err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"Message": err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func (s *Backend) cancel(w http.ResponseWriter, r *http.Request) {
+	var payload api.CancellationPayload
+
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"Message": err.Error()})
+		return
+	}
+
+	for i, b := range s.bookings {
+		if b.Id != payload.Id || !b.Mine {
+			continue
+		}
+
+		if !b.End.After(time.Now()) {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"Message": "Reservation already over"})
+			return
+		}
+
+		s.credits += b.Credits * b.End.Sub(b.Start).Hours()
+		s.bookings = append(s.bookings[:i], s.bookings[i+1:]...)
+
+		if err := s.save(); err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"Message": err.Error()})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+		return
+	}
+
+	writeJSON(w, http.StatusNotFound, map[string]string{"Message": "Reservation not found"})
+}
+
+func (s *Backend) room(id string) *Room {
+	for _, room := range s.rooms {
+		if room.Id == id {
+			return &room
+		}
+	}
+
+	return nil
+}
+
+func (s *Backend) free(roomId string, start, end time.Time) bool {
+	for _, b := range s.bookings {
+		if b.RoomId == roomId && b.Start.Before(end) && b.End.After(start) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func randomToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return fmt.Sprintf("%x", b)
+}
@@ -0,0 +1,97 @@
+package sandbox
+
+import (
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/common"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Rooms are the sandbox's rooms, loosely based on HUB612's.
+var Rooms = []Room{
+	{Id: "5a4d0b1e-0000-4000-8000-000000000001", Name: "Salle Bleue", NbUsers: 2, Price: 10, Floor: "1", Equipment: []string{"Écran 32 pouces", "Tableau blanc"}},
+	{Id: "5a4d0b1e-0000-4000-8000-000000000002", Name: "Salle Verte", NbUsers: 1, Price: 6, Floor: "1", Description: "Cabine calme pour les appels."},
+	{Id: "5a4d0b1e-0000-4000-8000-000000000003", Name: "Salle Rouge", NbUsers: 2, Price: 12, Floor: "2", Equipment: []string{"Écran", "Kit de visioconférence"}},
+	{Id: "5a4d0b1e-0000-4000-8000-000000000004", Name: "Salle Jaune", NbUsers: 4, Price: 16, Floor: "2", Equipment: []string{"Écran", "Tableau blanc", "Kit de visioconférence"}},
+	{Id: "5a4d0b1e-0000-4000-8000-000000000005", Name: "Bocal", NbUsers: 1, Price: 4, Floor: "0"},
+	{Id: "5a4d0b1e-0000-4000-8000-000000000006", Name: "Auditorium", NbUsers: 8, Price: 30, Floor: "0", Description: "Grande salle avec vidéoprojecteur et sonorisation."},
+}
+
+// Credits is the virtual balance the sandbox starts with.
+const Credits = 200
+
+// Enabled reports whether COSOFT_SANDBOX is set, which --sandbox does too.
+func Enabled() bool {
+	v := os.Getenv("COSOFT_SANDBOX")
+
+	return v == "1" || v == "true"
+}
+
+// Seeded returns a backend with the sandbox rooms, occupied by other people
+// at various times for the coming week, and a virtual credit balance. Any
+// credentials are accepted, and the tokens don't change from one run to
+// another.
+func Seeded() (*Backend, error) {
+	b, err := New(Options{
+		Rooms:     Rooms,
+		Credits:   Credits,
+		FirstName: "Sandbox",
+		LastName:  "User",
+		Email:     "sandbox@example.com",
+		AnyLogin:  true,
+		Token:     "sandbox-w-auth",
+		Refresh:   "sandbox-w-auth-refresh",
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := common.LoadLocalTime()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(location)
+
+	// Spread the other people's meetings between 8:00 and 18:00, so that
+	// every room has free and busy slots every day. They depend on the date
+	// only, so that the user's saved bookings never overlap them.
+	for day := 0; day < 7; day++ {
+		date := time.Date(now.Year(), now.Month(), now.Day()+day, 0, 0, 0, 0, location)
+		n := date.YearDay()
+
+		for i, room := range Rooms {
+			first := time.Date(date.Year(), date.Month(), date.Day(), 8, (i*75+n*45)%240, 0, 0, location)
+			second := first.Add(time.Duration(150+(i*30)%120) * time.Minute)
+
+			b.Occupy(room.Id, first, first.Add(time.Duration(30+(i+n)%3*30)*time.Minute))
+			b.Occupy(room.Id, second, second.Add(time.Duration(45+(i*n)%2*45)*time.Minute))
+		}
+	}
+
+	return b, nil
+}
+
+// Start serves backend on a local port and points the api package to it. The
+// returned function stops it.
+func Start(backend http.Handler) (func(), error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	server := &http.Server{Handler: backend}
+
+	go server.Serve(listener)
+
+	previous := api.SetBaseUrl(fmt.Sprintf("http://%s", listener.Addr().String()))
+
+	return func() {
+		api.SetBaseUrl(previous)
+		server.Close()
+	}, nil
+}
@@ -0,0 +1,129 @@
+package sandbox
+
+import (
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/common"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSeeded(t *testing.T) {
+	backend, err := Seeded()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stop, err := Start(backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(stop)
+
+	a := api.NewApi()
+
+	user, err := a.Login(&api.LoginPayload{Email: "someone@example.com", Password: "anything"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Email != "someone@example.com" || user.Credits != Credits {
+		t.Errorf("Login() = %+v, want someone@example.com with %d credits", user, Credits)
+	}
+
+	rooms, err := a.GetAllRooms(user.JwtToken, user.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rooms) != len(Rooms) {
+		t.Fatalf("GetAllRooms() = %d rooms, want %d", len(rooms), len(Rooms))
+	}
+
+	location, err := common.LoadLocalTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tomorrow := time.Now().In(location).AddDate(0, 0, 1)
+
+	for _, room := range Rooms {
+		busy, err := a.GetRoomBusyTime(user.JwtToken, user.RefreshToken, room.Id, tomorrow, location)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(*busy) == 0 {
+			t.Errorf("%s has no busy times tomorrow", room.Name)
+		}
+	}
+}
+
+func TestPersisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sandbox.json")
+
+	// start serves a new backend from path, as every CLI command does.
+	start := func() (*api.Api, *api.UserResponse) {
+		t.Helper()
+
+		backend, err := Persisted(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		stop, err := Start(backend)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(stop)
+
+		a := api.NewApi()
+
+		user, err := a.Login(&api.LoginPayload{Email: "someone@example.com", Password: "anything"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return a, user
+	}
+
+	a, user := start()
+
+	rooms, err := a.GetAllRooms(user.JwtToken, user.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	location, err := common.LoadLocalTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().In(location)
+	// Every room is free at 7:00, before the seeded meetings.
+	at := time.Date(now.Year(), now.Month(), now.Day()+1, 7, 0, 0, 0, location)
+
+	err = a.BookRoom(user.JwtToken, user.RefreshToken, api.CosoftBookingPayload{
+		CosoftAvailabilityPayload: api.CosoftAvailabilityPayload{DateTime: at, Duration: 60, NbPeople: 1},
+		UserCredits:               user.Credits,
+		Room:                      rooms[0],
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, user = start()
+
+	bookings, err := a.GetFutureBookings(user.JwtToken, user.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bookings.Data) != 1 || bookings.Data[0].ItemName != rooms[0].Name {
+		t.Errorf("GetFutureBookings() = %+v, want the booking of the previous run", bookings.Data)
+	}
+
+	if want := Credits - rooms[0].Price; user.Credits != want {
+		t.Errorf("credits = %v, want %v", user.Credits, want)
+	}
+}
@@ -0,0 +1,96 @@
+package sandbox
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// state is what the sandbox remembers from one run to another: the user's
+// bookings and credit balance. Other people's meetings are seeded again.
+type state struct {
+	Credits  float64   `json:"credits"`
+	Bookings []Booking `json:"bookings"`
+}
+
+// Persisted returns a Seeded backend which keeps the user's bookings and
+// credits in the JSON file at path, so that they carry over from one command
+// to the next. The file is read before every request, and written after
+// every payment or cancellation.
+func Persisted(path string) (*Backend, error) {
+	b, err := Seeded()
+	if err != nil {
+		return nil, err
+	}
+
+	b.statePath = path
+
+	if err := b.load(); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// load replaces the user's bookings and credits with the saved ones, if any.
+func (s *Backend) load() error {
+	if s.statePath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.statePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var saved state
+
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+
+	bookings := saved.Bookings
+
+	for _, b := range s.bookings {
+		if !b.Mine {
+			bookings = append(bookings, b)
+		}
+	}
+
+	s.credits = saved.Credits
+	s.bookings = bookings
+
+	return nil
+}
+
+// save writes the user's bookings and credits to the state file.
+func (s *Backend) save() error {
+	if s.statePath == "" {
+		return nil
+	}
+
+	saved := state{Credits: s.credits, Bookings: []Booking{}}
+
+	for _, b := range s.bookings {
+		if b.Mine {
+			saved.Bookings = append(saved.Bookings, b)
+		}
+	}
+
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.statePath), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.statePath, data, 0644)
+}
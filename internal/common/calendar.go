@@ -2,12 +2,11 @@ package common
 
 import (
 	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/theme"
 	"cosoft-cli/shared/models"
 	"fmt"
 	"strings"
 	"time"
-
-	"github.com/charmbracelet/lipgloss"
 )
 
 func BuildCalendar(
@@ -37,10 +36,7 @@ func createCalendarHeader(labelLength, displayedHours int) string {
 	result := ""
 
 	for i := 0; i < displayedHours; i++ {
-		if i+8 < 10 {
-			result += "0"
-		}
-		result += fmt.Sprintf("%dh%s", i+8, strings.Repeat(" ", spacing))
+		result += fmt.Sprintf("%02dh%s", i+OpeningHour, strings.Repeat(" ", spacing))
 	}
 
 	return strings.Repeat(" ", labelLength-1) + result
@@ -89,7 +85,14 @@ func createCalendarRow(
 
 	now := GetClosestQuarterHour()
 
-	year, month, day := slots[0].Start.Date()
+	// Free rooms have no slot to tell the day from, which only matters to
+	// highlight the current time.
+	year, month, day := now.Date()
+
+	if len(slots) > 0 {
+		year, month, day = slots[0].Start.Date()
+	}
+
 	baseDate := time.Date(year, month, day, 0, 0, 0, 0, location)
 	startTime := baseDate.Add(8 * time.Hour)
 	endTime := baseDate.Add(23 * time.Hour)
@@ -119,11 +122,15 @@ func createCalendarRow(
 		symbol := " "
 
 		if occupied {
-			symbol = "░"
+			symbol = theme.Current().Busy()
+		}
+
+		if row.Unknown {
+			symbol = theme.Current().Unknown()
 		}
 
 		if ownReservation {
-			symbol = "█"
+			symbol = theme.Current().Own()
 		}
 
 		isNow := current.Equal(now)
@@ -131,8 +138,8 @@ func createCalendarRow(
 		nextIsNow := nextSlot.Equal(now)
 
 		if isNow {
-			// If current time, color the cell's background in red,
-			symbol = lipgloss.NewStyle().Background(lipgloss.Color("#f45656")).Render(symbol)
+			// If current time, highlight the cell,
+			symbol = theme.Current().CurrentSlot().Render(symbol)
 		}
 
 		if counter%4 == 3 && !nextIsNow {
@@ -0,0 +1,39 @@
+package common
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Notify raises a desktop notification using the tools shipped with each OS,
+// so no graphical dependency is needed.
+func Notify(title, message string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf(
+			"display notification %q with title %q",
+			message,
+			title,
+		)
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		script := fmt.Sprintf(
+			`[reflection.assembly]::loadwithpartialname('System.Windows.Forms') | Out-Null;`+
+				`$n = New-Object System.Windows.Forms.NotifyIcon;`+
+				`$n.Icon = [System.Drawing.SystemIcons]::Information;`+
+				`$n.Visible = $true;`+
+				`$n.ShowBalloonTip(10000, '%s', '%s', 'Info')`,
+			strings.ReplaceAll(title, "'", "''"),
+			strings.ReplaceAll(message, "'", "''"),
+		)
+		cmd = exec.Command("powershell", "-NoProfile", "-Command", script)
+	default:
+		cmd = exec.Command("notify-send", "--app-name=cosoft", title, message)
+	}
+
+	return cmd.Run()
+}
@@ -0,0 +1,36 @@
+package common
+
+import (
+	"cosoft-cli/internal/api"
+	"time"
+)
+
+// ReminderRetention is how long sent reminders are remembered, well after
+// their reservation started.
+const ReminderRetention = 24 * time.Hour
+
+// DueReminders returns the reservations starting within lead of now, which
+// haven't started yet.
+func DueReminders(
+	reservations []api.Reservation,
+	lead time.Duration,
+	now time.Time,
+) []api.Reservation {
+	location, _ := LoadLocalTime()
+
+	var due []api.Reservation
+
+	for _, r := range reservations {
+		start, err := time.ParseInLocation("2006-01-02T15:04:05", r.Start, location)
+
+		if err != nil {
+			continue
+		}
+
+		if start.After(now) && !start.After(now.Add(lead)) {
+			due = append(due, r)
+		}
+	}
+
+	return due
+}
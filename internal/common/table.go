@@ -1,6 +1,7 @@
 package common
 
 import (
+	"cosoft-cli/internal/theme"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
 )
@@ -8,17 +9,19 @@ import (
 type Common struct{}
 
 func CreateTable(header []string, rows [][]string) string {
+	colors := theme.Current()
+
 	t := table.New().
 		Border(lipgloss.NormalBorder()).
-		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("#fd4b4b"))).
+		BorderStyle(colors.Fg(colors.Colors.Accent)).
 		StyleFunc(func(row, col int) lipgloss.Style {
 			switch {
 			case row == table.HeaderRow:
-				return lipgloss.NewStyle().Foreground(lipgloss.Color("#fd4b4b")).Bold(true).Align(lipgloss.Center)
+				return colors.Fg(colors.Colors.Accent).Bold(true).Align(lipgloss.Center)
 			case col == 1:
-				return lipgloss.NewStyle().Padding(0, 1).Width(20).Foreground(lipgloss.Color("245"))
+				return colors.Muted().Padding(0, 1).Width(20)
 			default:
-				return lipgloss.NewStyle().Padding(0, 1).Width(14).Foreground(lipgloss.Color("245"))
+				return colors.Muted().Padding(0, 1).Width(14)
 			}
 		}).
 		Headers(header...)
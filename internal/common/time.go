@@ -6,6 +6,16 @@ import (
 	"time"
 )
 
+// The opening hours of the coworking, which the calendars display and the
+// suggested slots fit in.
+const (
+	OpeningHour = 8
+	ClosingHour = 24
+)
+
+// MaxBookingMinutes is the longest booking Cosoft allows.
+const MaxBookingMinutes = 120
+
 func GetClosestQuarterHour() time.Time {
 	now := time.Now()
 	currentHour := now.Hour()
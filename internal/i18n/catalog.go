@@ -0,0 +1,20 @@
+package i18n
+
+// message holds the translations of a message, as fmt formats.
+type message map[Locale]string
+
+// catalog holds every message, by key. Keys are prefixed by where they are
+// displayed: cli, tui or slack.
+var catalog = merge(cliMessages, tuiMessages, slackMessages)
+
+func merge(catalogs ...map[string]message) map[string]message {
+	merged := map[string]message{}
+
+	for _, c := range catalogs {
+		for key, m := range c {
+			merged[key] = m
+		}
+	}
+
+	return merged
+}
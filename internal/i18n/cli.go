@@ -0,0 +1,57 @@
+package i18n
+
+var cliMessages = map[string]message{
+	"cli.sandbox":     {English: "Sandbox mode: nothing is booked or spent on the real Cosoft.", French: "Mode sandbox : rien n'est réservé ni dépensé sur le vrai Cosoft."},
+	"cli.error":       {English: "Error: %v", French: "Erreur : %v"},
+	"cli.auth_failed": {English: "authentication cancelled or failed", French: "connexion annulée ou échouée"},
+
+	"cli.book.too_many":        {English: "Too many users, defaulting to 2.", French: "Trop de personnes, 2 retenues."},
+	"cli.book.complete":        {English: "✓ Booking complete!", French: "✓ Réservation réussie !"},
+	"cli.book.dry_run":         {English: "Dry run, nothing was booked:", French: "Simulation, rien n'a été réservé :"},
+	"cli.book.unknown_feature": {English: "Unknown feature %q, pick among: %s", French: "Équipement %q inconnu, choisissez parmi : %s"},
+
+	"cli.table.id":       {English: "ID", French: "ID"},
+	"cli.table.room":     {English: "ROOM", French: "SALLE"},
+	"cli.table.start":    {English: "START", French: "DÉBUT"},
+	"cli.table.end":      {English: "END", French: "FIN"},
+	"cli.table.duration": {English: "DURATION", French: "DURÉE"},
+	"cli.table.cost":     {English: "COST", French: "COÛT"},
+	"cli.table.name":     {English: "NAME", French: "NOM"},
+	"cli.table.capacity": {English: "CAPACITY", French: "CAPACITÉ"},
+	"cli.table.price":    {English: "PRICE", French: "PRIX"},
+	"cli.credits":        {English: "%.2f credits", French: "%.2f crédits"},
+	"cli.people":         {English: "%d person(s)", French: "%d personne(s)"},
+
+	"cli.reservations.dry_run_cancel": {English: "Dry run, would cancel %s (%s), refunding %.02f credits.", French: "Simulation, %s (%s) serait annulée, %.02f crédits remboursés."},
+	"cli.reservations.cancelled":      {English: "%s (%s) cancelled, %.02f credits refunded.", French: "%s (%s) annulée, %.02f crédits remboursés."},
+	"cli.reservations.by_positive":    {English: "--by must be a positive number of minutes", French: "--by doit être un nombre positif de minutes"},
+	"cli.reservations.dry_run_spend":  {English: "Dry run, %s would end at %s, spending %.02f credits.", French: "Simulation, %s se terminerait à %s, %.02f crédits dépensés."},
+	"cli.reservations.dry_run_refund": {English: "Dry run, %s would end at %s, refunding %.02f credits.", French: "Simulation, %s se terminerait à %s, %.02f crédits remboursés."},
+	"cli.reservations.spent":          {English: "%s now ends at %s, %.02f credits spent.", French: "%s se termine maintenant à %s, %.02f crédits dépensés."},
+	"cli.reservations.refunded":       {English: "%s now ends at %s, %.02f credits refunded.", French: "%s se termine maintenant à %s, %.02f crédits remboursés."},
+
+	"cli.daemon.disabled": {English: "Reminders are disabled, run with --lead to enable them.", French: "Les rappels sont désactivés, lancez avec --lead pour les activer."},
+	"cli.daemon.watching": {English: "Watching reservations, you'll be notified %d minutes before they start.", French: "Surveillance des réservations, vous serez prévenu %d minutes avant leur début."},
+	"cli.daemon.starts":   {English: "%s starts at %s", French: "%s commence à %s"},
+
+	"cli.admin.slack_id":      {English: "SLACK ID", French: "ID SLACK"},
+	"cli.admin.email":         {English: "EMAIL", French: "EMAIL"},
+	"cli.admin.credits":       {English: "CREDITS", French: "CRÉDITS"},
+	"cli.admin.last_seen":     {English: "LAST SEEN", French: "DERNIÈRE VISITE"},
+	"cli.admin.token":         {English: "TOKEN", French: "JETON"},
+	"cli.admin.never":         {English: "never", French: "jamais"},
+	"cli.admin.token_ok":      {English: "OK", French: "valide"},
+	"cli.admin.no_user":       {English: "Nobody is logged in.", French: "Personne n'est connecté."},
+	"cli.admin.logged_out":    {English: "%s has been logged out.", French: "%s a été déconnecté."},
+	"cli.admin.not_logged_in": {English: "%s wasn't logged in.", French: "%s n'était pas connecté."},
+	"cli.admin.purged":        {English: "%d views older than %d days purged.", French: "%d vues de plus de %d jours supprimées."},
+	"cli.admin.exported":      {English: "Database exported to %s.", French: "Base de données exportée vers %s."},
+	"cli.admin.imported":      {English: "Database imported from %s.", French: "Base de données importée depuis %s."},
+
+	"cli.rooms.unchanged":         {English: "The rooms did not change.", French: "Les salles n'ont pas changé."},
+	"cli.rooms.added":             {English: "New room: %s, %.2f credits an hour.", French: "Nouvelle salle : %s, %.2f crédits de l'heure."},
+	"cli.rooms.removed":           {English: "Removed room: %s.", French: "Salle retirée : %s."},
+	"cli.rooms.renamed":           {English: "%s is now called %s.", French: "%s s'appelle maintenant %s."},
+	"cli.rooms.repriced":          {English: "%s now costs %.2f credits an hour, instead of %.2f.", French: "%s coûte maintenant %.2f crédits de l'heure, au lieu de %.2f."},
+	"cli.rooms.favorite_repriced": {English: "★ Your favorite room %s now costs %.2f credits an hour, instead of %.2f.", French: "★ Votre salle favorite %s coûte maintenant %.2f crédits de l'heure, au lieu de %.2f."},
+}
@@ -0,0 +1,57 @@
+package i18n
+
+import (
+	"fmt"
+	"time"
+)
+
+// layouts are the time layouts of each locale. English follows the US, since
+// Go only knows English month and day names.
+var layouts = map[Locale]struct {
+	date, time, dateTime string
+}{
+	English: {date: "01/02/2006", time: "3:04 PM", dateTime: "01/02/2006 3:04 PM"},
+	French:  {date: "02/01/2006", time: "15:04", dateTime: "02/01/2006 15:04"},
+}
+
+var (
+	frenchDays = [...]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"}
+
+	frenchMonths = [...]string{
+		"janvier", "février", "mars", "avril", "mai", "juin",
+		"juillet", "août", "septembre", "octobre", "novembre", "décembre",
+	}
+)
+
+// Date formats t as a short date, e.g. 10/20/2026 or 20/10/2026.
+func (l Locale) Date(t time.Time) string {
+	return t.Format(l.layouts().date)
+}
+
+// Time formats t's time of day, e.g. 2:30 PM or 14:30.
+func (l Locale) Time(t time.Time) string {
+	return t.Format(l.layouts().time)
+}
+
+// DateTime formats t as a short date followed by its time of day.
+func (l Locale) DateTime(t time.Time) string {
+	return t.Format(l.layouts().dateTime)
+}
+
+// Day formats t's date with the day's and month's names, e.g. Tuesday,
+// October 20 or mardi 20 octobre.
+func (l Locale) Day(t time.Time) string {
+	if l == French {
+		return fmt.Sprintf("%s %d %s", frenchDays[t.Weekday()], t.Day(), frenchMonths[t.Month()-1])
+	}
+
+	return t.Format("Monday, January 2")
+}
+
+func (l Locale) layouts() struct{ date, time, dateTime string } {
+	if layout, ok := layouts[l]; ok {
+		return layout
+	}
+
+	return layouts[Locales[0]]
+}
@@ -0,0 +1,120 @@
+// Package i18n holds the messages displayed by the CLI, the TUI and the Slack
+// bot in every supported language, and formats dates the way each language
+// does.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Locale is a supported language, as its ISO 639-1 code.
+type Locale string
+
+const (
+	English Locale = "en"
+	French  Locale = "fr"
+)
+
+// Locales lists the supported languages, the first one being the fallback
+// of missing translations.
+var Locales = []Locale{English, French}
+
+// Name returns the language's name, in that language.
+func (l Locale) Name() string {
+	switch l {
+	case French:
+		return "Français"
+	default:
+		return "English"
+	}
+}
+
+// Parse reads a locale from a POSIX locale ("fr_FR.UTF-8"), a BCP 47 tag
+// ("fr-FR", as Slack gives them) or a bare language code. It is false for
+// unsupported languages and for "C" or "POSIX".
+func Parse(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	tag, _, _ = strings.Cut(tag, ".")
+	tag, _, _ = strings.Cut(tag, "@")
+	language, _, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+
+	for _, l := range Locales {
+		if string(l) == language {
+			return l, true
+		}
+	}
+
+	return "", false
+}
+
+// Pick returns the locale of the first tag that Parse accepts, English when
+// none does. Tags go from the most to the least specific, e.g. the user's
+// setting then the environment.
+func Pick(tags ...string) Locale {
+	for _, tag := range tags {
+		if l, ok := Parse(tag); ok {
+			return l
+		}
+	}
+
+	return English
+}
+
+// Env returns the locale the environment asks for, following the POSIX
+// precedence of LC_ALL, LC_MESSAGES and LANG.
+func Env() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// T returns the message identified by key in l, formatted with args. Missing
+// translations fall back to English, and unknown keys are returned as is.
+func (l Locale) T(key string, args ...any) string {
+	message, ok := catalog[key]
+	if !ok {
+		return key
+	}
+
+	text, ok := message[l]
+	if !ok {
+		text = message[Locales[0]]
+	}
+
+	if len(args) == 0 {
+		return text
+	}
+
+	return fmt.Sprintf(text, args...)
+}
+
+var (
+	mu      sync.RWMutex
+	current = English
+)
+
+// SetDefault sets the locale of the CLI and TUI, see T.
+func SetDefault(l Locale) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = l
+}
+
+// Default returns the locale of the CLI and TUI.
+func Default() Locale {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// T returns the message identified by key in the default locale.
+func T(key string, args ...any) string {
+	return Default().T(key, args...)
+}
@@ -0,0 +1,129 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		tag    string
+		want   Locale
+		wantOk bool
+	}{
+		{name: "posix", tag: "fr_FR.UTF-8", want: French, wantOk: true},
+		{name: "posix_modifier", tag: "fr_FR@euro", want: French, wantOk: true},
+		{name: "bcp47", tag: "en-US", want: English, wantOk: true},
+		{name: "bare", tag: "FR", want: French, wantOk: true},
+		{name: "c", tag: "C.UTF-8", wantOk: false},
+		{name: "posix_default", tag: "POSIX", wantOk: false},
+		{name: "unsupported", tag: "de_DE.UTF-8", wantOk: false},
+		{name: "empty", tag: "", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Parse(tt.tag)
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf("Parse(%q) = %q, %v, want %q, %v", tt.tag, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestPick(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want Locale
+	}{
+		{name: "setting_first", tags: []string{"en", "fr_FR.UTF-8"}, want: English},
+		{name: "setting_empty", tags: []string{"", "fr_FR.UTF-8"}, want: French},
+		{name: "unsupported_skipped", tags: []string{"de-DE", "fr-FR"}, want: French},
+		{name: "fallback", tags: []string{"", "C"}, want: English},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Pick(tt.tags...); got != tt.want {
+				t.Errorf("Pick(%q) = %q, want %q", tt.tags, got, tt.want)
+			}
+		})
+	}
+}
+
+var verb = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+// TestCatalog makes sure every message is translated, with the same
+// arguments in every language.
+func TestCatalog(t *testing.T) {
+	for key, message := range catalog {
+		want := verb.FindAllString(message[Locales[0]], -1)
+
+		for _, l := range Locales {
+			text, ok := message[l]
+			if !ok || text == "" {
+				t.Errorf("%s has no %s translation", key, l.Name())
+				continue
+			}
+
+			if got := verb.FindAllString(text, -1); !equal(got, want) {
+				t.Errorf("%s in %s formats %q, want %q", key, l.Name(), got, want)
+			}
+		}
+	}
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestT(t *testing.T) {
+	if got := French.T("slack.credits", 2.5); got != "2.50 crédits" {
+		t.Errorf("French.T() = %q, want %q", got, "2.50 crédits")
+	}
+
+	if got := Locale("de").T("slack.credits", 2.5); got != "2.50 credits" {
+		t.Errorf("T() without translation = %q, want the English one", got)
+	}
+
+	if got := English.T("unknown.key"); got != "unknown.key" {
+		t.Errorf("T() of an unknown key = %q, want the key", got)
+	}
+}
+
+func TestDates(t *testing.T) {
+	date := time.Date(2026, 10, 20, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		locale Locale
+		format func(Locale, time.Time) string
+		want   string
+	}{
+		{name: "date_en", locale: English, format: Locale.Date, want: "10/20/2026"},
+		{name: "date_fr", locale: French, format: Locale.Date, want: "20/10/2026"},
+		{name: "time_en", locale: English, format: Locale.Time, want: "2:30 PM"},
+		{name: "time_fr", locale: French, format: Locale.Time, want: "14:30"},
+		{name: "date_time_fr", locale: French, format: Locale.DateTime, want: "20/10/2026 14:30"},
+		{name: "day_en", locale: English, format: Locale.Day, want: "Tuesday, October 20"},
+		{name: "day_fr", locale: French, format: Locale.Day, want: "mardi 20 octobre"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.format(tt.locale, date); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
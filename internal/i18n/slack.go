@@ -0,0 +1,257 @@
+package i18n
+
+var slackMessages = map[string]message{
+	"slack.back":                 {English: "Back", French: "Retour"},
+	"slack.back_to_landing":      {English: "Back to the menu", French: "Retour à l'accueil"},
+	"slack.back_to_landing_hint": {English: "You can now go back to the menu", French: "Vous pouvez maintenant revenir à l'accueil"},
+	"slack.book":                 {English: "Book", French: "Réserver"},
+	"slack.booking_success":      {English: ":white_check_mark: *Booking complete!*", French: ":white_check_mark: *Réservation réussie !*"},
+	"slack.credits":              {English: "%.2f credits", French: "%.2f crédits"},
+	"slack.date_in_future":       {English: "Please pick a date in the future", French: "Veuillez choisir une date dans le futur"},
+	"slack.fields_required":      {English: "All fields are required", French: "Tous les champs sont requis"},
+	"slack.time_on_quarter":      {English: "Please pick a quarter hour (2:00 PM, 3:15 PM, 4:30 PM, 5:45 PM...)", French: "Veuillez choisir un quart d'heure (14h00, 15h15, 16h30, 17h45....)"},
+
+	"slack.browse.title":          {English: "Book a room", French: "Réserver une salle"},
+	"slack.browse.search":         {English: "Search", French: "Rechercher"},
+	"slack.browse.no_room":        {English: "*No room available*\nPlease change your filters", French: "*Aucune salle disponible*\nVeuillez changer vos filtres"},
+	"slack.browse.found":          {English: "%d rooms found", French: "%d salles ont été trouvées"},
+	"slack.browse.criteria":       {English: "%s → %s — %d people", French: "%s → %s — %d personnes"},
+	"slack.browse.pick_room":      {English: "Pick a room", French: "Sélectionez une salle"},
+	"slack.browse.room":           {English: "Room", French: "Salle"},
+	"slack.browse.change_filters": {English: "Change the filters", French: "Modifier les filtres"},
+	"slack.browse.suggestions":    {English: "*Suggestions*\nClosest free slots:", French: "*Suggestions*\nCréneaux libres les plus proches :"},
+	"slack.browse.choose":         {English: "Choose", French: "Choisir"},
+
+	"slack.loading": {English: ":hourglass_flowing_sand: Loading...", French: ":hourglass_flowing_sand: Chargement en cours..."},
+
+	"slack.progress.elapsed": {English: ":hourglass_flowing_sand: Loading... (%ds)", French: ":hourglass_flowing_sand: Chargement en cours... (%ds)"},
+	"slack.progress.steps":   {English: ":hourglass_flowing_sand: Loading... %d/%d (%ds)", French: ":hourglass_flowing_sand: Chargement en cours... %d/%d (%ds)"},
+	"slack.progress.timeout": {English: ":snail: Cosoft is taking longer than usual to answer.", French: ":snail: Cosoft met plus de temps que d'habitude à répondre."},
+	"slack.progress.retry":   {English: "Try again", French: "Réessayer"},
+
+	"slack.quick_book.title":   {English: "Quick booking", French: "Réservation rapide"},
+	"slack.quick_book.booking": {English: "Booking...", French: "Réservation en cours..."},
+	"slack.quick_book.found":   {English: ":large_green_circle: A room was found!", French: ":large_green_circle: Une salle a été trouvée !"},
+
+	"slack.summary.room":     {English: "*Meeting room:*\n%s", French: "*Salle de réunion :*\n%s"},
+	"slack.summary.duration": {English: "*Duration:*\n%s → %s", French: "*Durée :*\n%s → %s"},
+	"slack.summary.cost":     {English: "*Cost:*\n%.2f credits", French: "*Coût :*\n%.2f crédits"},
+
+	"slack.cancel":             {English: "Cancel", French: "Annuler"},
+	"slack.close":              {English: "Close", French: "Fermer"},
+	"slack.cancel_reservation": {English: "Cancel the reservation", French: "Annuler la réservation"},
+	"slack.cancel_success":     {English: ":white_check_mark: *Cancellation complete!*", French: ":white_check_mark: *Annulation réussie !*"},
+	"slack.confirm_cancel":     {English: "Cancel \"%s\"?", French: "Confirmer l'annulation de \"%s\" ?"},
+	"slack.credits_left":       {English: "You have *%.2f* credits left", French: "Il vous reste *%.2f* crédits"},
+	"slack.credits_refunded":   {English: "%.02f credits refunded", French: "%.02f crédits remboursés"},
+	"slack.credits_spent":      {English: "%.02f credits spent", French: "%.02f crédits dépensés"},
+	"slack.logged_in_as":       {English: "You are logged in as *%s %s* (%s)", French: "Vous êtes connecté(e) en tant que *%s %s* (%s)"},
+	"slack.select":             {English: "Select", French: "Sélectionner"},
+
+	"slack.command.usage": {
+		English: "*Usage:*\n" +
+			"• `/book`: open the main menu\n" +
+			"• `/book [date] [time] [duration] [people] [room]`: book right away, " +
+			"e.g. `/book 14:30 1h 2p Salle Bleue`\n" +
+			"    date: `2006-01-02` (today by default)\n" +
+			"    time: `14:30` or `14h30` (next quarter hour by default, 8:00 on another day)\n" +
+			"    duration: `30m`, `1h`, `1h30`, `90m` (30 minutes by default)\n" +
+			"    people: `1p` or `2p` (1 by default)\n" +
+			"    room: the room's name (first available by default)\n" +
+			"• `/book list`: see your reservations\n" +
+			"• `/book cancel next`: cancel your next reservation\n" +
+			"• `/book remind 15`: be notified 15 minutes before each reservation (`/book remind off` to disable)\n" +
+			"• `/book lang en`: speak English (`fr` for French, `auto` to follow Slack's language)\n" +
+			"• `/book team [date] [time] [duration] [people] [room]`: book for the channel's team, which is told where to go and notified if it's cancelled\n" +
+			"• `/book delegate @someone`: allow someone to book with your account (`/book revoke @someone` to stop)\n" +
+			"• `/book as @someone [date] [time] [duration] [people] [room]`: book with the account of someone who allowed you\n" +
+			"• `/book delegations`: see who may book with your account, and the bookings made so",
+		French: "*Utilisation :*\n" +
+			"• `/book` : ouvrir le menu principal\n" +
+			"• `/book [date] [heure] [durée] [personnes] [salle]` : réserver directement, " +
+			"ex. `/book 14:30 1h 2p Salle Bleue`\n" +
+			"    date : `2006-01-02` (aujourd'hui par défaut)\n" +
+			"    heure : `14:30` ou `14h30` (prochain quart d'heure par défaut, 8h00 un autre jour)\n" +
+			"    durée : `30m`, `1h`, `1h30`, `90m` (30 minutes par défaut)\n" +
+			"    personnes : `1p` ou `2p` (1 par défaut)\n" +
+			"    salle : nom de la salle (première disponible par défaut)\n" +
+			"• `/book list` : voir vos réservations\n" +
+			"• `/book cancel next` : annuler votre prochaine réservation\n" +
+			"• `/book remind 15` : être prévenu 15 minutes avant chaque réservation (`/book remind off` pour désactiver)\n" +
+			"• `/book lang fr` : parler français (`en` pour l'anglais, `auto` pour suivre la langue de Slack)\n" +
+			"• `/book team [date] [heure] [durée] [personnes] [salle]` : réserver pour l'équipe du canal, qui sait où aller et est prévenue en cas d'annulation\n" +
+			"• `/book delegate @quelqu'un` : autoriser quelqu'un à réserver avec votre compte (`/book revoke @quelqu'un` pour arrêter)\n" +
+			"• `/book as @quelqu'un [date] [heure] [durée] [personnes] [salle]` : réserver avec le compte de quelqu'un qui vous y autorise\n" +
+			"• `/book delegations` : voir qui peut réserver avec votre compte, et les réservations faites ainsi",
+	},
+	"slack.command.no_argument":       {English: "`%s` takes no argument", French: "`%s` n'accepte pas d'argument"},
+	"slack.command.cancel_next_only":  {English: "only the next reservation can be cancelled: `/book cancel next`", French: "seule l'annulation de la prochaine réservation est possible : `/book cancel next`"},
+	"slack.command.remind_usage":      {English: "give a number of minutes or `off`: `/book remind 15`", French: "précisez un nombre de minutes ou `off` : `/book remind 15`"},
+	"slack.command.invalid_lead_time": {English: "invalid delay: `%s`", French: "délai invalide : `%s`"},
+	"slack.command.lang_usage":        {English: "give a language, `en` or `fr`, or `auto`: `/book lang fr`", French: "précisez une langue, `en` ou `fr`, ou `auto` : `/book lang fr`"},
+	"slack.command.invalid_lang":      {English: "unsupported language: `%s`", French: "langue non prise en charge : `%s`"},
+	"slack.command.invalid_date":      {English: "invalid date: `%s`", French: "date invalide : `%s`"},
+	"slack.command.invalid_time":      {English: "invalid time: `%s`", French: "heure invalide : `%s`"},
+	"slack.command.duration_quarter":  {English: "the duration must be a multiple of 15 minutes", French: "la durée doit être un multiple de 15 minutes"},
+	"slack.command.duration_max":      {English: "the duration can't exceed 2 hours", French: "la durée ne peut pas dépasser 2 heures"},
+	"slack.command.date_in_future":    {English: "the date must be in the future", French: "la date doit être dans le futur"},
+	"slack.command.time_on_quarter":   {English: "the time must be a quarter hour (14:00, 15:15, 16:30, 17:45...)", French: "l'heure doit être un quart d'heure (14:00, 15:15, 16:30, 17:45...)"},
+	"slack.command.reminders_off":     {English: ":no_bell: Reminders disabled.", French: ":no_bell: Rappels désactivés."},
+	"slack.command.reminders_on":      {English: ":bell: You will be notified %d minutes before each reservation.", French: ":bell: Vous serez prévenu %d minutes avant chaque réservation."},
+	"slack.command.nothing_to_cancel": {English: ":information_source: You have no reservation to cancel.", French: ":information_source: Vous n'avez pas de réservation à annuler."},
+	"slack.command.lang_set":          {English: ":speech_balloon: Messages are now in %s.", French: ":speech_balloon: Les messages sont maintenant en %s."},
+	"slack.command.team_usage":        {English: "give the booking's details: `/book team 14:30 1h 2p`", French: "précisez la réservation : `/book team 14:30 1h 2p`"},
+	"slack.command.as_usage":          {English: "mention whose account books, then the booking's details: `/book as @someone 14:30 1h`", French: "mentionnez le compte qui réserve, puis la réservation : `/book as @quelqu'un 14:30 1h`"},
+	"slack.command.delegate_usage":    {English: "mention one person: `/book %s @someone`", French: "mentionnez une personne : `/book %s @quelqu'un`"},
+	"slack.command.invalid_mention":   {English: "`%s` doesn't mention anyone, pick them in Slack's suggestions", French: "`%s` ne mentionne personne, choisissez-le dans les suggestions de Slack"},
+
+	"slack.delegation.book_as":         {English: "Book with the account of", French: "Réserver avec le compte de"},
+	"slack.delegation.myself":          {English: "Myself", French: "Moi-même"},
+	"slack.delegation.booked_for":      {English: ":bust_in_silhouette: Booked with %s's account", French: ":bust_in_silhouette: Réservé avec le compte de %s"},
+	"slack.delegation.nobody":          {English: "nobody", French: "personne"},
+	"slack.delegation.delegates":       {English: "*May book with your account:* %s", French: "*Peuvent réserver avec votre compte :* %s"},
+	"slack.delegation.principals":      {English: "*You may book with the account of:* %s", French: "*Vous pouvez réserver avec le compte de :* %s"},
+	"slack.delegation.audit":           {English: "*Last bookings made on someone's behalf:*\n%s", French: "*Dernières réservations faites pour quelqu'un :*\n%s"},
+	"slack.delegation.audit_line":      {English: "• <@%s> booked *%s*, %s, for <@%s> (%.02f credits)", French: "• <@%s> a réservé *%s*, %s, pour <@%s> (%.02f crédits)"},
+	"slack.delegation.granted":         {English: ":white_check_mark: <@%s> may now book with your account.", French: ":white_check_mark: <@%s> peut maintenant réserver avec votre compte."},
+	"slack.delegation.revoked":         {English: ":white_check_mark: <@%s> may no longer book with your account.", French: ":white_check_mark: <@%s> ne peut plus réserver avec votre compte."},
+	"slack.delegation.not_delegate":    {English: ":information_source: <@%s> couldn't book with your account.", French: ":information_source: <@%s> ne pouvait pas réserver avec votre compte."},
+	"slack.delegation.self":            {English: ":information_source: You can already book with your own account.", French: ":information_source: Vous pouvez déjà réserver avec votre propre compte."},
+	"slack.delegation.granted_notice":  {English: ":key: <@%s> allowed you to book with their account: pick them in the booking forms, or `/book as <@%s>`.", French: ":key: <@%s> vous autorise à réserver avec son compte : choisissez-le dans les formulaires de réservation, ou `/book as <@%s>`."},
+	"slack.delegation.booking_notice":  {English: ":bust_in_silhouette: <@%s> booked *%s* with your account, %s → %s (%.02f credits).", French: ":bust_in_silhouette: <@%s> a réservé *%s* avec votre compte, %s → %s (%.02f crédits)."},
+	"slack.error.not_delegate":         {English: ":red_circle: <@%s> hasn't allowed you to book with their account", French: ":red_circle: <@%s> ne vous a pas autorisé à réserver avec son compte"},
+	"slack.error.principal_logged_out": {English: ":red_circle: <@%s> is no longer logged in to Cosoft", French: ":red_circle: <@%s> n'est plus connecté à Cosoft"},
+
+	"slack.announcement.share":       {English: "Share in the channel", French: "Partager dans le canal"},
+	"slack.announcement.booked":      {English: ":calendar: <@%s> booked *%s*, %s → %s", French: ":calendar: <@%s> a réservé *%s*, %s → %s"},
+	"slack.announcement.team_booked": {English: ":calendar: <@%s> booked *%s* for the team, %s → %s", French: ":calendar: <@%s> a réservé *%s* pour l'équipe, %s → %s"},
+	"slack.announcement.nobody":      {English: "Nobody has joined yet.", French: "Personne n'a encore rejoint."},
+	"slack.announcement.attendees":   {English: "*Attending:* %s", French: "*Participants :* %s"},
+	"slack.announcement.join":        {English: "Join", French: "Participer"},
+	"slack.announcement.leave":       {English: "Leave", French: "Se désister"},
+	"slack.announcement.cancelled":   {English: ":x: <@%s> cancelled the team's booking of *%s*, %s.", French: ":x: <@%s> a annulé la réservation de *%s* pour l'équipe, %s."},
+	"slack.announcement.not_found":   {English: ":red_circle: This booking can't be shared anymore", French: ":red_circle: Cette réservation ne peut plus être partagée"},
+
+	"slack.reminder.extend":          {English: "Extend by %d min", French: "Prolonger de %d min"},
+	"slack.reminder.not_going":       {English: "I'm not going", French: "Je n'y vais pas"},
+	"slack.reminder.starts":          {English: ":alarm_clock: Your reservation of *%s* starts at *%s* (in %d minutes)", French: ":alarm_clock: Votre réservation de *%s* commence à *%s* (dans %d minutes)"},
+	"slack.reminder.extended":        {English: ":white_check_mark: *%s* extended by %d minutes (%.02f credits)", French: ":white_check_mark: *%s* prolongée de %d minutes (%.02f crédits)"},
+	"slack.reminder.released":        {English: ":wave: *%s* has been released, thanks!", French: ":wave: *%s* a été libérée, merci !"},
+	"slack.reminder.already_started": {English: ":information_source: The reservation already started, the room can't be released anymore.", French: ":information_source: La réservation a déjà commencé, la salle ne peut plus être libérée."},
+
+	"slack.reservations.title":           {English: "My reservations", French: "Mes réservations"},
+	"slack.reservations.count":           {English: "*%d* upcoming reservation(s)", French: "*%d* réservation(s) à venir"},
+	"slack.reservations.none":            {English: ":information_source: You have no upcoming reservation.", French: ":information_source: Vous n'avez pas de réservation à venir."},
+	"slack.reservations.extend":          {English: "Extend by %d min (+%.02f)", French: "Prolonger %d min (+%.02f)"},
+	"slack.reservations.shorten":         {English: "End %d min earlier (-%.02f)", French: "Terminer %d min plus tôt (-%.02f)"},
+	"slack.reservations.edit":            {English: "Change \"%s\":", French: "Modifier \"%s\" :"},
+	"slack.reservations.already_started": {English: ":warning: A reservation that already started can't be cancelled", French: ":warning: Impossible d'annuler une réservation déjà commencée"},
+	"slack.reservations.extended":        {English: ":white_check_mark: Reservation extended!", French: ":white_check_mark: Réservation prolongée !"},
+	"slack.reservations.shortened":       {English: ":white_check_mark: Reservation shortened!", French: ":white_check_mark: Réservation raccourcie !"},
+	"slack.reservations.cancel_selected": {English: "Cancel the selection", French: "Annuler la sélection"},
+	"slack.reservations.cancel_day":      {English: "Cancel everything on %s", French: "Tout annuler le %s"},
+	"slack.reservations.cancel_several":  {English: "Cancel several reservations", French: "Annuler plusieurs réservations"},
+	"slack.reservations.confirm_several": {English: "Cancel %d reservations?", French: "Annuler %d réservations ?"},
+	"slack.reservations.confirm":         {English: "Confirm the cancellation", French: "Confirmer l'annulation"},
+	"slack.reservations.cancellations":   {English: "Cancellations", French: "Annulations"},
+
+	"slack.home.login":     {English: ":information_source: Use the `/book` command to log in.", French: ":information_source: Utilisez la commande `/book` pour vous identifier."},
+	"slack.home.title":     {English: "Dashboard", French: "Tableau de bord"},
+	"slack.home.refresh":   {English: "Refresh", French: "Rafraîchir"},
+	"slack.home.occupancy": {English: "Today's occupancy", French: "Occupation du jour"},
+
+	"slack.calendar.title":        {English: "Calendar", French: "Calendrier"},
+	"slack.calendar.next_day":     {English: "Next day", French: "Jour suivant"},
+	"slack.calendar.prev_day":     {English: "Previous day", French: "Jour précédent"},
+	"slack.calendar.legend_own":   {English: "`█`: Slot booked by you", French: "`█`: Créneau réservé par vous"},
+	"slack.calendar.legend_other": {English: "`░`: Slot booked by someone else", French: "`░`: Créneau réservé par quelqu'un d'autre"},
+
+	"slack.login.intro":            {English: ":information_source:  To book a room, please log in first.", French: ":information_source:  Pour réserver une salle, il faut d'abord vous identifier."},
+	"slack.login.email":            {English: "Email", French: "Email"},
+	"slack.login.password":         {English: "Password", French: "Mot de passe"},
+	"slack.login.password_visible": {English: ":warning: The password is displayed in clear in the field", French: ":warning: Le mot de passe est affiché en clair dans le champ"},
+	"slack.login.submit":           {English: "Log in", French: "Connexion"},
+	"slack.login.required":         {English: "Required field", French: "Champ requis"},
+
+	"slack.menu.title":        {English: "Main menu", French: "Menu principal"},
+	"slack.menu.open":         {English: "Open", French: "Accéder"},
+	"slack.menu.calendar":     {English: "*Calendar*\nSee the rooms' occupancy today and on the following days", French: "*Calendrier*\nVoir l'occupation des salles de la journée et des suivantes"},
+	"slack.menu.quick_book":   {English: "*Quick booking*\nBook a meeting room right now", French: "*Réservation rapide*\nRéserver immédiatement une salle de réunion"},
+	"slack.menu.browse_rooms": {English: "*Browse rooms*\nBook a room for a later date", French: "*Parcourir les salles*\nRéserver une salle pour une date ultérieure"},
+	"slack.menu.reservations": {English: "*My reservations*\nSee and cancel your upcoming reservations", French: "*Mes réservations*\nVoir et annuler vos futures réservations"},
+	"slack.menu.browse":       {English: "Browse rooms", French: "Parcourir les salles"},
+	"slack.menu.duration":     {English: "Reservation's duration", French: "Durée de la réservation"},
+	"slack.menu.capacity":     {English: "Capacity", French: "Capacité"},
+	"slack.menu.date":         {English: "Date", French: "Date"},
+	"slack.menu.time":         {English: "Time", French: "Heure"},
+	"slack.menu.time_hint":    {English: "*Time*\nAllowed formats: 3:00 PM, 15:15", French: "*Heure*\nFormat autorisé : 15h00, 15:15"},
+	"slack.menu.see_rooms":    {English: "See the available rooms", French: "Voir les salles disponibles"},
+	"slack.menu.duration_30":  {English: "30 minutes", French: "30 minutes"},
+	"slack.menu.duration_60":  {English: "1 hour", French: "1 heure"},
+	"slack.menu.duration_90":  {English: "1 hour 30", French: "1 heure 30"},
+	"slack.menu.duration_120": {English: "2 hours", French: "2 heures"},
+	"slack.menu.one_person":   {English: "One person", French: "Une personne"},
+	"slack.menu.two_people":   {English: "Two people or more", French: "Deux personnes ou plus"},
+
+	"slack.error.login":          {English: "Wrong email or password", French: "Identifiant / mot de passe incorrect"},
+	"slack.error.booking":        {English: ":red_circle: The booking failed", French: ":red_circle: La réservation a échoué"},
+	"slack.error.booking_reason": {English: ":red_circle: The booking failed: %s", French: ":red_circle: La réservation a échoué : %s"},
+	"slack.error.reservations":   {English: ":red_circle: Could not load the reservations", French: ":red_circle: Impossible de charger les réservations"},
+	"slack.error.cancel":         {English: ":red_circle: Could not cancel the reservation", French: ":red_circle: Impossible d'annuler la réservation"},
+	"slack.error.resize":         {English: ":red_circle: Could not change the reservation: %s", French: ":red_circle: Impossible de modifier la réservation : %s"},
+	"slack.error.extend":         {English: ":red_circle: Could not extend the reservation: %s", French: ":red_circle: Impossible de prolonger la réservation : %s"},
+	"slack.error.rooms":          {English: ":red_circle: Could not fetch the meeting rooms", French: ":red_circle: Impossible de récupérer les salles de réunion"},
+	"slack.error.calendar":       {English: ":red_circle: Could not load the calendar", French: ":red_circle: Impossible de charger le calendrier"},
+	"slack.error.not_found":      {English: ":red_circle: Reservation not found", French: ":red_circle: Réservation introuvable"},
+	"slack.error.release":        {English: ":red_circle: Could not release the room", French: ":red_circle: Impossible de libérer la salle"},
+
+	"slack.reason.date_in_past":          {English: "the date must be in the future", French: "la date doit être dans le futur"},
+	"slack.reason.not_on_quarter":        {English: "the time must be on a quarter hour", French: "l'heure doit tomber sur un quart d'heure"},
+	"slack.reason.duration_not_quarter":  {English: "the duration must be a multiple of 15 minutes", French: "la durée doit être un multiple de 15 minutes"},
+	"slack.reason.too_long":              {English: "a booking can't last more than 2 hours", French: "une réservation ne peut pas dépasser 2 heures"},
+	"slack.reason.after_closing":         {English: "a booking must end by closing time, at midnight", French: "une réservation doit finir avant la fermeture, à minuit"},
+	"slack.reason.no_room":               {English: "no room is available", French: "aucune salle n'est disponible"},
+	"slack.reason.no_room_with_features": {English: "no available room has the requested equipment", French: "aucune salle disponible n'a l'équipement demandé"},
+	"slack.reason.room_not_available":    {English: "this room isn't available for these filters", French: "cette salle n'est pas disponible pour ces critères"},
+	"slack.reason.not_enough_credits":    {English: "not enough credits", French: "pas assez de crédits"},
+	"slack.reason.resize_not_quarter":    {English: "reservations can only be changed by multiples of 15 minutes", French: "les réservations ne peuvent être modifiées que par multiples de 15 minutes"},
+	"slack.reason.slot_taken":            {English: "the room is already booked right after", French: "la salle est déjà réservée juste après"},
+	"slack.reason.already_started":       {English: "the reservation already started and can't be cancelled", French: "la réservation a déjà commencé et ne peut plus être annulée"},
+	"slack.reason.end_before_start":      {English: "a reservation can't end before it starts", French: "une réservation ne peut pas finir avant de commencer"},
+	"slack.reason.end_in_past":           {English: "a reservation can't end in the past", French: "une réservation ne peut pas finir dans le passé"},
+	"slack.reason.not_found":             {English: "reservation not found", French: "réservation introuvable"},
+	"slack.reason.unexpected":            {English: "an unexpected error occurred", French: "une erreur inattendue est survenue"},
+
+	"slack.admin.usage":         {English: "admin commands: `/book admin users`, `/book admin logout @someone`, `/book admin purge 30`, `/book admin rooms`", French: "commandes d'administration : `/book admin users`, `/book admin logout @quelqu'un`, `/book admin purge 30`, `/book admin rooms`"},
+	"slack.admin.invalid_days":  {English: "invalid number of days: `%s`", French: "nombre de jours invalide : `%s`"},
+	"slack.admin.forbidden":     {English: ":no_entry: Only the bot's admins can run this command.", French: ":no_entry: Seuls les administrateurs du bot peuvent lancer cette commande."},
+	"slack.admin.no_user":       {English: ":information_source: Nobody is logged in.", French: ":information_source: Personne n'est connecté."},
+	"slack.admin.users":         {English: "*%d linked users:*", French: "*%d utilisateurs liés :*"},
+	"slack.admin.user_line":     {English: "• <@%s> %s (%s), %.02f credits, last seen %s, %s", French: "• <@%s> %s (%s), %.02f crédits, vu pour la dernière fois %s, %s"},
+	"slack.admin.never":         {English: "never", French: "jamais"},
+	"slack.admin.token_ok":      {English: ":large_green_circle: token OK", French: ":large_green_circle: jeton valide"},
+	"slack.admin.token_broken":  {English: ":red_circle: token broken (%s)", French: ":red_circle: jeton invalide (%s)"},
+	"slack.admin.logged_out":    {English: ":white_check_mark: <@%s> has been logged out.", French: ":white_check_mark: <@%s> a été déconnecté."},
+	"slack.admin.not_logged_in": {English: ":information_source: <@%s> wasn't logged in.", French: ":information_source: <@%s> n'était pas connecté."},
+	"slack.admin.purged":        {English: ":wastebasket: %d views older than %d days purged.", French: ":wastebasket: %d vues de plus de %d jours supprimées."},
+	"slack.admin.rooms_synced":  {English: ":white_check_mark: Rooms fetched from Cosoft, %d change(s).", French: ":white_check_mark: Salles récupérées depuis Cosoft, %d changement(s)."},
+	"slack.admin.rooms_failed":  {English: ":red_circle: Could not fetch the rooms: %s", French: ":red_circle: Impossible de récupérer les salles : %s"},
+
+	"slack.admin.room_added":    {English: "• New room: %s, %.2f credits an hour.", French: "• Nouvelle salle : %s, %.2f crédits de l'heure."},
+	"slack.admin.room_removed":  {English: "• Removed room: %s.", French: "• Salle retirée : %s."},
+	"slack.admin.room_renamed":  {English: "• %s is now called %s.", French: "• %s s'appelle maintenant %s."},
+	"slack.admin.room_repriced": {English: "• %s now costs %.2f credits an hour, instead of %.2f.", French: "• %s coûte maintenant %.2f crédits de l'heure, au lieu de %.2f."},
+
+	"slack.menu.features":           {English: "Equipment", French: "Équipement"},
+	"slack.feature.screen":          {English: "Screen", French: "Écran"},
+	"slack.feature.whiteboard":      {English: "Whiteboard", French: "Tableau blanc"},
+	"slack.feature.videoconference": {English: "Videoconference", French: "Visioconférence"},
+	"slack.browse.features":         {English: "Equipment: %s", French: "Équipement : %s"},
+	"slack.browse.floor":            {English: "Floor: %s", French: "Étage : %s"},
+	"slack.browse.equipment":        {English: "Equipment: %s", French: "Équipement : %s"},
+
+	"slack.busy.title": {English: "Please wait", French: "Patientez"},
+	"slack.busy.text":  {English: "Your previous action is still running. Close this window and try again in a moment.", French: "Votre action précédente est toujours en cours. Fermez cette fenêtre et réessayez dans un instant."},
+
+	"slack.calendar.unknown_rooms": {English: "The occupancy of %s could not be loaded, shown with `?`", French: "L'occupation de %s n'a pas pu être chargée, affichée avec `?`"},
+}
@@ -0,0 +1,208 @@
+package i18n
+
+var tuiMessages = map[string]message{
+	"tui.confirm":     {English: "Confirm?", French: "Confirmer ?"},
+	"tui.yes":         {English: "Yes", French: "Oui"},
+	"tui.no":          {English: "No", French: "Non"},
+	"tui.minutes":     {English: "%d minutes", French: "%d minutes"},
+	"tui.loading":     {English: "Loading...", French: "Chargement..."},
+	"tui.error_quit":  {English: "Error: %s\nPress q to quit", French: "Erreur : %s\nAppuyez sur q pour quitter"},
+	"tui.esc_to_menu": {English: "You can now press \"ESC\" to go back to the main menu.", French: "Vous pouvez maintenant appuyer sur \"ESC\" pour revenir au menu principal."},
+	"tui.esc_to_quit": {English: "You can now press \"ESC\" to quit the program.", French: "Vous pouvez maintenant appuyer sur \"ESC\" pour quitter le programme."},
+	"tui.credits":     {English: "Credits: %.02f", French: "Crédits : %.02f"},
+	"tui.footer":      {English: "Press Ctrl + C to cancel", French: "Appuyez sur Ctrl + C pour annuler"},
+
+	"tui.page.landing":      {English: "Landing", French: "Accueil"},
+	"tui.page.quick-book":   {English: "Quick book", French: "Réservation rapide"},
+	"tui.page.browse":       {English: "Browse", French: "Parcourir"},
+	"tui.page.reservations": {English: "Reservations", French: "Réservations"},
+	"tui.page.settings":     {English: "Settings", French: "Paramètres"},
+	"tui.page.calendar":     {English: "Calendar", French: "Calendrier"},
+
+	"tui.validate.date_required": {English: "date is required", French: "la date est requise"},
+	"tui.validate.date_invalid":  {English: "date could not be parsed", French: "la date est invalide"},
+	"tui.validate.date_past":     {English: "date is not in the future", French: "la date n'est pas dans le futur"},
+	"tui.validate.hour_required": {English: "hour is required", French: "l'heure est requise"},
+	"tui.validate.hour_invalid":  {English: "could not parse time", French: "l'heure est invalide"},
+	"tui.validate.opening_hours": {English: "hours outside opening hours", French: "heure en dehors des horaires d'ouverture"},
+	"tui.validate.quarter":       {English: "minutes not rounded to quarters", French: "les minutes ne sont pas arrondies au quart d'heure"},
+
+	"tui.landing.reservations":       {English: "My reservations", French: "Mes réservations"},
+	"tui.landing.reservations_count": {English: "My reservations (%d)", French: "Mes réservations (%d)"},
+	"tui.landing.plan":               {English: "What's the plan?", French: "Qu'est-ce qu'on fait ?"},
+	"tui.landing.quick_book":         {English: "Quick book", French: "Réservation rapide"},
+	"tui.landing.browse":             {English: "Browse & book", French: "Parcourir et réserver"},
+	"tui.landing.calendar":           {English: "Calendar", French: "Calendrier"},
+	"tui.landing.history":            {English: "Previous reservations", French: "Réservations passées"},
+	"tui.landing.settings":           {English: "Settings", French: "Paramètres"},
+	"tui.landing.quit":               {English: "Quit", French: "Quitter"},
+	"tui.landing.loading_calendar":   {English: "Loading calendar informations...", French: "Chargement du calendrier..."},
+	"tui.landing.form_nil":           {English: "Error: form is nil", French: "Erreur : le formulaire est vide"},
+
+	"tui.settings.title":          {English: "Settings", French: "Paramètres"},
+	"tui.settings.choose":         {English: "Choose a setting", French: "Choisissez un paramètre"},
+	"tui.settings.reminder":       {English: "Reminder lead time", French: "Délai des rappels"},
+	"tui.settings.language":       {English: "Language", French: "Langue"},
+	"tui.settings.clean":          {English: "Delete the local settings", French: "Supprimer les paramètres locaux"},
+	"tui.settings.lead_title":     {English: "How long before a reservation should `cosoft daemon` notify you?", French: "Combien de temps avant une réservation `cosoft daemon` doit-il vous prévenir ?"},
+	"tui.settings.never":          {English: "Never", French: "Jamais"},
+	"tui.settings.automatic":      {English: "Automatic (LANG)", French: "Automatique (LANG)"},
+	"tui.settings.language_title": {English: "Which language should Cosoft CLI speak?", French: "Dans quelle langue Cosoft CLI doit-il s'afficher ?"},
+	"tui.settings.warning":        {English: "⚠️  Warning", French: "⚠️  Attention"},
+	"tui.settings.clean_warning":  {English: "You are about to delete the local settings file, which will also log you out. \nThis cannot be undone.", French: "Vous allez supprimer le fichier de paramètres locaux, ce qui vous déconnectera aussi. \nCette action est irréversible."},
+	"tui.settings.saving":         {English: "Saving...", French: "Enregistrement..."},
+	"tui.settings.clearing":       {English: "Clearing personal data...", French: "Suppression des données personnelles..."},
+	"tui.settings.lead_saved":     {English: "✓ Reminder lead time saved!", French: "✓ Délai des rappels enregistré !"},
+	"tui.settings.lead_tooltip":   {English: "Run \"cosoft daemon\" to get notified before your reservations.", French: "Lancez \"cosoft daemon\" pour être prévenu avant vos réservations."},
+	"tui.settings.language_saved": {English: "✓ Cosoft CLI now speaks %s!", French: "✓ Cosoft CLI s'affiche maintenant en %s !"},
+	"tui.settings.cleared":        {English: "✓ Cleared Personal Data!", French: "✓ Données personnelles supprimées !"},
+
+	"tui.login.required":    {English: "field is required", French: "ce champ est requis"},
+	"tui.login.title":       {English: "Login", French: "Connexion"},
+	"tui.login.description": {English: "Please insert your credentials to authenticate", French: "Veuillez saisir vos identifiants pour vous connecter"},
+	"tui.login.email":       {English: "Email address", French: "Adresse email"},
+	"tui.login.password":    {English: "Password", French: "Mot de passe"},
+	"tui.login.logging_in":  {English: "Logging in...", French: "Connexion en cours..."},
+	"tui.login.error":       {English: "❌ Error: %v", French: "❌ Erreur : %v"},
+	"tui.login.header":      {English: "COSOFT CLI - Authentication", French: "COSOFT CLI - Connexion"},
+	"tui.login.footer":      {English: "Press Ctrl+C to cancel", French: "Appuyez sur Ctrl+C pour annuler"},
+
+	"tui.booking.looking":            {English: "Looking for available rooms...", French: "Recherche des salles disponibles..."},
+	"tui.booking.complete":           {English: "✓ Booking complete!", French: "✓ Réservation réussie !"},
+	"tui.booking.no_suiting_room":    {English: "no room suiting user's selection, aborting", French: "aucune salle ne correspond à votre sélection, abandon"},
+	"tui.booking.not_enough_credits": {English: "not enough credits to perform the booking, aborting", French: "pas assez de crédits pour réserver, abandon"},
+
+	"tui.reason.date_in_past":          {English: "the date must be in the future", French: "la date doit être dans le futur"},
+	"tui.reason.not_on_quarter":        {English: "the time must be on a quarter hour", French: "l'heure doit tomber sur un quart d'heure"},
+	"tui.reason.duration_not_quarter":  {English: "the duration must be a multiple of 15 minutes", French: "la durée doit être un multiple de 15 minutes"},
+	"tui.reason.too_long":              {English: "a booking can't last more than 2 hours", French: "une réservation ne peut pas dépasser 2 heures"},
+	"tui.reason.after_closing":         {English: "a booking must end by closing time, at midnight", French: "une réservation doit finir avant la fermeture, à minuit"},
+	"tui.reason.no_room":               {English: "no room is available", French: "aucune salle n'est disponible"},
+	"tui.reason.no_room_with_features": {English: "no available room has the requested equipment", French: "aucune salle disponible n'a l'équipement demandé"},
+	"tui.reason.room_not_available":    {English: "this room isn't available for these filters", French: "cette salle n'est pas disponible pour ces critères"},
+	"tui.reason.not_enough_credits":    {English: "not enough credits", French: "pas assez de crédits"},
+	"tui.reason.resize_not_quarter":    {English: "reservations can only be changed by multiples of 15 minutes", French: "les réservations ne peuvent être modifiées que par multiples de 15 minutes"},
+	"tui.reason.slot_taken":            {English: "the room is already booked right after", French: "la salle est déjà réservée juste après"},
+	"tui.reason.already_started":       {English: "the reservation already started and can't be cancelled", French: "la réservation a déjà commencé et ne peut plus être annulée"},
+	"tui.reason.end_before_start":      {English: "a reservation can't end before it starts", French: "une réservation ne peut pas finir avant de commencer"},
+	"tui.reason.end_in_past":           {English: "a reservation can't end in the past", French: "une réservation ne peut pas finir dans le passé"},
+	"tui.reason.not_found":             {English: "reservation not found", French: "réservation introuvable"},
+	"tui.reason.unexpected":            {English: "unexpected error: %s", French: "erreur inattendue : %s"},
+
+	"tui.people.one":      {English: "1 person", French: "1 personne"},
+	"tui.people.one_hint": {English: "The research will include callboxes", French: "La recherche inclura les cabines téléphoniques"},
+	"tui.people.two":      {English: "2 persons or more", French: "2 personnes ou plus"},
+	"tui.people.two_hint": {English: "The research will default to classic meeting rooms", French: "La recherche portera sur les salles de réunion classiques"},
+	"tui.people.how_many": {English: "For how many people?", French: "Pour combien de personnes ?"},
+
+	"tui.quick_book.title":       {English: "Booking", French: "Réservation"},
+	"tui.quick_book.booking":     {English: "Found a meeting room. Booking now...", French: "Salle trouvée. Réservation en cours..."},
+	"tui.quick_book.from_to":     {English: "From %s to %s", French: "De %s à %s"},
+	"tui.quick_book.duration_30": {English: "30 minutes", French: "30 minutes"},
+	"tui.quick_book.duration_60": {English: "1 hour", French: "1 heure"},
+	"tui.quick_book.how_long":    {English: "For how long?", French: "Pour combien de temps ?"},
+	"tui.quick_book.no_room":     {English: "no room available for the selected time", French: "aucune salle disponible pour l'horaire choisi"},
+
+	"tui.table.room":     {English: "ROOM", French: "SALLE"},
+	"tui.table.duration": {English: "DURATION", French: "DURÉE"},
+	"tui.table.cost":     {English: "COST", French: "COÛT"},
+	"tui.credits_amount": {English: "%.2f credits", French: "%.2f crédits"},
+
+	"tui.browse.title":        {English: "Browse", French: "Parcourir"},
+	"tui.browse.date":         {English: "Reservation date", French: "Date de la réservation"},
+	"tui.browse.date_hint":    {English: "Pick a date in the future, format yyyy-mm-dd", French: "Choisissez une date dans le futur, au format aaaa-mm-jj"},
+	"tui.browse.hour":         {English: "Reservation hour", French: "Heure de la réservation"},
+	"tui.browse.hour_hint":    {English: "The hour needs to be rounded to the quarter (ex: 9:15, 10:30, etc)", French: "L'heure doit être arrondie au quart d'heure (ex : 9:15, 10:30, etc)"},
+	"tui.browse.duration":     {English: "Reservation duration", French: "Durée de la réservation"},
+	"tui.browse.duration_30":  {English: "30mn", French: "30 min"},
+	"tui.browse.duration_60":  {English: "1 hour", French: "1 heure"},
+	"tui.browse.duration_90":  {English: "1 hour 30 minutes", French: "1 heure 30"},
+	"tui.browse.duration_120": {English: "2 hours", French: "2 heures"},
+	"tui.browse.booking":      {English: "Booking selected room...", French: "Réservation de la salle choisie..."},
+	"tui.browse.no_room_day":  {English: "no room available on the selected day", French: "aucune salle disponible le jour choisi"},
+	"tui.browse.pick_room":    {English: "Pick a meeting room", French: "Choisissez une salle de réunion"},
+	"tui.browse.capacity":     {English: "Capacity: %d people", French: "Capacité : %d personnes"},
+	"tui.browse.price":        {English: "Price:    %.02f credits/h", French: "Prix :    %.02f crédits/h"},
+	"tui.browse.total":        {English: "Total:    %.02f credits for %d min", French: "Total :   %.02f crédits pour %d min"},
+	"tui.browse.later":        {English: "%d min later · %.02f credits", French: "%d min plus tard · %.02f crédits"},
+	"tui.browse.earlier":      {English: "%d min earlier · %.02f credits", French: "%d min plus tôt · %.02f crédits"},
+	"tui.browse.suggestions":  {English: "No room is free at this time, pick one of the closest slots", French: "Aucune salle n'est libre à cette heure, choisissez l'un des créneaux les plus proches"},
+
+	"tui.browse.features":      {English: "Equipment", French: "Équipement"},
+	"tui.browse.features_hint": {English: "Space to pick, the rooms must have all of them", French: "Espace pour choisir, les salles doivent tout avoir"},
+	"tui.browse.floor":         {English: "Floor:    %s", French: "Étage :   %s"},
+	"tui.browse.equipment":     {English: "Equipment: %s", French: "Équipement : %s"},
+	"tui.browse.photo":         {English: "ctrl+o: see the photo", French: "ctrl+o : voir la photo"},
+
+	"tui.reservations.title":           {English: "Reservations", French: "Réservations"},
+	"tui.reservations.loading":         {English: "Loading reservations...", French: "Chargement des réservations..."},
+	"tui.reservations.none":            {English: "No reservations found \n\n Press \"ESC\" to go back to the main menu.", French: "Aucune réservation trouvée \n\n Appuyez sur \"ESC\" pour revenir au menu principal."},
+	"tui.reservations.updating":        {English: "Updating reservation...", French: "Modification de la réservation..."},
+	"tui.reservations.cancelling":      {English: "Cancelling reservations...", French: "Annulation des réservations..."},
+	"tui.reservations.cancelled":       {English: "✓ Cancellation complete!", French: "✓ Annulation réussie !"},
+	"tui.reservations.extended":        {English: "✓ Reservation extended, %.02f credits spent", French: "✓ Réservation prolongée, %.02f crédits dépensés"},
+	"tui.reservations.shortened":       {English: "✓ Reservation shortened, %.02f credits refunded", French: "✓ Réservation écourtée, %.02f crédits remboursés"},
+	"tui.reservations.subtitle":        {English: "%s → %s · %.02f credits", French: "%s → %s · %.02f crédits"},
+	"tui.reservations.pick":            {English: "Pick reservations (space to select several, ctrl+g for the whole day)", French: "Choisissez des réservations (espace pour en sélectionner plusieurs, ctrl+g pour toute la journée)"},
+	"tui.reservations.what":            {English: "What do you want to do?", French: "Que voulez-vous faire ?"},
+	"tui.reservations.cancel_several":  {English: "Cancel these %d reservations", French: "Annuler ces %d réservations"},
+	"tui.reservations.upcoming":        {English: "Upcoming", French: "À venir"},
+	"tui.reservations.in_progress":     {English: "In progress", French: "En cours"},
+	"tui.reservations.date":            {English: "Date:     %s", French: "Date :    %s"},
+	"tui.reservations.time":            {English: "Time:     %s → %s", French: "Heure :   %s → %s"},
+	"tui.reservations.duration":        {English: "Duration: %d min", French: "Durée :   %d min"},
+	"tui.reservations.cost":            {English: "Cost:     %.02f credits", French: "Coût :    %.02f crédits"},
+	"tui.reservations.status":          {English: "Status:   %s", French: "Statut :  %s"},
+	"tui.reservations.refunded":        {English: "%.02f credits refunded.", French: "%.02f crédits remboursés."},
+	"tui.reservations.cancel":          {English: "Cancel it", French: "L'annuler"},
+	"tui.reservations.extend":          {English: "Extend by %d min (+%.02f credits)", French: "Prolonger de %d min (+%.02f crédits)"},
+	"tui.reservations.shorten":         {English: "End %d min early (-%.02f credits)", French: "Terminer %d min plus tôt (-%.02f crédits)"},
+	"tui.reservations.already_started": {English: "this reservation has already started, it can't be cancelled anymore", French: "cette réservation a déjà commencé, elle ne peut plus être annulée"},
+
+	"tui.calendar.too_long":     {English: "bookings can't last more than 2 hours", French: "une réservation ne peut pas dépasser 2 heures"},
+	"tui.calendar.passed":       {English: "this time has already passed", French: "cet horaire est déjà passé"},
+	"tui.calendar.booked_at":    {English: "%s is already booked at %s", French: "%s est déjà réservée à %s"},
+	"tui.calendar.no_rooms":     {English: "no rooms found", French: "aucune salle trouvée"},
+	"tui.calendar.slot_booked":  {English: "This slot is already booked", French: "Ce créneau est déjà réservé"},
+	"tui.calendar.slot_passed":  {English: "This time has already passed", French: "Cet horaire est déjà passé"},
+	"tui.calendar.book":         {English: "Book %s?", French: "Réserver %s ?"},
+	"tui.calendar.book_summary": {English: "%s, %s → %s · %.02f credits", French: "%s, %s → %s · %.02f crédits"},
+	"tui.calendar.what":         {English: "What do you want to do with your reservation in %s?", French: "Que voulez-vous faire de votre réservation en %s ?"},
+	"tui.calendar.loading":      {English: "Loading calendar for %s...", French: "Chargement du calendrier du %s..."},
+	"tui.calendar.updating":     {English: "Updating reservations...", French: "Modification des réservations..."},
+	"tui.calendar.help":         {English: "←/→ move · ↑/↓ change room · pgup/pgdown change day · enter select, then book · x clear selection", French: "←/→ se déplacer · ↑/↓ changer de salle · pgup/pgdown changer de jour · entrée sélectionner, puis réserver · x effacer la sélection"},
+	"tui.calendar.selection":    {English: "%s · %s → %s · %.02f credits, press enter to book", French: "%s · %s → %s · %.02f crédits, appuyez sur entrée pour réserver"},
+	"tui.calendar.free":         {English: "free", French: "libre"},
+	"tui.calendar.booked":       {English: "booked", French: "réservée"},
+	"tui.calendar.past":         {English: "past", French: "passé"},
+	"tui.calendar.yours":        {English: "your reservation, %s → %s", French: "votre réservation, %s → %s"},
+	"tui.calendar.cell":         {English: "%s · %d people · %.02f credits/h · %s → %s · %s", French: "%s · %d personnes · %.02f crédits/h · %s → %s · %s"},
+	"tui.calendar.booked_from":  {English: "✓ %s booked from %s to %s, %.02f credits spent", French: "✓ %s réservée de %s à %s, %.02f crédits dépensés"},
+	"tui.calendar.cancelled":    {English: "✓ Reservation cancelled, %.02f credits refunded", French: "✓ Réservation annulée, %.02f crédits remboursés"},
+
+	"tui.list.empty":       {English: "No items available", French: "Aucun élément disponible"},
+	"tui.list.items":       {English: "%d items", French: "%d éléments"},
+	"tui.list.filter":      {English: "Filter: %s", French: "Filtre : %s"},
+	"tui.list.no_match":    {English: "No match, backspace to change the filter", French: "Aucun résultat, retour arrière pour changer le filtre"},
+	"tui.list.more_above":  {English: "  ↑ %d more", French: "  ↑ %d de plus"},
+	"tui.list.more_below":  {English: "  ↓ %d more", French: "  ↓ %d de plus"},
+	"tui.list.prompt":      {English: "Enter selection number: ", French: "Numéro de la sélection : "},
+	"tui.list.invalid":     {English: "invalid selection: %d", French: "sélection invalide : %d"},
+	"tui.list.up":          {English: "move up", French: "monter"},
+	"tui.list.down":        {English: "move down", French: "descendre"},
+	"tui.list.select":      {English: "select", French: "choisir"},
+	"tui.list.type":        {English: "type", French: "taper"},
+	"tui.list.filter_help": {English: "filter", French: "filtrer"},
+	"tui.list.toggle":      {English: "toggle", French: "cocher"},
+	"tui.list.extend":      {English: "extend selection", French: "étendre la sélection"},
+	"tui.list.all":         {English: "all/none", French: "tout/rien"},
+	"tui.list.group":       {English: "select group", French: "choisir le groupe"},
+
+	"tui.feature.screen":          {English: "Screen", French: "Écran"},
+	"tui.feature.whiteboard":      {English: "Whiteboard", French: "Tableau blanc"},
+	"tui.feature.videoconference": {English: "Videoconference", French: "Visioconférence"},
+	"tui.photo.back":              {English: "Press enter to go back.", French: "Appuyez sur entrée pour revenir."},
+
+	"tui.calendar.slot_unknown": {English: "The occupancy of this room could not be loaded", French: "L'occupation de cette salle n'a pas pu être chargée"},
+	"tui.calendar.unknown":      {English: "unknown", French: "inconnue"},
+}
@@ -3,52 +3,42 @@ package services
 import (
 	"cosoft-cli/internal/api"
 	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/i18n"
 	"cosoft-cli/internal/storage"
+	"cosoft-cli/internal/theme"
 	"cosoft-cli/shared/models"
-	"errors"
 	"fmt"
-	"os"
+	"strings"
 	"sync"
 	"time"
-
-	"github.com/charmbracelet/lipgloss"
 )
 
 func (s *Service) UpdateCredits() (*float64, error) {
 	return s.store.UpdateCredits(nil)
 }
 
-func (s *Service) EnsureRoomsStored() error {
-	rooms, err := s.store.GetRooms()
-
-	if err != nil {
-		return err
-	}
-
-	if len(rooms) > 0 {
-		return nil
-	}
-
-	authData, err := s.store.GetUserData(nil)
-
+func (s *Service) GetRoomAvailabilities(
+	date time.Time,
+	userBookings []api.Reservation,
+) ([]string, error) {
+	results, err := s.GetRoomUsages(date)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	apiClient := api.NewApi()
-	apiRooms, err := apiClient.GetAllRooms(authData.WAuth, authData.WAuthRefresh)
+	rows := common.BuildCalendar(0, common.ClosingHour-common.OpeningHour, results, userBookings)
 
-	if err != nil {
-		return err
-	}
+	return rows, nil
+}
 
-	return s.store.CreateRooms(apiRooms)
+// GetRooms returns the stored rooms, see EnsureRoomsStored.
+func (s *Service) GetRooms() ([]storage.Room, error) {
+	return s.store.GetRooms()
 }
 
-func (s *Service) GetRoomAvailabilities(
-	date time.Time,
-	userBookings []api.Reservation,
-) ([]string, error) {
+// GetRoomUsages returns the busy slots of every stored room on date, in the
+// same order as GetRooms. Rooms whose slots couldn't be fetched have none.
+func (s *Service) GetRoomUsages(date time.Time) ([]models.RoomUsage, error) {
 	location, err := common.LoadLocalTime()
 	if err != nil {
 		return nil, err
@@ -86,14 +76,11 @@ func (s *Service) GetRoomAvailabilities(
 				Name: room.Name,
 			}
 
+			// A room may not be free when its busy times are missing.
 			if err == nil && response != nil {
 				result.UsedSlots = *response
 			} else {
-				if err != nil {
-					debug(fmt.Sprintf("Error for %s: %s", room.Name, err.Error()))
-				} else {
-					debug(fmt.Sprintf("Nil response for %s", room.Name))
-				}
+				result.Unknown = true
 			}
 
 			results[i] = result
@@ -102,62 +89,171 @@ func (s *Service) GetRoomAvailabilities(
 
 	wg.Wait()
 
-	rows := common.BuildCalendar(0, 16, results, userBookings)
+	return results, nil
+}
 
-	return rows, nil
+// BookingRequest holds the criteria of a non-interactive booking, as given to
+// `cosoft book` or to the Slack command's arguments. With DryRun, the room is
+// picked and the credits checked, but nothing is paid.
+type BookingRequest struct {
+	Capacity int
+	Duration int
+	Name     string
+	DateTime time.Time
+	DryRun   bool
+	// Features are the equipment the room must have, see models.Features.
+	Features []models.Feature
+}
+
+// Cost returns what booking room for the request's duration charges.
+func (r BookingRequest) Cost(room models.Room) float64 {
+	return room.Price * float64(r.Duration) / 60
+}
+
+// Validate applies the constraints Cosoft puts on bookings.
+func (r BookingRequest) Validate() error {
+	if r.DateTime.Before(time.Now()) {
+		return ErrDateInPast
+	}
+
+	if r.DateTime.Minute()%15 != 0 {
+		return ErrNotOnQuarter
+	}
+
+	if r.Duration <= 0 || r.Duration%15 != 0 {
+		return ErrDurationNotQuarter
+	}
+
+	return validateLength(r.DateTime, r.Duration)
+}
+
+// validateLength checks that a booking starting at start and lasting
+// duration minutes isn't too long, and ends by closing time.
+func validateLength(start time.Time, duration int) error {
+	if duration > common.MaxBookingMinutes {
+		return ErrTooLong
+	}
+
+	closing := time.Date(start.Year(), start.Month(), start.Day(), common.ClosingHour, 0, 0, 0, start.Location())
+
+	if start.Add(time.Duration(duration) * time.Minute).After(closing) {
+		return ErrAfterClosing
+	}
+
+	return nil
 }
 
 func (s *Service) NonInteractiveBooking(
 	capacity, duration int,
 	name string,
 	dt time.Time,
+	features []models.Feature,
+	dryRun bool,
 ) (string, error) {
 	user, err := s.store.GetUserData(nil)
 	if err != nil {
 		return "", err
 	}
 
+	request := BookingRequest{
+		Capacity: capacity,
+		Duration: duration,
+		Name:     name,
+		DateTime: dt,
+		DryRun:   dryRun,
+		Features: features,
+	}
+
+	targetRoom, err := BookFirstAvailable(*user, request, func(step string) {
+		fmt.Println(step)
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	endTime := dt.Add(time.Duration(duration) * time.Minute)
+	l := i18n.Default()
+	success := theme.Current().Success().Render(l.T("cli.book.complete"))
+	if dryRun {
+		success = theme.Current().Warning().Render(l.T("cli.book.dry_run"))
+	}
+
+	headers := []string{l.T("cli.table.room"), l.T("cli.table.duration"), l.T("cli.table.cost")}
+
+	rows := [][]string{
+		{
+			targetRoom.Name,
+			fmt.Sprintf("%s → %s", l.DateTime(dt), l.DateTime(endTime)),
+			l.T("cli.credits", request.Cost(*targetRoom)),
+		},
+	}
+
+	fmt.Println(success)
+
+	return common.CreateTable(headers, rows), nil
+}
+
+// BookFirstAvailable books the room named in request, or the first available
+// one having the requested features when no name is given, with user's
+// account. progress is called before
+// each step, and can be nil. With request.DryRun, it stops before paying.
+func BookFirstAvailable(
+	user storage.User,
+	request BookingRequest,
+	progress func(step string),
+) (*models.Room, error) {
+	if progress == nil {
+		progress = func(string) {}
+	}
+
 	clientApi := api.NewApi()
 
 	// Ensure user is authenticated
-	fmt.Println("checking user authentication status...")
-	err = clientApi.GetAuth(user.WAuth, user.WAuthRefresh)
+	progress("checking user authentication status...")
+	err := clientApi.GetAuth(user.WAuth, user.WAuthRefresh)
 
 	if err != nil {
-		return "", fmt.Errorf("user not authenticated: %v", err)
+		return nil, fmt.Errorf("user not authenticated: %v", err)
 	}
 
 	var room *models.Room
 
 	payload := api.CosoftAvailabilityPayload{
-		DateTime: dt,
-		Duration: duration,
-		NbPeople: capacity,
+		DateTime: request.DateTime,
+		Duration: request.Duration,
+		NbPeople: request.Capacity,
 	}
 
-	fmt.Println("retrieving available rooms with requested filters...")
+	progress("retrieving available rooms with requested filters...")
 	availabilities, err := clientApi.GetAvailableRooms(user.WAuth, user.WAuthRefresh, payload)
 
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	if len(availabilities) == 0 {
+		return nil, ErrNoRoomAvailable
 	}
 
+	availabilities = models.FilterRooms(availabilities, request.Features)
+
 	if len(availabilities) == 0 {
-		return "", errors.New("no available rooms")
+		return nil, ErrNoRoomWithFeatures
 	}
 
 	// If room name was provided, check if is among the API's response.
-	if name != "" {
+	if request.Name != "" {
 		var found *models.Room
 		for _, avail := range availabilities {
-			if avail.Name == name {
+			if strings.EqualFold(avail.Name, request.Name) {
 				found = &avail
 				break
 			}
 		}
 
 		if found == nil {
-			return "", fmt.Errorf("room %s not available for the selected filter", name)
+			return nil, fmt.Errorf("%w: %s", ErrRoomNotAvailable, request.Name)
 		}
 
 		room = found
@@ -170,47 +266,25 @@ func (s *Service) NonInteractiveBooking(
 		targetRoom = *room
 	}
 
-	if targetRoom.Price > user.Credits {
-		return "", errors.New("not enough credits")
+	if request.Cost(targetRoom) > user.Credits {
+		return nil, ErrNotEnoughCredits
+	}
+
+	if request.DryRun {
+		return &targetRoom, nil
 	}
 
 	bookingPayload := api.CosoftBookingPayload{
-		CosoftAvailabilityPayload: api.CosoftAvailabilityPayload{
-			DateTime: dt,
-			Duration: duration,
-			NbPeople: capacity,
-		},
-		UserCredits: user.Credits,
-		Room:        targetRoom,
+		CosoftAvailabilityPayload: payload,
+		UserCredits:               user.Credits,
+		Room:                      targetRoom,
 	}
 
-	fmt.Println("booking requested room...")
+	progress("booking requested room...")
 	err = clientApi.BookRoom(user.WAuth, user.WAuthRefresh, bookingPayload)
 	if err != nil {
-		return "", err
-	}
-
-	endTime := dt.Add(time.Duration(duration) * time.Minute)
-	success := lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Render(`✓ Booking complete!`)
-	dateFormat := "02/01/2006 15:04"
-
-	headers := []string{"ROOM", "DURATION", "COST"}
-
-	rows := [][]string{
-		{
-			targetRoom.Name,
-			fmt.Sprintf("%s → %s", dt.Format(dateFormat), endTime.Format(dateFormat)),
-			fmt.Sprintf("%.2f credits", targetRoom.Price),
-		},
+		return nil, err
 	}
 
-	fmt.Println(success)
-
-	return common.CreateTable(headers, rows), nil
-}
-
-func debug(text string) {
-	file, _ := os.OpenFile("debug.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	defer file.Close()
-	_, _ = fmt.Fprintf(file, "%s: %s \n\n", time.Now().Format(time.RFC3339), text)
+	return &targetRoom, nil
 }
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/storage"
+	"time"
+)
+
+// CancellationResult is the outcome of cancelling one of several
+// reservations.
+type CancellationResult struct {
+	Reservation api.Reservation
+	Credits     float64
+	Err         error
+}
+
+// CancelReservation cancels the user's reservation by id, and returns it with
+// the credits given back. With dryRun, the reservation is looked up but not
+// cancelled.
+func (s *Service) CancelReservation(reservationId string, dryRun bool) (*api.Reservation, float64, error) {
+	user, err := s.store.GetUserData(nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	reservation, err := findReservation(*user, reservationId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	refund, err := cancelReservation(*user, *reservation, dryRun)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reservation, refund, nil
+}
+
+// CancelReservations cancels every reservation, carrying on after failures,
+// and returns the outcome of each in the same order.
+func (s *Service) CancelReservations(reservations []api.Reservation) ([]CancellationResult, error) {
+	user, err := s.store.GetUserData(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]CancellationResult, len(reservations))
+
+	for i, r := range reservations {
+		credits, err := cancelReservation(*user, r, false)
+		results[i] = CancellationResult{Reservation: r, Credits: credits, Err: err}
+	}
+
+	return results, nil
+}
+
+// cancelReservation cancels reservation, unless dryRun, and returns the
+// credits given back. Reservations which already started can't be cancelled.
+func cancelReservation(user storage.User, reservation api.Reservation, dryRun bool) (float64, error) {
+	location, err := common.LoadLocalTime()
+	if err != nil {
+		return 0, err
+	}
+
+	start, err := time.ParseInLocation("2006-01-02T15:04:05", reservation.Start, location)
+	if err != nil {
+		return 0, err
+	}
+
+	end, err := time.ParseInLocation("2006-01-02T15:04:05", reservation.End, location)
+	if err != nil {
+		return 0, err
+	}
+
+	if !start.After(time.Now()) {
+		return 0, ErrAlreadyStarted
+	}
+
+	// Reservations are priced by the hour.
+	refund := reservation.Credits * end.Sub(start).Hours()
+
+	if dryRun {
+		return refund, nil
+	}
+
+	apiClient := api.NewApi()
+	if err := apiClient.CancelBooking(user.WAuth, user.WAuthRefresh, reservation.OrderResourceRentId); err != nil {
+		return 0, err
+	}
+
+	return refund, nil
+}
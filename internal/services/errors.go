@@ -0,0 +1,24 @@
+package services
+
+import "errors"
+
+// Errors of the requests Cosoft would refuse, for the interfaces to word them
+// in the user's language. They may be wrapped with the room or reservation
+// concerned.
+var (
+	ErrDateInPast          = errors.New("the date needs to be in the future")
+	ErrNotOnQuarter        = errors.New("time needs to be rounded to a quarter")
+	ErrDurationNotQuarter  = errors.New("duration must be a multiple of 15")
+	ErrTooLong             = errors.New("bookings can't last more than 2 hours")
+	ErrAfterClosing        = errors.New("bookings must end by closing time")
+	ErrNoRoomAvailable     = errors.New("no available rooms")
+	ErrNoRoomWithFeatures  = errors.New("no available room has the requested features")
+	ErrRoomNotAvailable    = errors.New("room not available for the selected filter")
+	ErrNotEnoughCredits    = errors.New("not enough credits")
+	ErrResizeNotQuarter    = errors.New("reservations can only be changed by multiples of 15 minutes")
+	ErrSlotTaken           = errors.New("the room is already booked")
+	ErrAlreadyStarted      = errors.New("this reservation has already started and cannot be cancelled")
+	ErrEndBeforeStart      = errors.New("a reservation cannot end before it starts")
+	ErrEndInPast           = errors.New("a reservation cannot end in the past")
+	ErrReservationNotFound = errors.New("reservation not found")
+)
@@ -0,0 +1,671 @@
+package services
+
+import (
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/api/cosofttest"
+	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/settings"
+	"cosoft-cli/shared/models"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestService returns a service logged in on a fake Cosoft, with its
+// database in a temporary config directory.
+func newTestService(t *testing.T) (*Service, *cosofttest.Server) {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	fake := cosofttest.NewServer(t)
+
+	if err := settings.EnsureDatabaseExists(); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewService()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.store.Close() })
+
+	if err := s.SaveAuthData(fake.LoginResponse()); err != nil {
+		t.Fatal(err)
+	}
+
+	return s, fake
+}
+
+// tomorrowAt returns tomorrow at hour:minute, when every room is free.
+func tomorrowAt(t *testing.T, hour, minute int) time.Time {
+	t.Helper()
+
+	location, err := common.LoadLocalTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().In(location)
+
+	return time.Date(now.Year(), now.Month(), now.Day()+1, hour, minute, 0, 0, location)
+}
+
+// bookStarted books an hour which began 15 to 30 minutes ago, straight
+// through the API since it is in the past.
+func bookStarted(t *testing.T) (*Service, *cosofttest.Server, string, time.Time) {
+	t.Helper()
+
+	s, fake := newTestService(t)
+	user, _ := s.GetAuthData()
+	start := time.Now().Truncate(15 * time.Minute).Add(-15 * time.Minute)
+
+	rooms, err := api.NewApi().GetAllRooms(user.WAuth, user.WAuthRefresh)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = api.NewApi().BookRoom(user.WAuth, user.WAuthRefresh, api.CosoftBookingPayload{
+		CosoftAvailabilityPayload: api.CosoftAvailabilityPayload{DateTime: start, Duration: 60, NbPeople: 1},
+		UserCredits:               user.Credits,
+		Room:                      rooms[0],
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s, fake, fake.Bookings()[0].Id, start
+}
+
+func TestIsAuthenticated(t *testing.T) {
+	s, fake := newTestService(t)
+
+	if !s.IsAuthenticated() {
+		t.Fatal("IsAuthenticated() = false, want true")
+	}
+
+	fake.Fail(cosofttest.Auth, http.StatusInternalServerError)
+
+	if s.IsAuthenticated() {
+		t.Error("IsAuthenticated() = true while the API fails, want false")
+	}
+}
+
+func TestBookingRequestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    time.Time
+		duration int
+		wantErr  error
+	}{
+		{name: "valid", start: tomorrowAt(t, 10, 0), duration: 120},
+		{name: "until_closing", start: tomorrowAt(t, 23, 0), duration: 60},
+		{name: "past", start: time.Now().Add(-time.Hour), duration: 30, wantErr: ErrDateInPast},
+		{name: "not_on_quarter", start: tomorrowAt(t, 10, 10), duration: 30, wantErr: ErrNotOnQuarter},
+		{name: "duration_not_quarter", start: tomorrowAt(t, 10, 0), duration: 20, wantErr: ErrDurationNotQuarter},
+		{name: "too_long", start: tomorrowAt(t, 10, 0), duration: 135, wantErr: ErrTooLong},
+		{name: "after_closing", start: tomorrowAt(t, 23, 30), duration: 60, wantErr: ErrAfterClosing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := BookingRequest{DateTime: tt.start, Duration: tt.duration}.Validate()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBookFirstAvailable(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  BookingRequest
+		prepare  func(fake *cosofttest.Server, start time.Time)
+		wantRoom string
+		wantErr  bool
+	}{
+		{
+			name:     "first_available",
+			request:  BookingRequest{Capacity: 2, Duration: 60},
+			wantRoom: "Salle Bleue",
+		},
+		{
+			name:     "by_name",
+			request:  BookingRequest{Capacity: 1, Duration: 30, Name: "salle rouge"},
+			wantRoom: "Salle Rouge",
+		},
+		{
+			name:    "room_taken",
+			request: BookingRequest{Capacity: 1, Duration: 30, Name: "Salle Rouge"},
+			prepare: func(fake *cosofttest.Server, start time.Time) {
+				fake.Occupy(cosofttest.DefaultRooms[2].Id, start.Add(-15*time.Minute), start.Add(15*time.Minute))
+			},
+			wantErr: true,
+		},
+		{
+			name:    "capacity_skips_small_rooms",
+			request: BookingRequest{Capacity: 2, Duration: 30},
+			prepare: func(fake *cosofttest.Server, start time.Time) {
+				fake.Occupy(cosofttest.DefaultRooms[0].Id, start, start.Add(time.Hour))
+			},
+			wantRoom: "Salle Rouge",
+		},
+		{
+			name:     "features",
+			request:  BookingRequest{Capacity: 1, Duration: 30, Features: []models.Feature{models.FeatureVideoconference}},
+			wantRoom: "Salle Rouge",
+		},
+		{
+			name:    "features_taken",
+			request: BookingRequest{Capacity: 1, Duration: 30, Features: []models.Feature{models.FeatureWhiteboard}},
+			prepare: func(fake *cosofttest.Server, start time.Time) {
+				fake.Occupy(cosofttest.DefaultRooms[0].Id, start, start.Add(time.Hour))
+			},
+			wantErr: true,
+		},
+		{
+			name:    "not_enough_credits",
+			request: BookingRequest{Capacity: 1, Duration: 30},
+			prepare: func(fake *cosofttest.Server, _ time.Time) {
+				fake.SetCredits(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "payment_failure",
+			request: BookingRequest{Capacity: 1, Duration: 30},
+			prepare: func(fake *cosofttest.Server, _ time.Time) {
+				fake.Fail(cosofttest.Payment, http.StatusInternalServerError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, fake := newTestService(t)
+			tt.request.DateTime = tomorrowAt(t, 10, 0)
+
+			if tt.prepare != nil {
+				tt.prepare(fake, tt.request.DateTime)
+			}
+
+			user, err := s.GetAuthData()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			room, err := BookFirstAvailable(*user, tt.request, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BookFirstAvailable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				if len(fake.Bookings()) != 0 {
+					t.Errorf("bookings = %+v, want none", fake.Bookings())
+				}
+				return
+			}
+
+			if room.Name != tt.wantRoom {
+				t.Errorf("BookFirstAvailable() room = %s, want %s", room.Name, tt.wantRoom)
+			}
+
+			bookings := fake.Bookings()
+			if len(bookings) != 1 || bookings[0].RoomId != room.Id || !bookings[0].Start.Equal(tt.request.DateTime) {
+				t.Errorf("bookings = %+v, want one in %s at %s", bookings, room.Name, tt.request.DateTime)
+			}
+		})
+	}
+}
+
+func TestBookFirstAvailableDryRun(t *testing.T) {
+	s, fake := newTestService(t)
+	user, _ := s.GetAuthData()
+	request := BookingRequest{Capacity: 2, Duration: 90, Name: "Salle Rouge", DateTime: tomorrowAt(t, 10, 0), DryRun: true}
+
+	// Nothing is paid, payment failures can't be noticed.
+	fake.Fail(cosofttest.Payment, http.StatusInternalServerError)
+	before := fake.Credits()
+
+	room, err := BookFirstAvailable(*user, request, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if room.Name != "Salle Rouge" || request.Cost(*room) != 18 {
+		t.Errorf("BookFirstAvailable() = %s for %v credits, want Salle Rouge for 18", room.Name, request.Cost(*room))
+	}
+
+	if len(fake.Bookings()) != 0 || fake.Credits() != before {
+		t.Errorf("bookings = %+v, balance %v → %v, want nothing booked", fake.Bookings(), before, fake.Credits())
+	}
+
+	// The credits are still checked.
+	user.Credits = 10
+
+	if _, err := BookFirstAvailable(*user, request, nil); err == nil {
+		t.Error("BookFirstAvailable() succeeded without enough credits")
+	}
+}
+
+func TestCancelReservation(t *testing.T) {
+	s, fake := newTestService(t)
+	user, _ := s.GetAuthData()
+
+	_, err := BookFirstAvailable(*user, BookingRequest{Capacity: 1, Duration: 90, Name: "Salle Bleue", DateTime: tomorrowAt(t, 10, 0)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := fake.Bookings()[0].Id
+
+	for _, dryRun := range []bool{true, false} {
+		_, credits, err := s.CancelReservation(id, dryRun)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if credits != 15 {
+			t.Errorf("CancelReservation(dryRun %v) credits = %v, want 15", dryRun, credits)
+		}
+
+		want := 0
+		if dryRun {
+			want = 1
+		}
+
+		if got := len(fake.Bookings()); got != want {
+			t.Errorf("CancelReservation(dryRun %v) left %d bookings, want %d", dryRun, got, want)
+		}
+	}
+}
+
+func TestCancelStartedReservation(t *testing.T) {
+	s, fake, id, _ := bookStarted(t)
+
+	if _, _, err := s.CancelReservation(id, false); !errors.Is(err, ErrAlreadyStarted) {
+		t.Errorf("CancelReservation() error = %v, want %v", err, ErrAlreadyStarted)
+	}
+
+	if got := len(fake.Bookings()); got != 1 {
+		t.Errorf("CancelReservation() left %d bookings, want the reservation kept", got)
+	}
+}
+
+func TestCancelReservations(t *testing.T) {
+	s, fake := newTestService(t)
+	user, _ := s.GetAuthData()
+
+	for _, hour := range []int{10, 14} {
+		_, err := BookFirstAvailable(*user, BookingRequest{Capacity: 1, Duration: 60, Name: "Salle Verte", DateTime: tomorrowAt(t, hour, 0)}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bookings, err := api.NewApi().GetFutureBookings(user.WAuth, user.WAuthRefresh)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake.FailOnce(cosofttest.Cancel, http.StatusInternalServerError)
+
+	results, err := s.CancelReservations(bookings.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 || results[0].Err == nil || results[1].Err != nil || results[1].Credits != 6 {
+		t.Errorf("CancelReservations() = %+v, want the first to fail and the second to refund 6 credits", results)
+	}
+
+	if len(fake.Bookings()) != 1 {
+		t.Errorf("bookings = %+v, want the failed one left", fake.Bookings())
+	}
+}
+
+func TestResizeReservation(t *testing.T) {
+	bookAt := func(t *testing.T, start time.Time) (*Service, *cosofttest.Server, string) {
+		s, fake := newTestService(t)
+		user, _ := s.GetAuthData()
+
+		_, err := BookFirstAvailable(*user, BookingRequest{
+			Capacity: 1,
+			Duration: 60,
+			Name:     "Salle Bleue",
+			DateTime: start,
+		}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return s, fake, fake.Bookings()[0].Id
+	}
+
+	book := func(t *testing.T) (*Service, *cosofttest.Server, string) {
+		return bookAt(t, tomorrowAt(t, 10, 0))
+	}
+
+	t.Run("extend", func(t *testing.T) {
+		s, fake, id := book(t)
+		before := fake.Credits()
+
+		_, credits, err := s.ResizeReservation(id, 30, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if credits != 5 || fake.Credits() != before-5 {
+			t.Errorf("credits = %v, balance %v → %v, want 5 spent", credits, before, fake.Credits())
+		}
+
+		bookings := fake.Bookings()
+		if len(bookings) != 2 || !bookings[1].Start.Equal(tomorrowAt(t, 11, 0)) || !bookings[1].End.Equal(tomorrowAt(t, 11, 30)) {
+			t.Errorf("bookings = %+v, want the extension from 11:00 to 11:30", bookings)
+		}
+	})
+
+	t.Run("extend_taken", func(t *testing.T) {
+		s, fake, id := book(t)
+		fake.Occupy(cosofttest.DefaultRooms[0].Id, tomorrowAt(t, 11, 15), tomorrowAt(t, 12, 0))
+
+		if _, _, err := s.ResizeReservation(id, 30, false); err == nil {
+			t.Error("ResizeReservation() succeeded on a taken slot")
+		}
+	})
+
+	t.Run("extend_too_long", func(t *testing.T) {
+		s, fake, id := book(t)
+
+		if _, _, err := s.ResizeReservation(id, 75, false); !errors.Is(err, ErrTooLong) {
+			t.Errorf("ResizeReservation() error = %v, want %v", err, ErrTooLong)
+		}
+
+		if len(fake.Bookings()) != 1 {
+			t.Errorf("bookings = %+v, want the reservation untouched", fake.Bookings())
+		}
+	})
+
+	t.Run("extend_after_closing", func(t *testing.T) {
+		s, fake, id := bookAt(t, tomorrowAt(t, 22, 45))
+
+		if _, _, err := s.ResizeReservation(id, 30, false); !errors.Is(err, ErrAfterClosing) {
+			t.Errorf("ResizeReservation() error = %v, want %v", err, ErrAfterClosing)
+		}
+
+		if len(fake.Bookings()) != 1 {
+			t.Errorf("bookings = %+v, want the reservation untouched", fake.Bookings())
+		}
+	})
+
+	t.Run("shorten", func(t *testing.T) {
+		s, fake, id := book(t)
+		before := fake.Credits()
+
+		_, credits, err := s.ResizeReservation(id, -15, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if credits != -2.5 || fake.Credits() != before+2.5 {
+			t.Errorf("credits = %v, balance %v → %v, want 2.5 refunded", credits, before, fake.Credits())
+		}
+
+		bookings := fake.Bookings()
+		if len(bookings) != 1 || !bookings[0].End.Equal(tomorrowAt(t, 10, 45)) {
+			t.Errorf("bookings = %+v, want one ending at 10:45", bookings)
+		}
+	})
+
+	t.Run("shorten_started", func(t *testing.T) {
+		s, fake, id, start := bookStarted(t)
+
+		if _, _, err := s.ResizeReservation(id, -15, false); err != nil {
+			t.Fatal(err)
+		}
+
+		bookings := fake.Bookings()
+		if len(bookings) != 1 || !bookings[0].Start.Equal(start) || !bookings[0].End.Equal(start.Add(45*time.Minute)) {
+			t.Errorf("bookings = %+v, want the same start and 15 minutes less", bookings)
+		}
+	})
+
+	t.Run("shorten_started_to_the_past", func(t *testing.T) {
+		s, fake, id, start := bookStarted(t)
+
+		if _, _, err := s.ResizeReservation(id, -45, false); !errors.Is(err, ErrEndInPast) {
+			t.Errorf("ResizeReservation() error = %v, want %v", err, ErrEndInPast)
+		}
+
+		bookings := fake.Bookings()
+		if len(bookings) != 1 || !bookings[0].End.Equal(start.Add(time.Hour)) {
+			t.Errorf("bookings = %+v, want the reservation untouched", bookings)
+		}
+	})
+
+	t.Run("dry_run", func(t *testing.T) {
+		s, fake, id := book(t)
+		before := fake.Credits()
+
+		for _, minutes := range []int{30, -15} {
+			if _, _, err := s.ResizeReservation(id, minutes, true); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		bookings := fake.Bookings()
+		if len(bookings) != 1 || !bookings[0].End.Equal(tomorrowAt(t, 11, 0)) || fake.Credits() != before {
+			t.Errorf("bookings = %+v, balance %v → %v, want the reservation untouched", bookings, before, fake.Credits())
+		}
+	})
+
+	t.Run("shorten_restores_on_failure", func(t *testing.T) {
+		s, fake, id := book(t)
+		fake.FailOnce(cosofttest.Payment, http.StatusInternalServerError)
+
+		if _, _, err := s.ResizeReservation(id, -15, false); err == nil {
+			t.Fatal("ResizeReservation() succeeded while the payment failed")
+		}
+
+		bookings := fake.Bookings()
+		if len(bookings) != 1 || !bookings[0].End.Equal(tomorrowAt(t, 11, 0)) {
+			t.Errorf("bookings = %+v, want the original reservation restored", bookings)
+		}
+	})
+}
+
+func TestPendingReminders(t *testing.T) {
+	s, fake := newTestService(t)
+	user, _ := s.GetAuthData()
+	start := common.GetClosestQuarterHour().Add(15 * time.Minute)
+
+	_, err := BookFirstAvailable(*user, BookingRequest{Capacity: 1, Duration: 30, DateTime: start}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lead := time.Until(start) + time.Minute
+
+	pending, err := s.PendingReminders(lead)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pending) != 1 || pending[0].OrderResourceRentId != fake.Bookings()[0].Id {
+		t.Fatalf("PendingReminders() = %+v, want the reservation", pending)
+	}
+
+	// Until the notification is delivered, the reservation is still pending.
+	pending, err = s.PendingReminders(lead)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pending) != 1 {
+		t.Fatalf("PendingReminders() = %+v before being marked, want the reservation", pending)
+	}
+
+	if err := s.MarkReminderSent(pending[0].OrderResourceRentId); err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err = s.PendingReminders(lead)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pending) != 0 {
+		t.Errorf("PendingReminders() = %+v once marked, want none", pending)
+	}
+}
+
+func TestNearestFreeSlots(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	tests := []struct {
+		name     string
+		busy     []busyPeriod
+		start    time.Time
+		duration int
+		want     []time.Time
+	}{
+		{
+			name:     "around_a_meeting",
+			busy:     []busyPeriod{{start: at(10, 0), end: at(11, 0)}},
+			start:    at(10, 0),
+			duration: 30,
+			want:     []time.Time{at(9, 30), at(11, 0)},
+		},
+		{
+			name:     "ends_at_closing",
+			busy:     []busyPeriod{{start: at(22, 0), end: at(23, 0)}},
+			start:    at(22, 0),
+			duration: 60,
+			want:     []time.Time{at(21, 0), at(23, 0)},
+		},
+		{
+			name:     "past_closing",
+			busy:     []busyPeriod{{start: at(22, 0), end: at(23, 15)}},
+			start:    at(22, 0),
+			duration: 60,
+			want:     []time.Time{at(21, 0)},
+		},
+		{
+			name:     "before_opening",
+			busy:     []busyPeriod{{start: at(8, 0), end: at(9, 0)}},
+			start:    at(8, 30),
+			duration: 30,
+			want:     []time.Time{at(9, 0)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nearestFreeSlots(tt.busy, tt.start, tt.duration, day)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("nearestFreeSlots() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("nearestFreeSlots() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSuggestSlots(t *testing.T) {
+	s, fake := newTestService(t)
+	user, _ := s.GetAuthData()
+
+	occupy := map[string][2]time.Time{
+		"Salle Bleue": {tomorrowAt(t, 9, 30), tomorrowAt(t, 11, 0)},
+		"Salle Verte": {tomorrowAt(t, 9, 45), tomorrowAt(t, 10, 45)},
+		"Salle Rouge": {tomorrowAt(t, 10, 0), tomorrowAt(t, 10, 30)},
+	}
+
+	for _, room := range cosofttest.DefaultRooms {
+		fake.Occupy(room.Id, occupy[room.Name][0], occupy[room.Name][1])
+	}
+
+	tests := []struct {
+		name     string
+		capacity int
+		features []models.Feature
+		want     []string
+	}{
+		{
+			name:     "closest_first",
+			capacity: 1,
+			want: []string{
+				"Salle Rouge 09:30", "Salle Rouge 10:30",
+				"Salle Verte 09:15", "Salle Verte 10:45",
+				"Salle Bleue 09:00", "Salle Bleue 11:00",
+			},
+		},
+		{
+			name:     "capacity",
+			capacity: 2,
+			want: []string{
+				"Salle Rouge 09:30", "Salle Rouge 10:30",
+				"Salle Bleue 09:00", "Salle Bleue 11:00",
+			},
+		},
+		{
+			name:     "features",
+			capacity: 1,
+			features: []models.Feature{models.FeatureWhiteboard},
+			want:     []string{"Salle Bleue 09:00", "Salle Bleue 11:00"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestions, err := SuggestSlots(*user, tt.capacity, 30, tomorrowAt(t, 10, 0), tt.features)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+
+			for _, s := range suggestions {
+				got = append(got, s.Room.Name+" "+s.Start.Format("15:04"))
+			}
+
+			if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+				t.Errorf("SuggestSlots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// A room whose busy times are unknown is left out, the others are still
+	// suggested.
+	fake.FailOnce(cosofttest.BusyTimes, http.StatusInternalServerError)
+
+	suggestions, err := SuggestSlots(*user, 1, 30, tomorrowAt(t, 10, 0), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rooms := map[string]bool{}
+	for _, suggestion := range suggestions {
+		rooms[suggestion.Room.Name] = true
+	}
+
+	if len(rooms) != len(cosofttest.DefaultRooms)-1 {
+		t.Errorf("SuggestSlots() = %v, want the slots of every room but the unknown one", suggestions)
+	}
+
+	// When no room's busy times are known, nothing can be suggested.
+	fake.Fail(cosofttest.BusyTimes, http.StatusInternalServerError)
+
+	if suggestions, err := SuggestSlots(*user, 1, 30, tomorrowAt(t, 10, 0), nil); err == nil {
+		t.Errorf("SuggestSlots() = %v, want an error when busy times fail", suggestions)
+	}
+}
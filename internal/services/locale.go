@@ -0,0 +1,23 @@
+package services
+
+import "cosoft-cli/internal/i18n"
+
+// Locale returns the language picked in the settings, else the one of the
+// environment.
+func (s *Service) Locale() i18n.Locale {
+	var stored string
+
+	user, err := s.store.GetUserData(nil)
+
+	if err == nil && user != nil {
+		stored = user.Locale
+	}
+
+	return i18n.Pick(stored, i18n.Env())
+}
+
+// SetLocale stores the language of the messages. An empty locale follows
+// the environment.
+func (s *Service) SetLocale(locale i18n.Locale) error {
+	return s.store.SetLocale(nil, string(locale))
+}
@@ -2,8 +2,11 @@ package services
 
 import (
 	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/api/sandbox"
+	"cosoft-cli/internal/settings"
 	"cosoft-cli/internal/storage"
-	"fmt"
+	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
 )
@@ -13,8 +16,13 @@ type Service struct {
 }
 
 func NewService() (*Service, error) {
-	configDir, _ := os.UserConfigDir()
-	store, err := storage.NewStore(fmt.Sprintf("%s/cosoft/data.db", configDir))
+	path, err := settings.DatabasePath()
+
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := storage.NewStore(path)
 
 	if err != nil {
 		return nil, err
@@ -46,12 +54,29 @@ func (s *Service) ClearData() error {
 		return err
 	}
 
-	configDir, err := os.UserConfigDir()
+	path, err := settings.DatabasePath()
 	if err != nil {
 		return err
 	}
 
-	cosoftDir := filepath.Join(configDir, "cosoft")
+	// Leave the real account's data alone when clearing the sandbox's, and
+	// start the sandbox afresh.
+	if sandbox.Enabled() {
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+
+		statePath, err := settings.SandboxStatePath()
+		if err != nil {
+			return err
+		}
+
+		if err := os.Remove(statePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		return nil
+	}
 
-	return os.RemoveAll(cosoftDir)
+	return os.RemoveAll(filepath.Dir(path))
 }
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/common"
+	"time"
+)
+
+func (s *Service) SetReminderLeadTime(minutes int) error {
+	return s.store.SetReminderLeadTime(nil, minutes)
+}
+
+// PendingReminders returns the reservations starting within lead which
+// haven't been reminded yet, once the old reminders are forgotten. They are
+// returned again until marked with MarkReminderSent.
+func (s *Service) PendingReminders(lead time.Duration) ([]api.Reservation, error) {
+	if _, err := s.store.PurgeReminders(time.Now().Add(-common.ReminderRetention)); err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.GetUserData(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	apiClient := api.NewApi()
+	bookings, err := apiClient.GetFutureBookings(user.WAuth, user.WAuthRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []api.Reservation
+
+	for _, r := range common.DueReminders(bookings.Data, lead, time.Now()) {
+		sent, err := s.store.HasSentReminder(r.OrderResourceRentId)
+		if err != nil {
+			return nil, err
+		}
+
+		if sent {
+			continue
+		}
+
+		pending = append(pending, r)
+	}
+
+	return pending, nil
+}
+
+// MarkReminderSent records that the reservation was reminded, once the
+// notification is delivered.
+func (s *Service) MarkReminderSent(reservationId string) error {
+	return s.store.SetReminderSent(reservationId, nil)
+}
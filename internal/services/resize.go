@@ -0,0 +1,190 @@
+package services
+
+import (
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/storage"
+	"cosoft-cli/shared/models"
+	"fmt"
+	"time"
+)
+
+// ResizeReservation moves reservation's end by minutes, in the same room: a
+// positive value extends it, a negative one ends it early. It returns the
+// credits spent, negative when credits are given back.
+//
+// Extending books the following minutes, provided nobody booked them in the
+// meantime. Ending early cancels the reservation and books the shorter one
+// from the same start, even once started, as long as it ends in the future;
+// when that fails, the original reservation is booked again. With dryRun,
+// everything is checked but nothing is booked or cancelled.
+func ResizeReservation(
+	user storage.User,
+	room models.Room,
+	reservation api.Reservation,
+	minutes int,
+	dryRun bool,
+) (float64, error) {
+	if minutes == 0 || minutes%15 != 0 {
+		return 0, ErrResizeNotQuarter
+	}
+
+	location, err := common.LoadLocalTime()
+	if err != nil {
+		return 0, err
+	}
+
+	start, err := time.ParseInLocation("2006-01-02T15:04:05", reservation.Start, location)
+	if err != nil {
+		return 0, err
+	}
+
+	end, err := time.ParseInLocation("2006-01-02T15:04:05", reservation.End, location)
+	if err != nil {
+		return 0, err
+	}
+
+	credits := room.Price * float64(minutes) / 60
+	clientApi := api.NewApi()
+	duration := int(end.Sub(start).Minutes())
+
+	if minutes > 0 {
+		if err := validateLength(start, duration+minutes); err != nil {
+			return 0, err
+		}
+
+		extensionEnd := end.Add(time.Duration(minutes) * time.Minute)
+
+		busySlots, err := clientApi.GetRoomBusyTime(user.WAuth, user.WAuthRefresh, room.Id, end, location)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, slot := range *busySlots {
+			slotStart, err := time.ParseInLocation("2006-01-02T15:04:05", slot.Start, location)
+			if err != nil {
+				return 0, err
+			}
+
+			slotEnd, err := time.ParseInLocation("2006-01-02T15:04:05", slot.End, location)
+			if err != nil {
+				return 0, err
+			}
+
+			if slotStart.Before(extensionEnd) && slotEnd.After(end) {
+				return 0, fmt.Errorf("%w: %s after %s", ErrSlotTaken, room.Name, end.Format("15:04"))
+			}
+		}
+
+		if credits > user.Credits {
+			return 0, ErrNotEnoughCredits
+		}
+
+		if dryRun {
+			return credits, nil
+		}
+
+		err = clientApi.BookRoom(user.WAuth, user.WAuthRefresh, api.CosoftBookingPayload{
+			CosoftAvailabilityPayload: api.CosoftAvailabilityPayload{
+				DateTime: end,
+				Duration: minutes,
+				NbPeople: room.NbUsers,
+			},
+			UserCredits: user.Credits,
+			Room:        room,
+		})
+
+		if err != nil {
+			return 0, err
+		}
+
+		return credits, nil
+	}
+
+	if duration+minutes <= 0 {
+		return 0, ErrEndBeforeStart
+	}
+
+	if !end.Add(time.Duration(minutes) * time.Minute).After(time.Now()) {
+		return 0, ErrEndInPast
+	}
+
+	if dryRun {
+		return credits, nil
+	}
+
+	err = clientApi.CancelBooking(user.WAuth, user.WAuthRefresh, reservation.OrderResourceRentId)
+	if err != nil {
+		return 0, err
+	}
+
+	rebook := func(duration int) error {
+		return clientApi.BookRoom(user.WAuth, user.WAuthRefresh, api.CosoftBookingPayload{
+			CosoftAvailabilityPayload: api.CosoftAvailabilityPayload{
+				DateTime: start,
+				Duration: duration,
+				NbPeople: room.NbUsers,
+			},
+			UserCredits: user.Credits,
+			Room:        room,
+		})
+	}
+
+	if err := rebook(duration + minutes); err != nil {
+		if restoreErr := rebook(duration); restoreErr != nil {
+			return 0, fmt.Errorf("could not book the shorter reservation (%v), nor restore the original one: %v", err, restoreErr)
+		}
+
+		return 0, fmt.Errorf("could not book the shorter reservation, the original one has been restored: %v", err)
+	}
+
+	return credits, nil
+}
+
+// ResizeReservation finds the user's reservation by id, then resizes it in
+// its room. See ResizeReservation.
+func (s *Service) ResizeReservation(reservationId string, minutes int, dryRun bool) (*api.Reservation, float64, error) {
+	user, err := s.store.GetUserData(nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	reservation, err := findReservation(*user, reservationId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := s.EnsureRoomsStored(); err != nil {
+		return nil, 0, err
+	}
+
+	room, err := s.store.GetRoomByName(reservation.ItemName)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if room == nil {
+		return nil, 0, fmt.Errorf("room %s not found", reservation.ItemName)
+	}
+
+	credits, err := ResizeReservation(*user, *room, *reservation, minutes, dryRun)
+
+	return reservation, credits, err
+}
+
+// findReservation returns the user's upcoming reservation with the given id.
+func findReservation(user storage.User, reservationId string) (*api.Reservation, error) {
+	apiClient := api.NewApi()
+	bookings, err := apiClient.GetFutureBookings(user.WAuth, user.WAuthRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range bookings.Data {
+		if r.OrderResourceRentId == reservationId {
+			return &r, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrReservationNotFound, reservationId)
+}
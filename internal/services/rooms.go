@@ -0,0 +1,123 @@
+package services
+
+import (
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/storage"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RoomsTTL is how long the stored rooms are trusted, before being fetched
+// from Cosoft again for their names and prices to stay current.
+const RoomsTTL = 24 * time.Hour
+
+// RoomsStale tells whether the rooms should be fetched again: none are
+// stored, or one was fetched more than RoomsTTL ago.
+func RoomsStale(rooms []storage.Room) bool {
+	if len(rooms) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(rooms, func(r storage.Room) bool {
+		return time.Since(r.RefreshedAt()) > RoomsTTL
+	})
+}
+
+// EnsureRoomsStored fetches the rooms from Cosoft when none are stored, or
+// they are stale.
+func (s *Service) EnsureRoomsStored() error {
+	_, err := s.RefreshStaleRooms()
+
+	return err
+}
+
+// RefreshStaleRooms is EnsureRoomsStored, returning what changed. Stale
+// rooms are better than none: failing to refresh them is only an error when
+// none are stored.
+func (s *Service) RefreshStaleRooms() ([]storage.RoomChange, error) {
+	rooms, err := s.store.GetRooms()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !RoomsStale(rooms) {
+		return nil, nil
+	}
+
+	changes, err := s.RefreshRooms()
+
+	if err != nil && len(rooms) > 0 {
+		return nil, nil
+	}
+
+	return changes, err
+}
+
+// RefreshRooms fetches the rooms from Cosoft and updates the stored ones,
+// returning what changed.
+func (s *Service) RefreshRooms() ([]storage.RoomChange, error) {
+	authData, err := s.store.GetUserData(nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if authData == nil {
+		return nil, errors.New("not logged in")
+	}
+
+	apiClient := api.NewApi()
+	apiRooms, err := apiClient.GetAllRooms(authData.WAuth, authData.WAuthRefresh)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.SyncRooms(apiRooms)
+}
+
+// FavoritePriceChanges returns the price changes of the favorite rooms,
+// named by their name or id as in settings.UserConfig.FavoriteRooms.
+func FavoritePriceChanges(changes []storage.RoomChange, favorites []string) []storage.RoomChange {
+	var repriced []storage.RoomChange
+
+	for _, c := range changes {
+		if c.Kind != storage.RoomRepriced {
+			continue
+		}
+
+		favorite := slices.ContainsFunc(favorites, func(f string) bool {
+			return f == c.Id || strings.EqualFold(f, c.Name) || strings.EqualFold(f, c.OldName)
+		})
+
+		if favorite {
+			repriced = append(repriced, c)
+		}
+	}
+
+	return repriced
+}
+
+// DescribeRoomChange tells what changed about a room, in a line.
+func DescribeRoomChange(c storage.RoomChange, l i18n.Locale) string {
+	switch c.Kind {
+	case storage.RoomAdded:
+		return l.T("cli.rooms.added", c.Name, c.Price)
+	case storage.RoomRemoved:
+		return l.T("cli.rooms.removed", c.Name)
+	case storage.RoomRenamed:
+		return l.T("cli.rooms.renamed", c.OldName, c.Name)
+	default:
+		return l.T("cli.rooms.repriced", c.Name, c.Price, c.OldPrice)
+	}
+}
+
+// DescribeFavoritePriceChange tells that a favorite room's price changed, in a
+// line.
+func DescribeFavoritePriceChange(c storage.RoomChange, l i18n.Locale) string {
+	return l.T("cli.rooms.favorite_repriced", c.Name, c.Price, c.OldPrice)
+}
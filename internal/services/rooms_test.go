@@ -0,0 +1,36 @@
+package services
+
+import (
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/storage"
+	"testing"
+)
+
+func TestDescribeRoomChange(t *testing.T) {
+	tests := []struct {
+		name   string
+		change storage.RoomChange
+		want   string
+	}{
+		{name: "added", change: storage.RoomChange{Kind: storage.RoomAdded, Name: "Mercure", Price: 8}, want: "New room: Mercure, 8.00 credits an hour."},
+		{name: "removed", change: storage.RoomChange{Kind: storage.RoomRemoved, Name: "Mercure"}, want: "Removed room: Mercure."},
+		{name: "renamed", change: storage.RoomChange{Kind: storage.RoomRenamed, Name: "Vénus", OldName: "Mercure"}, want: "Mercure is now called Vénus."},
+		{name: "repriced", change: storage.RoomChange{Kind: storage.RoomRepriced, Name: "Mercure", Price: 12, OldPrice: 8}, want: "Mercure now costs 12.00 credits an hour, instead of 8.00."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DescribeRoomChange(tt.change, i18n.English); got != tt.want {
+				t.Errorf("DescribeRoomChange() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribeFavoritePriceChange(t *testing.T) {
+	change := storage.RoomChange{Kind: storage.RoomRepriced, Name: "Mercure", Price: 12, OldPrice: 8}
+
+	want := "★ Votre salle favorite Mercure coûte maintenant 12.00 crédits de l'heure, au lieu de 8.00."
+	if got := DescribeFavoritePriceChange(change, i18n.French); got != want {
+		t.Errorf("DescribeFavoritePriceChange() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/storage"
+	"cosoft-cli/shared/models"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type busyPeriod struct {
+	start time.Time
+	end   time.Time
+}
+
+// SuggestSlots looks, in every room fitting capacity and having features,
+// for the free slots of duration minutes closest to start on the same day:
+// the last one before it and the first one after it. They are sorted from
+// the closest to start. Rooms whose busy times can't be fetched are unknown,
+// and left out; the search only fails when every room is.
+func SuggestSlots(
+	user storage.User,
+	capacity, duration int,
+	start time.Time,
+	features []models.Feature,
+) ([]models.SlotSuggestion, error) {
+	clientApi := api.NewApi()
+
+	rooms, err := clientApi.GetAllRooms(user.WAuth, user.WAuthRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	rooms = models.FilterRooms(rooms, features)
+
+	location := start.Location()
+	now := time.Now().In(location)
+	results := make([][]models.SlotSuggestion, len(rooms))
+	errs := make([]error, len(rooms))
+	searched := 0
+	var wg sync.WaitGroup
+
+	for i, room := range rooms {
+		if room.NbUsers < capacity {
+			continue
+		}
+
+		searched++
+		wg.Add(1)
+		go func(i int, room models.Room) {
+			defer wg.Done()
+
+			slots, err := clientApi.GetRoomBusyTime(user.WAuth, user.WAuthRefresh, room.Id, start, location)
+			if err != nil {
+				errs[i] = fmt.Errorf("busy times of %s: %w", room.Name, err)
+				return
+			}
+
+			if slots == nil {
+				errs[i] = fmt.Errorf("no busy times returned for %s", room.Name)
+				return
+			}
+
+			busy := make([]busyPeriod, 0, len(*slots))
+
+			for _, slot := range *slots {
+				s, errStart := time.ParseInLocation("2006-01-02T15:04:05", slot.Start, location)
+				e, errEnd := time.ParseInLocation("2006-01-02T15:04:05", slot.End, location)
+
+				if err := errors.Join(errStart, errEnd); err != nil {
+					errs[i] = fmt.Errorf("busy times of %s: %w", room.Name, err)
+					return
+				}
+
+				busy = append(busy, busyPeriod{start: s, end: e})
+			}
+
+			for _, t := range nearestFreeSlots(busy, start, duration, now) {
+				results[i] = append(results[i], models.SlotSuggestion{Room: room, Start: t})
+			}
+		}(i, room)
+	}
+
+	wg.Wait()
+
+	unknown := 0
+
+	for _, err := range errs {
+		if err != nil {
+			unknown++
+		}
+	}
+
+	if unknown > 0 && unknown == searched {
+		return nil, errors.Join(errs...)
+	}
+
+	var suggestions []models.SlotSuggestion
+
+	for _, r := range results {
+		suggestions = append(suggestions, r...)
+	}
+
+	distance := func(t time.Time) time.Duration {
+		return max(t.Sub(start), start.Sub(t))
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		di, dj := distance(suggestions[i].Start), distance(suggestions[j].Start)
+
+		if di != dj {
+			return di < dj
+		}
+
+		return strings.Compare(suggestions[i].Room.Name, suggestions[j].Room.Name) < 0
+	})
+
+	return suggestions, nil
+}
+
+// nearestFreeSlots returns the last quarter hour before start, and the first
+// one after it, where duration minutes are free of busy periods. Slots fit
+// within opening hours, and don't start before now.
+func nearestFreeSlots(busy []busyPeriod, start time.Time, duration int, now time.Time) []time.Time {
+	year, month, day := start.Date()
+	opening := time.Date(year, month, day, common.OpeningHour, 0, 0, 0, start.Location())
+	closing := time.Date(year, month, day, common.ClosingHour, 0, 0, 0, start.Location())
+	length := time.Duration(duration) * time.Minute
+
+	free := func(t time.Time) bool {
+		for _, p := range busy {
+			if t.Before(p.end) && t.Add(length).After(p.start) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	var slots []time.Time
+
+	for t := start.Add(-15 * time.Minute); !t.Before(opening) && !t.Before(now); t = t.Add(-15 * time.Minute) {
+		if free(t) && !t.Add(length).After(closing) {
+			slots = append(slots, t)
+			break
+		}
+	}
+
+	for t := start.Add(15 * time.Minute); !t.Add(length).After(closing); t = t.Add(15 * time.Minute) {
+		if !t.Before(now) && free(t) {
+			slots = append(slots, t)
+			break
+		}
+	}
+
+	return slots
+}
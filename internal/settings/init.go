@@ -1,28 +1,102 @@
 package settings
 
 import (
+	"cosoft-cli/internal/api/sandbox"
 	"cosoft-cli/internal/storage"
+	"cosoft-cli/internal/theme"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
 )
 
 type UserConfig struct {
-	FavoriteRooms    []string `json:"favoriteRooms"`
-	PreferedDuration int      `json:"preferedDuration"`
+	FavoriteRooms    []string     `json:"favoriteRooms"`
+	PreferedDuration int          `json:"preferedDuration"`
+	Theme            theme.Config `json:"theme"`
 }
 
-func EnsureDatabaseExists() error {
+// ConfigPath returns where the user's configuration lives, next to the
+// database.
+func ConfigPath() (string, error) {
 	configDir, err := os.UserConfigDir()
 
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	return filepath.Join(configDir, "cosoft", "config.json"), nil
+}
+
+// LoadConfig reads the user's configuration. A missing file is an empty
+// configuration.
+func LoadConfig() (*UserConfig, error) {
+	config := &UserConfig{}
+
+	path, err := ConfigPath()
+
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// DatabasePath returns where the CLI's database lives. The sandbox has its
+// own, so that it never mixes with the real account.
+func DatabasePath() (string, error) {
+	configDir, err := os.UserConfigDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	name := "data.db"
+
+	if sandbox.Enabled() {
+		name = "sandbox.db"
+	}
+
+	return filepath.Join(configDir, "cosoft", name), nil
+}
+
+// SandboxStatePath returns where the sandbox keeps the user's bookings and
+// credits, next to the databases.
+func SandboxStatePath() (string, error) {
+	path, err := DatabasePath()
+
+	if err != nil {
+		return "", err
 	}
 
-	cosoftDir := fmt.Sprintf("%s/cosoft", configDir)
-	path := fmt.Sprintf("%s/data.db", cosoftDir)
+	return filepath.Join(filepath.Dir(path), "sandbox.json"), nil
+}
+
+func EnsureDatabaseExists() error {
+	path, err := DatabasePath()
+
+	if err != nil {
+		return err
+	}
 
 	// Ensure the cosoft directory exists
-	if err := os.MkdirAll(cosoftDir, 0755); err != nil {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return err
 	}
 
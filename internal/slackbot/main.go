@@ -1,11 +1,18 @@
 package slackbot
 
-import "cosoft-cli/internal/slackbot/services"
+import (
+	"cosoft-cli/internal/slackbot/services"
+	"sync/atomic"
+)
 
 type Bot struct {
 	service *services.SlackService
+	// stopping makes /readyz fail while the server shuts down.
+	stopping atomic.Bool
+	// signingSecret checks that Slack's routes are called by Slack.
+	signingSecret []byte
 }
 
-func NewBot(service *services.SlackService) *Bot {
-	return &Bot{service: service}
+func NewBot(service *services.SlackService, signingSecret string) *Bot {
+	return &Bot{service: service, signingSecret: []byte(signingSecret)}
 }
@@ -0,0 +1,240 @@
+// Package metrics counts what the Slack bot does, and serves it in the
+// Prometheus text format.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	Requests = NewCounter(
+		"cosoft_bot_requests_total",
+		"Requests received, by route, \"unmatched\" for unknown ones.",
+		"route",
+	)
+	Bookings = NewCounter(
+		"cosoft_bot_bookings_total",
+		"Bookings made from Slack, by result.",
+		"result",
+	)
+	Cancellations = NewCounter(
+		"cosoft_bot_cancellations_total",
+		"Reservations cancelled from Slack, by result.",
+		"result",
+	)
+	CosoftRequests = NewCounter(
+		"cosoft_api_requests_total",
+		"Requests sent to Cosoft, by endpoint and status code, \"error\" when no answer came.",
+		"endpoint", "status",
+	)
+	CosoftLatency = NewHistogram(
+		"cosoft_api_request_duration_seconds",
+		"Time Cosoft took to answer, by endpoint.",
+		[]float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+		"endpoint",
+	)
+)
+
+// metric is anything written on /metrics.
+type metric interface {
+	write(w io.Writer)
+}
+
+var registry []metric
+
+// Result labels the outcome of an operation.
+func Result(err error) string {
+	if err != nil {
+		return "error"
+	}
+
+	return "ok"
+}
+
+// Counter is a counter split by labels.
+type Counter struct {
+	name, help string
+	labels     []string
+
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// NewCounter registers a counter with the given labels.
+func NewCounter(name, help string, labels ...string) *Counter {
+	c := &Counter{name: name, help: help, labels: labels, values: map[string]float64{}}
+	registry = append(registry, c)
+
+	return c
+}
+
+// Inc adds one to the counter of the given label values.
+func (c *Counter) Inc(values ...string) {
+	key := labelSet(c.labels, values)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key]++
+}
+
+// Value returns the counter of the given label values.
+func (c *Counter) Value(values ...string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.values[labelSet(c.labels, values)]
+}
+
+func (c *Counter) write(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+
+	for _, key := range sortedKeys(c.values) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, key, formatFloat(c.values[key]))
+	}
+}
+
+// Histogram counts observations in buckets, split by labels.
+type Histogram struct {
+	name, help string
+	labels     []string
+	buckets    []float64
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+type series struct {
+	values []string
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewHistogram registers a histogram with the given upper bounds, sorted.
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	h := &Histogram{name: name, help: help, labels: labels, buckets: buckets, series: map[string]*series{}}
+	registry = append(registry, h)
+
+	return h
+}
+
+// Observe records v for the given label values.
+func (h *Histogram) Observe(v float64, values ...string) {
+	key := labelSet(h.labels, values)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &series{values: values, counts: make([]uint64, len(h.buckets))}
+		h.series[key] = s
+	}
+
+	for i, bound := range h.buckets {
+		if v <= bound {
+			s.counts[i]++
+		}
+	}
+
+	s.count++
+	s.sum += v
+}
+
+// Count returns how many values were observed for the given label values.
+func (h *Histogram) Count(values ...string) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if s, ok := h.series[labelSet(h.labels, values)]; ok {
+		return s.count
+	}
+
+	return 0
+}
+
+func (h *Histogram) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name)
+
+	for _, key := range sortedKeys(h.series) {
+		s := h.series[key]
+		labels := slices.Concat(h.labels, []string{"le"})
+
+		for i, bound := range h.buckets {
+			le := labelSet(labels, slices.Concat(s.values, []string{formatFloat(bound)}))
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, le, s.counts[i])
+		}
+
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, labelSet(labels, slices.Concat(s.values, []string{"+Inf"})), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, key, formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, key, s.count)
+	}
+}
+
+// WriteTo writes every metric in the Prometheus text format.
+func WriteTo(w io.Writer) {
+	for _, m := range registry {
+		m.write(w)
+	}
+}
+
+// Handler serves the metrics, for /metrics.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		WriteTo(w)
+	})
+}
+
+// labelSet formats labels as {name="value",...}, the key of a series.
+func labelSet(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, len(names))
+
+	for i, name := range names {
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+
+		pairs[i] = fmt.Sprintf("%s=%q", name, value)
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func formatFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+
+	return fmt.Sprintf("%g", v)
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	c := &Counter{name: "test_total", help: "Test.", labels: []string{"result"}, values: map[string]float64{}}
+	c.Inc("ok")
+	c.Inc("ok")
+	c.Inc("error")
+
+	h := &Histogram{name: "test_seconds", help: "Test.", labels: []string{"endpoint"}, buckets: []float64{0.5, 1}, series: map[string]*series{}}
+	h.Observe(0.2, "GET /a")
+	h.Observe(0.7, "GET /a")
+
+	var b strings.Builder
+	c.write(&b)
+	h.write(&b)
+
+	want := `# HELP test_total Test.
+# TYPE test_total counter
+test_total{result="error"} 1
+test_total{result="ok"} 2
+# HELP test_seconds Test.
+# TYPE test_seconds histogram
+test_seconds_bucket{endpoint="GET /a",le="0.5"} 1
+test_seconds_bucket{endpoint="GET /a",le="1"} 2
+test_seconds_bucket{endpoint="GET /a",le="+Inf"} 2
+test_seconds_sum{endpoint="GET /a"} 0.8999999999999999
+test_seconds_count{endpoint="GET /a"} 2
+`
+	if b.String() != want {
+		t.Errorf("write() =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	tests := []struct {
+		method, url, want string
+	}{
+		{"POST", "http://cosoft/v2/api/api/users/login", "POST /v2/api/api/users/login"},
+		{
+			"POST",
+			"http://cosoft/CoworkingSpace/a4928a70-38c1-42b9-96f9-b2dd00db5b02/category/7f1e5757-b9b9-4530-84ad-b2dd00db5f0f/items",
+			"POST /CoworkingSpace/:id/category/:id/items",
+		},
+		{"GET", "http://cosoft/Reservation/12345?x=1", "GET /Reservation/:id"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.url, nil)
+		if got := Endpoint(req); got != tt.want {
+			t.Errorf("Endpoint(%s %s) = %q, want %q", tt.method, tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Transport(http.DefaultTransport)}
+
+	resp, err := client.Get(server.URL + "/transport-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if got := CosoftRequests.Value("GET /transport-test", "418"); got != 1 {
+		t.Errorf("requests = %v, want 1", got)
+	}
+	if got := CosoftLatency.Count("GET /transport-test"); got != 1 {
+		t.Errorf("latency observations = %v, want 1", got)
+	}
+
+	failing := &http.Client{Transport: Transport(roundTripper(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("unreachable")
+	}))}
+
+	if _, err := failing.Get(server.URL + "/transport-test"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if got := CosoftRequests.Value("GET /transport-test", "error"); got != 1 {
+		t.Errorf("errors = %v, want 1", got)
+	}
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// idPattern finds the path segments identifying a resource, which would
+// otherwise make an endpoint per room or reservation.
+var idPattern = regexp.MustCompile(`^([0-9a-fA-F]{8}-[0-9a-fA-F-]{27}|[0-9]+)$`)
+
+// Endpoint names the endpoint of req, such as
+// "POST /CoworkingSpace/:id/category/:id/items".
+func Endpoint(req *http.Request) string {
+	segments := strings.Split(req.URL.Path, "/")
+
+	for i, segment := range segments {
+		if idPattern.MatchString(segment) {
+			segments[i] = ":id"
+		}
+	}
+
+	return req.Method + " " + strings.Join(segments, "/")
+}
+
+// Transport records the latency and the status of the requests sent
+// through next, by endpoint.
+func Transport(next http.RoundTripper) http.RoundTripper {
+	return roundTripper(func(req *http.Request) (*http.Response, error) {
+		endpoint := Endpoint(req)
+		start := time.Now()
+
+		resp, err := next.RoundTrip(req)
+
+		CosoftLatency.Observe(time.Since(start).Seconds(), endpoint)
+
+		if err != nil {
+			CosoftRequests.Inc(endpoint, "error")
+			return nil, err
+		}
+
+		CosoftRequests.Inc(endpoint, strconv.Itoa(resp.StatusCode))
+
+		return resp, nil
+	})
+}
+
+type roundTripper func(*http.Request) (*http.Response, error)
+
+func (f roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,42 @@
+package slackbot
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are log attributes whose value is never written.
+var sensitiveKeys = map[string]bool{
+	"password":       true,
+	"token":          true,
+	"w_auth":         true,
+	"w_auth_refresh": true,
+	"cookie":         true,
+	"authorization":  true,
+}
+
+// secretPattern finds credentials inside free text, such as an error
+// wrapping a JSON payload or a query string.
+var secretPattern = regexp.MustCompile(`(?i)("?(?:password|w_auth|w_auth_refresh|token)"?\s*[:=]\s*"?)[^"&;,}\s]+`)
+
+// Redact hides the credentials found in text.
+func Redact(text string) string {
+	return secretPattern.ReplaceAllString(text, "${1}"+redacted)
+}
+
+// RedactAttr is meant for slog.HandlerOptions.ReplaceAttr: it hides the
+// value of sensitive attributes, and credentials found in string values.
+func RedactAttr(_ []string, a slog.Attr) slog.Attr {
+	if sensitiveKeys[strings.ToLower(a.Key)] {
+		return slog.String(a.Key, redacted)
+	}
+
+	if a.Value.Kind() == slog.KindString {
+		return slog.String(a.Key, Redact(a.Value.String()))
+	}
+
+	return a
+}
@@ -1,44 +1,236 @@
 package slackbot
 
 import (
+	"context"
+	"cosoft-cli/internal/slackbot/metrics"
+	"cosoft-cli/internal/slackbot/services"
 	"cosoft-cli/internal/slackbot/views"
+	"cosoft-cli/internal/ui/slack"
 	"cosoft-cli/shared/models"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"os"
+	"strings"
+	"time"
 )
 
-func (b *Bot) StartServer() {
-	s := http.Server{
-		Addr: ":8080",
-		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			slog.Info("Request received", slog.String("method", r.Method), slog.String("url", r.URL.String()))
-
-			switch r.URL.String() {
-			case "/book":
-				b.handleRequests(w, r)
-			case "/interact":
-				b.handleInteractions(w, r)
-			default:
-				fmt.Println("Unknown URL", r.URL.String())
-			}
-		}),
+const (
+	// readyTimeout bounds the checks of /readyz.
+	readyTimeout = 5 * time.Second
+	// submissionWait bounds how long a modal submission waits for the
+	// user's previous work, Slack giving up on the answer after 3 seconds.
+	submissionWait = 2 * time.Second
+)
+
+// ServerConfig tells where and how the bot listens.
+type ServerConfig struct {
+	// Addr is the address to listen on, ":8080" by default.
+	Addr string
+	// CertFile and KeyFile serve HTTPS when both are set.
+	CertFile string
+	KeyFile  string
+	// ShutdownTimeout bounds how long pending work is waited for once the
+	// bot is asked to stop.
+	ShutdownTimeout time.Duration
+}
+
+// ServerConfigFromEnv reads LISTEN_ADDR, TLS_CERT_FILE and TLS_KEY_FILE.
+func ServerConfigFromEnv() (ServerConfig, error) {
+	config := ServerConfig{
+		Addr:            os.Getenv("LISTEN_ADDR"),
+		CertFile:        os.Getenv("TLS_CERT_FILE"),
+		KeyFile:         os.Getenv("TLS_KEY_FILE"),
+		ShutdownTimeout: 30 * time.Second,
 	}
 
-	slog.Info("Server is starting...")
+	if config.Addr == "" {
+		config.Addr = ":8080"
+	}
 
-	err := s.ListenAndServe()
+	if (config.CertFile == "") != (config.KeyFile == "") {
+		return config, errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
 
-	if err != nil {
-		slog.Error("failed to listen and serve", "err", err.Error())
+	return config, nil
+}
+
+// Handler routes the requests of Slack, and the monitoring ones.
+func (b *Bot) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /book", b.verifySlack(b.handleRequests))
+	mux.HandleFunc("POST /interact", b.verifySlack(b.handleInteractions))
+	mux.HandleFunc("POST /events", b.verifySlack(b.handleEvents))
+	mux.Handle("GET /metrics", metrics.Handler())
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /readyz", b.handleReadiness)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log := slog.With(slog.String("request_id", newRequestId()))
+		r = r.WithContext(services.WithLogger(r.Context(), log))
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+
+		mux.ServeHTTP(recorder, r)
+
+		// The mux tells which route served the request, none for 404 and 405.
+		route := r.Pattern
+		if route == "" {
+			route = "unmatched"
+		}
+
+		metrics.Requests.Inc(route)
+		log.Info("request served",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", recorder.status),
+			slog.Duration("duration", time.Since(start)),
+		)
+	})
+}
+
+// statusRecorder remembers the status written, for the logs.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// StartServer serves until ctx is done, then stops accepting requests and
+// waits for the ones in progress, and for the work they started in the
+// background, for at most config.ShutdownTimeout.
+func (b *Bot) StartServer(ctx context.Context, config ServerConfig) error {
+	s := &http.Server{
+		Addr:    config.Addr,
+		Handler: b.Handler(),
+		// Slack gives up on an answer after 3 seconds.
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+		IdleTimeout:       time.Minute,
+	}
+
+	failed := make(chan error, 1)
+
+	go func() {
+		slog.Info("Server is starting...", "addr", config.Addr, "tls", config.CertFile != "")
+
+		if config.CertFile != "" {
+			failed <- s.ListenAndServeTLS(config.CertFile, config.KeyFile)
+		} else {
+			failed <- s.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-failed:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("Server is stopping, waiting for pending work...")
+	b.stopping.Store(true)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	defer cancel()
+
+	if err := s.Shutdown(shutdownCtx); err != nil {
+		return err
 	}
+
+	if err := b.service.Wait(shutdownCtx); err != nil {
+		return fmt.Errorf("pending work was interrupted: %w", err)
+	}
+
+	slog.Info("Server stopped")
+
+	return nil
+}
+
+// newRequestId identifies a request in the logs.
+func newRequestId() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+// withUser adds the Slack user a request comes from to its logger.
+func withUser(r *http.Request, slackUserId string) (context.Context, *slog.Logger) {
+	log := services.Logger(r.Context()).With(slog.String("user", slackUserId))
+
+	return services.WithLogger(r.Context(), log), log
+}
+
+// deliveryKey identifies what Slack delivered, the same whenever it is
+// delivered again, none without an id.
+func deliveryKey(kind, id string) string {
+	if id == "" {
+		return ""
+	}
+
+	return kind + ":" + id
+}
+
+// actionKey identifies a click.
+func actionKey(interaction models.InteractionDiscovery) string {
+	if len(interaction.Actions) == 0 || interaction.Actions[0].ActionTs == "" {
+		return ""
+	}
+
+	action := interaction.Actions[0]
+
+	return deliveryKey("action", interaction.User.ID+":"+action.ActionID+":"+action.ActionTs)
+}
+
+// handleReadiness answers 503 when the database or Cosoft can't be reached,
+// listing the outcome of each check.
+func (b *Bot) handleReadiness(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readyTimeout)
+	defer cancel()
+
+	status := http.StatusOK
+	var report strings.Builder
+
+	if b.stopping.Load() {
+		status = http.StatusServiceUnavailable
+		report.WriteString("stopping\n")
+	}
+
+	for _, check := range b.service.Ready(ctx) {
+		if check.Err != nil {
+			status = http.StatusServiceUnavailable
+			services.Logger(ctx).Warn("readiness check failed", "check", check.Name, "err", check.Err.Error())
+			fmt.Fprintf(&report, "%s: %s\n", check.Name, check.Err.Error())
+			continue
+		}
+
+		fmt.Fprintf(&report, "%s: ok\n", check.Name)
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(report.String()))
 }
 
 func (b *Bot) handleRequests(w http.ResponseWriter, r *http.Request) {
+	log := services.Logger(r.Context())
 	err := r.ParseForm()
 
 	if err != nil {
-		fmt.Println(err)
+		log.Error("could not parse the command", "err", err.Error())
+		http.Error(w, "invalid form", http.StatusBadRequest)
 		return
 	}
 
@@ -48,88 +240,208 @@ func (b *Bot) handleRequests(w http.ResponseWriter, r *http.Request) {
 		UserId:      r.Form.Get("user_id"),
 		ResponseUrl: r.Form.Get("response_url"),
 		TriggerId:   r.Form.Get("trigger_id"),
+		ChannelId:   r.Form.Get("channel_id"),
 	}
 
-	// Clear out user's old slack states
-	err = b.service.ClearUserStates(slackRequest)
+	ctx, log := withUser(r, slackRequest.UserId)
+	log.Info("command received", "command", slackRequest.Command, "text", slackRequest.Text)
 
-	if err != nil {
-		fmt.Println(err)
+	if b.service.Duplicate(deliveryKey("command", slackRequest.TriggerId)) {
+		log.Info("ignoring a command already received", "retry", r.Header.Get("X-Slack-Retry-Num"))
+		w.WriteHeader(http.StatusOK)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	_, err = w.Write([]byte(`{"response_type":"ephemeral","text":"Chargement en cours..."}`))
+	err = json.NewEncoder(w).Encode(map[string]string{
+		"response_type": "ephemeral",
+		"text":          b.service.Locale(slackRequest.UserId).T("slack.loading"),
+	})
 
 	if err != nil {
-		fmt.Println(err)
+		log.Error("could not answer the command", "err", err.Error())
 		return
 	}
 
-	go func() {
+	ctx = context.WithoutCancel(ctx)
+
+	// The command waits for the user's previous actions to be done.
+	b.service.Serialize(slackRequest.UserId, func() {
+		// Clear out user's old slack states
+		err := b.service.ClearUserStates(slackRequest)
+
+		if err != nil {
+			log.Error("could not clear the user's state", "err", err.Error())
+			return
+		}
+
 		view, err := b.service.AuthGuard(slackRequest)
 
 		if err != nil {
-			fmt.Println(err)
+			log.Error("could not check the user's session", "err", err.Error())
 			return
 		}
 
 		if view != nil {
-			blocks := views.RenderView(view)
-			err = b.service.SendToSlack(slackRequest.ResponseUrl, blocks)
+			err = b.service.Present(ctx, slackRequest, view)
 
 			if err != nil {
-				fmt.Println(err)
+				log.Error("could not present the login form", "err", err.Error())
 			}
 
 			return
 		}
 
-		user, err := b.service.RefreshAndGetUser(slackRequest.UserId)
+		if strings.TrimSpace(slackRequest.Text) != "" {
+			err = b.service.HandleCommand(ctx, slackRequest)
+
+			if err != nil {
+				log.Error("command failed", "err", err.Error())
+			}
 
-		if err != nil {
-			fmt.Println(err)
 			return
 		}
 
-		mainMenu := views.LandingView{
-			User: *user,
+		user, err := b.service.RefreshAndGetUser(slackRequest.UserId)
+
+		if err != nil {
+			log.Error("could not refresh the user", "err", err.Error())
+			return
 		}
 
-		err = b.service.SetSlackState(slackRequest.UserId, "landing", &mainMenu)
+		mainMenu, err := b.service.Landing(*user)
 
 		if err != nil {
-			fmt.Println(err)
+			log.Error("could not build the menu", "err", err.Error())
 			return
 		}
 
-		blocks := views.RenderView(&mainMenu)
-		err = b.service.SendToSlack(slackRequest.ResponseUrl, blocks)
+		err = b.service.Present(ctx, slackRequest, mainMenu)
 
 		if err != nil {
-			fmt.Println(err)
+			log.Error("could not present the menu", "err", err.Error())
 			return
 		}
-	}()
+	})
 }
 
 func (b *Bot) handleInteractions(w http.ResponseWriter, r *http.Request) {
+	log := services.Logger(r.Context())
 	err := r.ParseForm()
 
 	if err != nil {
-		fmt.Println(err)
+		log.Error("could not parse the interaction", "err", err.Error())
+		http.Error(w, "invalid form", http.StatusBadRequest)
 		return
 	}
 
 	payload := r.Form.Get("payload")
 
+	var interaction models.InteractionDiscovery
+
+	err = json.Unmarshal([]byte(payload), &interaction)
+
+	if err != nil {
+		log.Error("could not decode the interaction", "err", err.Error())
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	ctx, log := withUser(r, interaction.User.ID)
+	log.Info("interaction received", "type", interaction.Type)
+
+	// Modal submissions must be answered synchronously, to display inline
+	// errors or replace the modal's content.
+	if interaction.Type == "view_submission" {
+		var response *slack.ModalResponse
+
+		ran := b.service.RunSerializedWithin(interaction.User.ID, submissionWait, func() {
+			response, err = b.service.HandleSubmission(ctx, payload)
+		})
+
+		if !ran {
+			log.Warn("submission dropped, the user's previous work is still running")
+			busy := views.RenderBusyModal(b.service.Locale(interaction.User.ID))
+			response = &slack.ModalResponse{ResponseAction: "update", View: &busy}
+		}
+
+		if err != nil {
+			log.Error("submission failed", "err", err.Error())
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+
+		if err != nil {
+			log.Error("could not answer the submission", "err", err.Error())
+		}
+
+		return
+	}
+
 	w.WriteHeader(http.StatusOK)
 
-	go func() {
-		err := b.service.HandleInteraction(payload)
+	if b.service.Duplicate(actionKey(interaction)) {
+		log.Info("ignoring an action already received", "retry", r.Header.Get("X-Slack-Retry-Num"))
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	// A double click is handled once the first click is, on the view it led to.
+	b.service.Serialize(interaction.User.ID, func() {
+		err := b.service.HandleInteraction(ctx, payload)
 
 		if err != nil {
-			fmt.Println(err)
+			log.Error("interaction failed", "err", err.Error())
 		}
-	}()
+	})
+}
+
+func (b *Bot) handleEvents(w http.ResponseWriter, r *http.Request) {
+	log := services.Logger(r.Context())
+	var event models.EventCallback
+
+	err := json.NewDecoder(r.Body).Decode(&event)
+
+	if err != nil {
+		log.Error("could not decode the event", "err", err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Sent once by Slack when the events url is configured.
+	if event.Type == "url_verification" {
+		w.Header().Set("Content-Type", "text/plain")
+		_, err = w.Write([]byte(event.Challenge))
+
+		if err != nil {
+			log.Error("could not answer the url verification", "err", err.Error())
+		}
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	// Slack sends the event again when it isn't acknowledged fast enough.
+	if b.service.Duplicate(deliveryKey("event", event.EventID)) {
+		log.Info("ignoring an event already received", "retry", r.Header.Get("X-Slack-Retry-Num"))
+		return
+	}
+
+	if event.Event.Type == "app_home_opened" && event.Event.Tab == "home" {
+		ctx, log := withUser(r, event.Event.User)
+		ctx = context.WithoutCancel(ctx)
+
+		b.service.Serialize(event.Event.User, func() {
+			err := b.service.PublishHome(ctx, event.Event.User)
+
+			if err != nil {
+				log.Error("could not publish the home tab", "err", err.Error())
+			}
+		})
+	}
 }
@@ -0,0 +1,215 @@
+package slackbot
+
+import (
+	"context"
+	"cosoft-cli/internal/slackbot/services"
+	"cosoft-cli/internal/storage"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSigningSecret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+func newTestBot(t *testing.T) *Bot {
+	t.Helper()
+
+	store, err := storage.NewStore(filepath.Join(t.TempDir(), "database.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	return NewBot(services.NewSlackService(store), testSigningSecret)
+}
+
+// signedRequest returns a request signed as Slack does at sentAt.
+func signedRequest(method, url, body string, sentAt time.Time) *http.Request {
+	r := httptest.NewRequest(method, url, strings.NewReader(body))
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+
+	r.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	r.Header.Set("X-Slack-Signature", sign([]byte(testSigningSecret), timestamp, []byte(body)))
+
+	return r
+}
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "healthz", method: "GET", url: "/healthz", wantStatus: http.StatusOK},
+		{name: "unknown", method: "GET", url: "/unknown", wantStatus: http.StatusNotFound},
+		{name: "wrong_method", method: "GET", url: "/book", wantStatus: http.StatusMethodNotAllowed},
+		{
+			name:       "query_string",
+			method:     "POST",
+			url:        "/events?retry=1",
+			body:       `{"type": "url_verification", "challenge": "abc"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   "abc",
+		},
+		{name: "bad_payload", method: "POST", url: "/events", body: "{", wantStatus: http.StatusBadRequest},
+	}
+
+	handler := newTestBot(t).Handler()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, signedRequest(tt.method, tt.url, tt.body, time.Now()))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestVerifySlack(t *testing.T) {
+	const challenge = `{"type": "url_verification", "challenge": "abc"}`
+
+	// An admin command, which must not be run on behalf of whoever claims
+	// to be an admin.
+	forged := "command=%2Fbook&text=admin+purge+1&user_id=U0ADMIN&response_url=http%3A%2F%2Fexample.com"
+
+	tests := []struct {
+		name       string
+		request    func() *http.Request
+		wantStatus int
+	}{
+		{
+			name: "signed",
+			request: func() *http.Request {
+				return signedRequest("POST", "/events", challenge, time.Now())
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "unsigned",
+			request: func() *http.Request {
+				return httptest.NewRequest("POST", "/book", strings.NewReader(forged))
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "bad_signature",
+			request: func() *http.Request {
+				r := signedRequest("POST", "/interact", "payload=%7B%7D", time.Now())
+				r.Header.Set("X-Slack-Signature", "v0=0123456789abcdef")
+				return r
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "altered_body",
+			request: func() *http.Request {
+				r := signedRequest("POST", "/book", forged, time.Now())
+				r.Body = io.NopCloser(strings.NewReader(strings.Replace(forged, "purge+1", "users", 1)))
+				return r
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "replayed",
+			request: func() *http.Request {
+				return signedRequest("POST", "/events", challenge, time.Now().Add(-10*time.Minute))
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	t.Setenv("ADMIN_SLACK_IDS", "U0ADMIN")
+	handler := newTestBot(t).Handler()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, tt.request())
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestVerifySlackWithoutSecret(t *testing.T) {
+	b := newTestBot(t)
+	b.signingSecret = nil
+
+	rec := httptest.NewRecorder()
+	b.Handler().ServeHTTP(rec, signedRequest("POST", "/events", `{"type": "url_verification"}`, time.Now()))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want every request refused without a signing secret", rec.Code)
+	}
+}
+
+func TestStartServerWaitsForPendingWork(t *testing.T) {
+	tests := []struct {
+		name    string
+		release bool
+		wantErr bool
+	}{
+		{name: "finished", release: true},
+		{name: "timeout", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestBot(t)
+			release := make(chan struct{})
+			finished := false
+
+			b.service.Go(func() {
+				<-release
+				finished = true
+			})
+			t.Cleanup(func() {
+				if !tt.release {
+					close(release)
+				}
+			})
+
+			ctx, cancel := context.WithCancel(t.Context())
+			stopped := make(chan error, 1)
+
+			go func() {
+				stopped <- b.StartServer(ctx, ServerConfig{Addr: "127.0.0.1:0", ShutdownTimeout: 200 * time.Millisecond})
+			}()
+
+			cancel()
+
+			if tt.release {
+				select {
+				case <-stopped:
+					t.Fatal("the server stopped before the pending work finished")
+				case <-time.After(50 * time.Millisecond):
+				}
+
+				close(release)
+			}
+
+			err := <-stopped
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StartServer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.release && !finished {
+				t.Error("the pending work didn't finish")
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/slackbot/views"
+	"cosoft-cli/shared/models"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IsAdmin tells whether slackUserId may run /book admin, see ADMIN_SLACK_IDS.
+func (s *SlackService) IsAdmin(slackUserId string) bool {
+	return slices.Contains(s.admins, slackUserId)
+}
+
+// adminsFromEnv reads the comma separated Slack ids of ADMIN_SLACK_IDS.
+func adminsFromEnv() []string {
+	var admins []string
+
+	for id := range strings.SplitSeq(os.Getenv("ADMIN_SLACK_IDS"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			admins = append(admins, id)
+		}
+	}
+
+	return admins
+}
+
+// LinkedUsers returns the users linked to a Slack account, checking whether
+// their Cosoft token still works.
+func (s *SlackService) LinkedUsers() ([]views.AdminUser, error) {
+	users, err := s.store.GetSlackUsers()
+
+	if err != nil {
+		return nil, err
+	}
+
+	apiClient := api.NewApi()
+	linked := make([]views.AdminUser, len(users))
+	var wg sync.WaitGroup
+
+	for i, user := range users {
+		linked[i] = views.AdminUser{
+			SlackUserId: *user.SlackUserID,
+			Name:        fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+			Email:       user.Email,
+			Credits:     user.Credits,
+		}
+
+		state, err := s.store.GetSlackState(*user.SlackUserID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if state != nil {
+			linked[i].LastSeen = state.UpdatedAt
+		}
+
+		wg.Go(func() {
+			if err := apiClient.GetAuth(user.WAuth, user.WAuthRefresh); err != nil {
+				linked[i].TokenError = err.Error()
+			}
+		})
+	}
+
+	wg.Wait()
+
+	return linked, nil
+}
+
+// ForceLogout logs the Slack user out, forgetting their Cosoft token and
+// views. It tells whether they were logged in.
+func (s *SlackService) ForceLogout(slackUserId string) (bool, error) {
+	user, err := s.store.GetUserData(&slackUserId)
+
+	if err != nil {
+		return false, err
+	}
+
+	err = s.store.ResetUserSlackState(slackUserId)
+
+	if err != nil || user == nil {
+		return false, err
+	}
+
+	// The token may be the reason the user is stuck: logging out from
+	// Cosoft is only a courtesy.
+	_ = api.NewApi().Logout(user.WAuth, user.WAuthRefresh)
+
+	err = s.store.LogoutUser(&slackUserId)
+
+	return err == nil, err
+}
+
+// PurgeStates deletes the views nobody displayed for days, returning how
+// many were.
+func (s *SlackService) PurgeStates(days int) (int64, error) {
+	if days < 1 {
+		return 0, errors.New("the number of days must be positive")
+	}
+
+	return s.store.PurgeSlackStates(time.Now().AddDate(0, 0, -days))
+}
+
+// handleAdminCommand runs /book admin, for the configured admins only.
+func (s *SlackService) handleAdminCommand(ctx context.Context, request models.Request, cmd views.Cmd) error {
+	l := s.Locale(request.UserId)
+
+	if !s.IsAdmin(request.UserId) {
+		Logger(ctx).Warn("admin command refused")
+		return s.SendToSlack(request.ResponseUrl, views.RenderCommandError(l.T("slack.admin.forbidden")))
+	}
+
+	Logger(ctx).Info("admin command", "cmd", fmt.Sprintf("%T", cmd))
+
+	var message string
+
+	switch c := cmd.(type) {
+	case *views.AdminUsersCmd:
+		users, err := s.LinkedUsers()
+
+		if err != nil {
+			return err
+		}
+
+		return s.SendToSlack(request.ResponseUrl, views.RenderAdminUsers(users, l))
+
+	case *views.AdminLogoutCmd:
+		loggedOut, err := s.ForceLogout(c.SlackUserId)
+
+		if err != nil {
+			return err
+		}
+
+		message = l.T("slack.admin.not_logged_in", c.SlackUserId)
+
+		if loggedOut {
+			message = l.T("slack.admin.logged_out", c.SlackUserId)
+		}
+
+	case *views.AdminPurgeCmd:
+		purged, err := s.PurgeStates(c.Days)
+
+		if err != nil {
+			return err
+		}
+
+		message = l.T("slack.admin.purged", purged, c.Days)
+
+	case *views.AdminRoomsCmd:
+		changes, err := s.SyncRooms(ctx)
+
+		if err != nil {
+			message = l.T("slack.admin.rooms_failed", err.Error())
+		} else {
+			message = views.RoomChangesText(changes, l)
+		}
+	}
+
+	return s.SendToSlack(request.ResponseUrl, views.RenderCommandError(message))
+}
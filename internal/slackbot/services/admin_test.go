@@ -0,0 +1,45 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAdminCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		admins      string
+		text        string
+		wantMessage string
+		wantLogout  bool
+	}{
+		{name: "not_admin", admins: "U0ADMIN", text: "admin logout <@" + slackUserId + ">", wantMessage: "Seuls les administrateurs"},
+		{name: "users", admins: slackUserId, text: "admin users", wantMessage: "jeton valide"},
+		{name: "logout", admins: " U0ADMIN, " + slackUserId, text: "admin logout <@" + slackUserId + ">", wantMessage: "a été déconnecté", wantLogout: true},
+		{name: "purge", admins: slackUserId, text: "admin purge 30", wantMessage: "0 vues de plus de 30 jours"},
+		{name: "rooms", admins: slackUserId, text: "admin rooms", wantMessage: "Nouvelle salle : Salle Bleue"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ADMIN_SLACK_IDS", tt.admins)
+
+			b := newTestBot(t)
+			b.login(t)
+
+			b.command(t, tt.text)
+
+			if msg := b.slack.last(t); !strings.Contains(msg, tt.wantMessage) {
+				t.Errorf("message = %s, want it to contain %q", msg, tt.wantMessage)
+			}
+
+			id := slackUserId
+			user, err := b.store.GetUserData(&id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (user == nil) != tt.wantLogout {
+				t.Errorf("user = %+v, want logged out %v", user, tt.wantLogout)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/slackbot/views"
+	"cosoft-cli/internal/storage"
+	"cosoft-cli/shared/models"
+	"fmt"
+	"time"
+)
+
+func init() {
+	handle(runShare)
+	handle(runAttend)
+}
+
+// announce records a booking made from Slack, so that it can be shared in
+// the channel. It returns the announcement's id, 0 when it could not be
+// recorded: the booking is done anyway.
+func (s *SlackService) announce(ctx context.Context, a storage.Announcement) int64 {
+	err := s.store.CreateAnnouncement(&a)
+
+	if err != nil {
+		Logger(ctx).Warn("could not record the booking's announcement", "err", err.Error())
+		return 0
+	}
+
+	return a.Id
+}
+
+func runShare(e *execution, c *views.ShareCmd, v *views.AnnouncementView) (views.View, error) {
+	return v, e.s.share(e.slackUserId, e.target.ResponseUrl, c.AnnouncementId)
+}
+
+func runAttend(e *execution, c *views.AttendCmd, v *views.AnnouncementView) (views.View, error) {
+	var err error
+
+	// Everyone in the channel updates the same message.
+	e.s.RunSerialized(fmt.Sprintf("announcement:%d", c.AnnouncementId), func() {
+		err = e.s.attend(e.slackUserId, e.target.ResponseUrl, c)
+	})
+
+	return v, err
+}
+
+// share posts the announcement in the channel the booking was made from,
+// through the response_url of the message offering to share it, or the one
+// recorded with the booking when shared from a modal.
+func (s *SlackService) share(slackUserId, responseUrl string, announcementId int64) error {
+	a, err := s.store.GetAnnouncement(announcementId)
+
+	if err != nil {
+		return err
+	}
+
+	if responseUrl == "" && a != nil {
+		responseUrl = a.ResponseUrl
+	}
+
+	if a == nil || a.SlackUserId != slackUserId {
+		l := s.Locale(slackUserId)
+		return s.SendToSlack(responseUrl, views.RenderCommandError(l.T("slack.announcement.not_found")))
+	}
+
+	attendees, err := s.store.GetAttendees(a.Id)
+
+	if err != nil {
+		return err
+	}
+
+	return s.SendToSlack(responseUrl, views.RenderAnnouncement(*a, attendees, s.Locale(a.SlackUserId)))
+}
+
+// attend records whether the user attends the announced meeting, and
+// updates the announcement's attendees.
+func (s *SlackService) attend(slackUserId, responseUrl string, c *views.AttendCmd) error {
+	a, err := s.store.GetAnnouncement(c.AnnouncementId)
+
+	if err != nil || a == nil {
+		return err
+	}
+
+	err = s.store.SetAttendance(a.Id, slackUserId, c.Attending)
+
+	if err != nil {
+		return err
+	}
+
+	attendees, err := s.store.GetAttendees(a.Id)
+
+	if err != nil {
+		return err
+	}
+
+	message := views.RenderAnnouncement(*a, attendees, s.Locale(a.SlackUserId))
+	message.ReplaceOriginal = true
+
+	return s.SendToSlack(responseUrl, message)
+}
+
+// bookForTeam announces a booking in the channel it was made from right
+// away, linking it to the channel to notify it of a cancellation.
+func (s *SlackService) bookForTeam(ctx context.Context, user storage.User, request models.Request, a storage.Announcement) error {
+	a.Team = true
+	a.ChannelId = request.ChannelId
+
+	reservation, err := s.findBooking(user, a.RoomName, a.Start)
+
+	if err != nil {
+		Logger(ctx).Warn("could not find the team's reservation", "err", err.Error())
+	} else if reservation != nil {
+		a.ReservationId = reservation.OrderResourceRentId
+	}
+
+	err = s.store.CreateAnnouncement(&a)
+
+	if err != nil {
+		Logger(ctx).Warn("could not record the team's booking", "err", err.Error())
+	}
+
+	return s.SendToSlack(request.ResponseUrl, views.RenderAnnouncement(a, nil, s.Locale(request.UserId)))
+}
+
+// findBooking returns the user's reservation of room starting at start, nil
+// if there is none.
+func (s *SlackService) findBooking(user storage.User, room string, start time.Time) (*api.Reservation, error) {
+	reservations, err := s.fetchReservations(user)
+
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := common.LoadLocalTime()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range reservations {
+		if r.ItemName == room && r.Start == start.In(location).Format("2006-01-02T15:04:05") {
+			return &r, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// notifyTeams tells the channels a cancelled reservation was booked for.
+// The bot may not be a member of the channel, in which case the
+// announcement's response_url is used while it is still valid.
+func (s *SlackService) notifyTeams(ctx context.Context, reservationId string) {
+	log := Logger(ctx)
+	announcements, err := s.store.GetTeamAnnouncements(reservationId)
+
+	if err != nil {
+		log.Warn("could not find the teams to notify of a cancellation", "reservation", reservationId, "err", err.Error())
+		return
+	}
+
+	for _, a := range announcements {
+		message := views.RenderTeamCancellation(a, s.Locale(a.SlackUserId))
+
+		if s.HasBotToken() && a.ChannelId != "" {
+			err := s.PostMessage(a.ChannelId, message)
+
+			if err == nil {
+				continue
+			}
+
+			log.Warn("could not post in the team's channel", "channel", a.ChannelId, "err", err.Error())
+		}
+
+		err := s.SendToSlack(a.ResponseUrl, message)
+
+		if err != nil {
+			log.Warn("could not notify the team of a cancellation", "channel", a.ChannelId, "err", err.Error())
+		}
+	}
+}
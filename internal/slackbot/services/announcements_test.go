@@ -0,0 +1,145 @@
+package services
+
+import (
+	"bytes"
+	"cosoft-cli/shared/models"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clickAs sends a block_actions payload for a button of a message, clicked
+// by slackUserId.
+func (b *testBot) clickAs(t *testing.T, slackUserId, actionId string) {
+	t.Helper()
+
+	raw, err := json.Marshal(map[string]any{
+		"type":         "block_actions",
+		"user":         map[string]string{"id": slackUserId},
+		"response_url": b.slack.URL,
+		"actions":      []map[string]string{{"action_id": actionId}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.service.HandleInteraction(WithSignedRequest(t.Context()), string(raw)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (b *testBot) command(t *testing.T, text string) {
+	t.Helper()
+
+	b.commandAs(t, slackUserId, text)
+}
+
+// commandAs sends the slash command's text, typed by slackUserId, signed by
+// Slack.
+func (b *testBot) commandAs(t *testing.T, slackUserId, text string) {
+	t.Helper()
+
+	err := b.service.HandleCommand(WithSignedRequest(t.Context()), models.Request{
+		UserId:      slackUserId,
+		Text:        text,
+		ResponseUrl: b.slack.URL,
+		ChannelId:   "C0TEAM",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func tomorrowAt(clock string) string {
+	return time.Now().AddDate(0, 0, 1).Format(time.DateOnly) + " " + clock
+}
+
+func TestShareBooking(t *testing.T) {
+	b := newTestBot(t)
+	b.login(t)
+
+	b.command(t, tomorrowAt("10:00")+" 1h")
+
+	if msg := b.slack.last(t); !strings.Contains(msg, "announcement-share:1") {
+		t.Fatalf("message = %s, want the booking to be shareable", msg)
+	}
+
+	b.clickAs(t, slackUserId, "announcement-share:1")
+
+	msg := b.slack.last(t)
+	if !strings.Contains(msg, `"response_type":"in_channel"`) || !strings.Contains(msg, "Salle Bleue") {
+		t.Fatalf("message = %s, want the booking announced in the channel", msg)
+	}
+
+	b.clickAs(t, "U0OTHER", "announcement-join:1")
+	b.clickAs(t, "U0THIRD", "announcement-join:1")
+	b.clickAs(t, "U0THIRD", "announcement-leave:1")
+
+	msg = b.slack.last(t)
+	if !strings.Contains(msg, "U0OTHER") || strings.Contains(msg, "U0THIRD") {
+		t.Errorf("message = %s, want U0OTHER to be the only attendee", msg)
+	}
+	if !strings.Contains(msg, `"replace_original":true`) {
+		t.Errorf("message = %s, want the announcement to be updated", msg)
+	}
+
+	// Only the booker can share the booking.
+	b.clickAs(t, "U0OTHER", "announcement-share:1")
+
+	if msg := b.slack.last(t); strings.Contains(msg, "in_channel") {
+		t.Errorf("message = %s, want the share to be refused", msg)
+	}
+}
+
+func TestTeamBooking(t *testing.T) {
+	b := newTestBot(t)
+	b.login(t)
+
+	b.command(t, "team "+tomorrowAt("10:00")+" 1h")
+
+	if msg := b.slack.last(t); !strings.Contains(msg, "in_channel") || !strings.Contains(msg, "pour l'équipe") {
+		t.Fatalf("message = %s, want the team's booking announced in the channel", msg)
+	}
+
+	announcements, err := b.store.GetTeamAnnouncements(b.fake.Bookings()[0].Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(announcements) != 1 || announcements[0].ChannelId != "C0TEAM" {
+		t.Fatalf("team announcements = %+v, want the booking linked to C0TEAM", announcements)
+	}
+
+	b.command(t, "cancel next")
+
+	b.slack.mu.Lock()
+	defer b.slack.mu.Unlock()
+
+	notified := false
+	for _, msg := range b.slack.messages {
+		notified = notified || strings.Contains(msg, "a annulé la réservation")
+	}
+	if !notified {
+		t.Errorf("messages = %v, want the team to be notified of the cancellation", b.slack.messages)
+	}
+}
+
+func TestNotifyTeamsLogsThroughContext(t *testing.T) {
+	b := newTestBot(t)
+	b.login(t)
+
+	b.command(t, "team "+tomorrowAt("10:00")+" 1h")
+
+	// The team's response_url can't be reached anymore.
+	b.slack.Close()
+
+	var logs bytes.Buffer
+	ctx := WithLogger(t.Context(), slog.New(slog.NewTextHandler(&logs, nil)))
+
+	b.service.notifyTeams(ctx, b.fake.Bookings()[0].Id)
+
+	if !strings.Contains(logs.String(), "could not notify the team of a cancellation") {
+		t.Errorf("logs = %q, want the failure logged by the request's logger", logs.String())
+	}
+}
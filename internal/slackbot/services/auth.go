@@ -12,12 +12,9 @@ func (s *SlackService) AuthGuard(request models.Request) (*views.LoginView, erro
 
 	if err != nil || cookies == nil {
 
-		loginView := &views.LoginView{
-			Email:    "",
-			Password: "",
-		}
+		loginView := &views.LoginView{}
 
-		err := s.store.SetSlackState(request.UserId, "login", loginView)
+		err := s.SetSlackState(request.UserId, loginView)
 
 		if err != nil {
 			return nil, err
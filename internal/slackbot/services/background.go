@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+)
+
+// Go runs f in the background, after Slack has been answered. Wait waits for
+// it before the bot shuts down.
+func (s *SlackService) Go(f func()) {
+	s.pending.Go(f)
+}
+
+// Wait waits for the work started with Go, at most until ctx is done.
+func (s *SlackService) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		s.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -1,19 +1,26 @@
 package services
 
 import (
+	"context"
 	"cosoft-cli/internal/api"
 	"cosoft-cli/internal/common"
+	cliservices "cosoft-cli/internal/services"
+	"cosoft-cli/internal/slackbot/metrics"
 	"cosoft-cli/internal/storage"
 	"cosoft-cli/shared/models"
 	"fmt"
+	"log/slog"
 	"sync"
 	"time"
 )
 
+// getRoomAvailabilities returns the rooms free at dateTime, for nbPeople
+// and duration, having every one of features.
 func (s *SlackService) getRoomAvailabilities(
 	user storage.User,
 	nbPeople, duration int,
 	dateTime time.Time,
+	features []models.Feature,
 ) ([]models.Room, error) {
 
 	apiClient := api.NewApi()
@@ -30,8 +37,10 @@ func (s *SlackService) getRoomAvailabilities(
 		return nil, err
 	}
 
+	rooms = models.FilterRooms(rooms, features)
+
 	if len(rooms) == 0 {
-		return nil, fmt.Errorf(":red_circle: Aucune salle disponible")
+		return nil, cliservices.ErrNoRoomAvailable
 	}
 
 	return rooms, nil
@@ -57,6 +66,7 @@ func (s *SlackService) bookRoom(
 	apiClient := api.NewApi()
 
 	err := apiClient.BookRoom(user.WAuth, user.WAuthRefresh, payload)
+	metrics.Bookings.Inc(metrics.Result(err))
 
 	if err != nil {
 		return err
@@ -77,69 +87,106 @@ func (s *SlackService) fetchReservations(user storage.User) ([]api.Reservation,
 }
 
 func (s *SlackService) cancelReservation(
+	ctx context.Context,
 	user storage.User,
 	reservationId string,
 ) error {
 	apiClient := api.NewApi()
 
-	return apiClient.CancelBooking(user.WAuth, user.WAuthRefresh, reservationId)
+	err := apiClient.CancelBooking(user.WAuth, user.WAuthRefresh, reservationId)
+	metrics.Cancellations.Inc(metrics.Result(err))
+
+	if err == nil {
+		s.notifyTeams(ctx, reservationId)
+	}
+
+	return err
 }
 
-func (s *SlackService) getAllRooms(user storage.User) ([]storage.Room, error) {
-	rooms, err := s.store.GetRooms()
+// resizeReservation moves the end of the user's reservation by minutes, in
+// the same room, and returns the credits it cost.
+func (s *SlackService) resizeReservation(
+	user storage.User,
+	reservationId string,
+	minutes int,
+) (*api.Reservation, float64, error) {
+	reservation, err := s.findReservation(user, reservationId)
+
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	// Rooms are stored, return early
-	if len(rooms) > 0 {
-		return rooms, nil
+	if reservation == nil {
+		return nil, 0, cliservices.ErrReservationNotFound
 	}
 
-	// Fetch the rooms
-	apiClient := api.NewApi()
-	apiRooms, err := apiClient.GetAllRooms(user.WAuth, user.WAuthRefresh)
+	if _, err := s.getAllRooms(user); err != nil {
+		return nil, 0, err
+	}
+
+	room, err := s.store.GetRoomByName(reservation.ItemName)
 
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	// Store them
-	err = s.store.CreateRooms(apiRooms)
+	credits, err := cliservices.ResizeReservation(user, *room, *reservation, minutes, false)
 
+	return reservation, credits, err
+}
+
+// getAllRooms returns the stored rooms, fetching them with the user's token
+// first when they are stale. Stale rooms are better than none: failing to
+// refresh them is only an error when none are stored.
+func (s *SlackService) getAllRooms(user storage.User) ([]storage.Room, error) {
+	rooms, err := s.store.GetRooms()
 	if err != nil {
 		return nil, err
 	}
 
-	rooms = make([]storage.Room, 0, len(apiRooms))
+	// Rooms are fresh, return early
+	if !cliservices.RoomsStale(rooms) {
+		return rooms, nil
+	}
+
+	_, err = s.refreshRooms(context.Background(), user)
 
-	for i, room := range apiRooms {
-		rooms[i] = storage.Room{
-			Id:       room.Id,
-			Name:     room.Name,
-			MaxUsers: room.NbUsers,
-			Price:    room.Price,
+	if err != nil {
+		if len(rooms) > 0 {
+			slog.Warn("could not refresh the rooms", "err", err.Error())
+			return rooms, nil
 		}
+
+		return nil, err
 	}
 
-	// And return them
-	return rooms, nil
+	return s.store.GetRooms()
 }
 
+// getRoomsPlanning builds the rooms' planning of date. Each room's busy times
+// are fetched concurrently, progress being called with the planning of the
+// rooms fetched so far as they arrive, when not nil. The rooms whose busy
+// times couldn't be fetched are marked as unknown, and their names returned.
 func (s *SlackService) getRoomsPlanning(
+	ctx context.Context,
 	user *storage.User,
 	rooms []storage.Room,
 	date time.Time,
 	userBookings []api.Reservation,
-) (string, error) {
+	progress func(calendar string, done, total int),
+) (string, []string, error) {
 	location, err := common.LoadLocalTime()
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	apiClient := api.NewApi()
-	results := make([]models.RoomUsage, len(rooms))
-	var wg sync.WaitGroup
+	results := make([]*models.RoomUsage, len(rooms))
+	done := 0
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
 
 	for i, r := range rooms {
 		wg.Add(1)
@@ -158,23 +205,53 @@ func (s *SlackService) getRoomsPlanning(
 				Name: r.Name,
 			}
 
-			if err == nil && response != nil {
+			switch {
+			case err != nil:
+				Logger(ctx).Warn("could not fetch the busy times of a room", "room", r.Name, "err", err.Error())
+				result.Unknown = true
+			case response == nil:
+				Logger(ctx).Warn("no busy times returned for a room", "room", r.Name)
+				result.Unknown = true
+			default:
 				result.UsedSlots = *response
-			} else {
-				if err != nil {
-					fmt.Printf("Error for %s: %s", r.Name, err.Error())
-				} else {
-					fmt.Printf("Nil response for %s", r.Name)
-				}
 			}
 
-			results[i] = result
+			mu.Lock()
+			defer mu.Unlock()
+
+			results[i] = &result
+			done++
+
+			if progress != nil && done < len(rooms) {
+				progress(buildPlanning(results, userBookings), done, len(rooms))
+			}
 		}(i, r)
 	}
 
 	wg.Wait()
 
-	rows := common.BuildCalendar(0, 16, results, userBookings)
+	var unknown []string
+
+	for _, result := range results {
+		if result.Unknown {
+			unknown = append(unknown, result.Name)
+		}
+	}
+
+	return buildPlanning(results, userBookings), unknown, nil
+}
+
+// buildPlanning renders the rooms' usage, leaving out those not fetched yet.
+func buildPlanning(results []*models.RoomUsage, userBookings []api.Reservation) string {
+	var fetched []models.RoomUsage
+
+	for _, result := range results {
+		if result != nil {
+			fetched = append(fetched, *result)
+		}
+	}
+
+	rows := common.BuildCalendar(0, common.ClosingHour-common.OpeningHour, fetched, userBookings)
 
 	var calendar string
 
@@ -182,5 +259,5 @@ func (s *SlackService) getRoomsPlanning(
 		calendar = fmt.Sprintf("%s\n%s", calendar, row)
 	}
 
-	return calendar, nil
+	return calendar
 }
@@ -0,0 +1,61 @@
+package services
+
+import (
+	cliservices "cosoft-cli/internal/services"
+	"cosoft-cli/internal/slackbot/views"
+	"cosoft-cli/shared/models"
+	"errors"
+)
+
+func init() {
+	handleRetryable(runBrowse)
+	handle(runBook)
+}
+
+func runBrowse(e *execution, c *views.BrowseCmd, v *views.BrowseView) (views.View, error) {
+	rooms, err := e.s.getRoomAvailabilities(*e.user, c.NbPeople, c.Duration, c.Datetime, c.Features)
+
+	// Offer the closest free slots rather than a dead end.
+	if errors.Is(err, cliservices.ErrNoRoomAvailable) {
+		suggestions, suggestErr := cliservices.SuggestSlots(*e.user, c.NbPeople, c.Duration, c.Datetime, c.Features)
+
+		if suggestErr == nil {
+			v.Phase = 1
+			v.Rooms = &[]models.Room{}
+			v.Suggestions = suggestions
+
+			return v, nil
+		}
+	}
+
+	if err != nil {
+		v.Fail(e.locale.T("slack.error.booking"))
+		return v, nil
+	}
+
+	v.Phase = 1
+	v.Rooms = &rooms
+
+	return v, nil
+}
+
+func runBook(e *execution, c *views.BookCmd, v *views.BrowseView) (views.View, error) {
+	booker, err := e.bookingUser(c.BookAs)
+
+	if err != nil {
+		return v, e.failDelegation(err, c.BookAs, v.Fail)
+	}
+
+	err = e.s.bookRoom(*booker, c.NbPeople, c.Duration, c.PickedRoom, c.Datetime)
+
+	if err != nil {
+		v.Fail(e.locale.T("slack.error.booking"))
+		return v, nil
+	}
+
+	v.Phase = 2
+	v.AnnouncementId = e.announce(v, c.PickedRoom, c.Datetime, c.Duration)
+	e.recordDelegatedBooking(booker, c.PickedRoom, c.Datetime, c.Duration)
+
+	return v, nil
+}
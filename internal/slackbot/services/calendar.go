@@ -0,0 +1,52 @@
+package services
+
+import (
+	"cosoft-cli/internal/slackbot/views"
+	"strings"
+)
+
+func init() {
+	handleRetryable(runCalendar)
+}
+
+// runCalendar rebuilds the whole planning every time: the calendar is
+// completely stateless.
+func runCalendar(e *execution, _ *views.CalendarCmd, v *views.CalendarView) (views.View, error) {
+	v.Error = nil
+	v.Calendar = ""
+
+	reservations, err := e.s.fetchReservations(*e.user)
+
+	if err != nil {
+		v.Fail(e.locale.T("slack.error.reservations"))
+		return v, nil
+	}
+
+	// Ensure we have all rooms available.
+	rooms, err := e.s.getAllRooms(*e.user)
+
+	if err != nil {
+		v.Fail(e.locale.T("slack.error.rooms"))
+		return v, nil
+	}
+
+	// The rooms are displayed as their busy times arrive.
+	rows, unknown, err := e.s.getRoomsPlanning(e.ctx, e.user, rooms, v.CurrentDate, reservations, func(calendar string, done, total int) {
+		v.Calendar = calendar
+		e.step(v, done, total)
+	})
+
+	if err != nil {
+		Logger(e.ctx).Error("could not build the calendar", "err", err.Error())
+		v.Fail(e.locale.T("slack.error.calendar"))
+		return v, nil
+	}
+
+	v.Calendar = rows
+
+	if len(unknown) > 0 {
+		v.Fail(e.locale.T("slack.calendar.unknown_rooms", strings.Join(unknown, ", ")))
+	}
+
+	return v, nil
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/common"
+	cliservices "cosoft-cli/internal/services"
+	"cosoft-cli/internal/slackbot/metrics"
+	"cosoft-cli/internal/slackbot/views"
+	"cosoft-cli/internal/storage"
+	"cosoft-cli/shared/models"
+	"sort"
+	"time"
+)
+
+// HandleCommand executes the arguments given to the slash command, without
+// going through the main menu. The user must already be authenticated.
+func (s *SlackService) HandleCommand(ctx context.Context, request models.Request) error {
+	l := s.Locale(request.UserId)
+	cmd, err := views.ParseCommand(request.Text, l)
+
+	if err != nil {
+		return s.SendToSlack(request.ResponseUrl, views.RenderUsage(err.Error(), l))
+	}
+
+	if _, ok := cmd.(*views.HelpCmd); ok {
+		return s.SendToSlack(request.ResponseUrl, views.RenderUsage("", l))
+	}
+
+	if views.IsAdminCmd(cmd) {
+		return s.handleAdminCommand(ctx, request, cmd)
+	}
+
+	user, err := s.RefreshAndGetUser(request.UserId)
+
+	if err != nil {
+		return err
+	}
+
+	target := surface{
+		TriggerId:   request.TriggerId,
+		ResponseUrl: request.ResponseUrl,
+	}
+
+	switch c := cmd.(type) {
+	case *views.RemindCmd:
+		err := s.store.SetReminderLeadTime(&request.UserId, c.LeadTime)
+
+		if err != nil {
+			return err
+		}
+
+		return s.SendToSlack(request.ResponseUrl, views.RenderReminderSettings(c.LeadTime, l))
+
+	case *views.LangCmd:
+		err := s.store.SetLocale(&request.UserId, string(c.Locale))
+
+		if err != nil {
+			return err
+		}
+
+		return s.SendToSlack(request.ResponseUrl, views.RenderLanguageSettings(s.Locale(request.UserId)))
+
+	case *views.ReservationCmd:
+		return s.execute(ctx, request.UserId, target, &views.ReservationView{}, c)
+
+	case *views.DelegateCmd:
+		message, err := s.delegate(ctx, request.UserId, c, l)
+
+		if err != nil {
+			return err
+		}
+
+		return s.SendToSlack(request.ResponseUrl, views.RenderCommandError(message))
+
+	case *views.RevokeCmd:
+		message, err := s.revoke(request.UserId, c, l)
+
+		if err != nil {
+			return err
+		}
+
+		return s.SendToSlack(request.ResponseUrl, views.RenderCommandError(message))
+
+	case *views.DelegationsCmd:
+		message, err := s.delegations(request.UserId, l)
+
+		if err != nil {
+			return err
+		}
+
+		return s.SendToSlack(request.ResponseUrl, message)
+
+	case *views.DirectBookCmd:
+		booker, err := s.bookingUser(ctx, request.UserId, c.BookAs, user)
+
+		if reason, ok := delegationFailure(err, c.BookAs, l); ok {
+			return s.SendToSlack(request.ResponseUrl, views.RenderCommandError(reason))
+		}
+
+		if err != nil {
+			return err
+		}
+
+		room, err := cliservices.BookFirstAvailable(*booker, c.Request, nil)
+		metrics.Bookings.Inc(metrics.Result(err))
+
+		if err != nil {
+			return s.SendToSlack(
+				request.ResponseUrl,
+				views.RenderCommandError(l.T("slack.error.booking_reason", failureReason(ctx, err, l))),
+			)
+		}
+
+		if booker != user {
+			s.recordDelegatedBooking(ctx, c.BookAs, request.UserId, *room, c.Request.DateTime, c.Request.Duration)
+		}
+
+		announcement := storage.Announcement{
+			SlackUserId: request.UserId,
+			RoomName:    room.Name,
+			RoomImage:   room.Image,
+			Start:       c.Request.DateTime,
+			End:         c.Request.DateTime.Add(time.Duration(c.Request.Duration) * time.Minute),
+			ResponseUrl: request.ResponseUrl,
+		}
+
+		if c.Team {
+			return s.bookForTeam(ctx, *booker, request, announcement)
+		}
+
+		announcementId := s.announce(ctx, announcement)
+
+		return s.SendToSlack(request.ResponseUrl, views.RenderDirectBooking(*room, c.Request, announcementId, l))
+
+	case *views.CancelNextCmd:
+		reservation, err := s.nextCancellableReservation(*user)
+
+		if err != nil {
+			return s.SendToSlack(
+				request.ResponseUrl,
+				views.RenderCommandError(l.T("slack.error.reservations")),
+			)
+		}
+
+		if reservation == nil {
+			return s.SendToSlack(
+				request.ResponseUrl,
+				views.RenderCommandError(l.T("slack.command.nothing_to_cancel")),
+			)
+		}
+
+		err = s.cancelReservation(ctx, *user, reservation.OrderResourceRentId)
+
+		if err != nil {
+			return s.SendToSlack(
+				request.ResponseUrl,
+				views.RenderCommandError(l.T("slack.error.cancel")),
+			)
+		}
+
+		return s.SendToSlack(request.ResponseUrl, views.RenderCancellation(*reservation, l))
+	}
+
+	return nil
+}
+
+// nextCancellableReservation returns the user's earliest reservation which
+// hasn't started yet, or nil when there is none.
+func (s *SlackService) nextCancellableReservation(user storage.User) (*api.Reservation, error) {
+	reservations, err := s.fetchReservations(user)
+
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := common.LoadLocalTime()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(reservations, func(i, j int) bool {
+		return reservations[i].Start < reservations[j].Start
+	})
+
+	for _, r := range reservations {
+		start, err := time.ParseInLocation("2006-01-02T15:04:05", r.Start, location)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if start.After(time.Now()) {
+			return &r, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/slackbot/views"
+	"cosoft-cli/internal/storage"
+	"cosoft-cli/internal/ui/slack"
+	"cosoft-cli/shared/models"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxAuditLines is how many delegated bookings /book delegations lists.
+const maxAuditLines = 10
+
+var (
+	errNotDelegate        = errors.New("not allowed to book with this account")
+	errPrincipalLoggedOut = errors.New("the account's owner is logged out")
+	errUnsignedDelegation = errors.New("booking with another account requires a request signed by Slack")
+)
+
+// Landing returns the main menu of user, along with the principals the
+// booking views offer to book as.
+func (s *SlackService) Landing(user storage.User) (*views.LandingView, error) {
+	landing := &views.LandingView{User: user}
+
+	if user.SlackUserID == nil {
+		return landing, nil
+	}
+
+	principals, err := s.principals(*user.SlackUserID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	landing.Principals = principals
+
+	return landing, nil
+}
+
+// principals returns the users who allowed slackUserId to book with their
+// account.
+func (s *SlackService) principals(slackUserId string) ([]views.Principal, error) {
+	users, err := s.store.GetPrincipals(slackUserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	principals := make([]views.Principal, len(users))
+
+	for i, u := range users {
+		principals[i] = views.Principal{
+			SlackUserId: *u.SlackUserID,
+			Name:        fmt.Sprintf("%s %s", u.FirstName, u.LastName),
+		}
+	}
+
+	return principals, nil
+}
+
+// bookingUser returns the account slackUserId books with: own, unless
+// bookAs names a principal who allowed it. Another account is only used
+// when ctx is about a request signed by Slack, else anyone could spend the
+// principal's credits by claiming to be one of their delegates.
+func (s *SlackService) bookingUser(ctx context.Context, slackUserId, bookAs string, own *storage.User) (*storage.User, error) {
+	if bookAs == "" || bookAs == slackUserId {
+		return own, nil
+	}
+
+	if !signedRequest(ctx) {
+		return nil, errUnsignedDelegation
+	}
+
+	allowed, err := s.store.IsDelegate(bookAs, slackUserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !allowed {
+		return nil, errNotDelegate
+	}
+
+	principal, err := s.store.GetUserData(&bookAs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if principal == nil {
+		return nil, errPrincipalLoggedOut
+	}
+
+	return s.RefreshAndGetUser(bookAs)
+}
+
+// delegationFailure explains why the user can't book as bookAs, when err
+// comes from bookingUser.
+func delegationFailure(err error, bookAs string, l i18n.Locale) (string, bool) {
+	switch {
+	case errors.Is(err, errNotDelegate), errors.Is(err, errUnsignedDelegation):
+		return l.T("slack.error.not_delegate", bookAs), true
+	case errors.Is(err, errPrincipalLoggedOut):
+		return l.T("slack.error.principal_logged_out", bookAs), true
+	}
+
+	return "", false
+}
+
+// recordDelegatedBooking adds a booking made with the owner's account to
+// the audit trail, and tells the owner about it when the bot can. The
+// booking is done anyway, so failures are only logged.
+func (s *SlackService) recordDelegatedBooking(ctx context.Context, owner, delegate string, room models.Room, start time.Time, duration int) {
+	booking := storage.DelegatedBooking{
+		OwnerSlackUserId:    owner,
+		DelegateSlackUserId: delegate,
+		RoomName:            room.Name,
+		Start:               start,
+		End:                 start.Add(time.Duration(duration) * time.Minute),
+		Cost:                room.Price * float64(duration) / 60,
+	}
+
+	err := s.store.RecordDelegatedBooking(&booking)
+
+	if err != nil {
+		Logger(ctx).Error("could not record a delegated booking", "owner", owner, "err", err.Error())
+	}
+
+	if !s.HasBotToken() {
+		return
+	}
+
+	l := s.Locale(owner)
+	notice := l.T("slack.delegation.booking_notice", delegate, room.Name, l.DateTime(booking.Start), l.Time(booking.End), booking.Cost)
+
+	err = s.PostMessage(owner, views.RenderCommandError(notice))
+
+	if err != nil {
+		Logger(ctx).Warn("could not tell the owner of a delegated booking", "owner", owner, "err", err.Error())
+	}
+}
+
+// delegate allows c.SlackUserId to book with the owner's account, and
+// tells them when the bot can.
+func (s *SlackService) delegate(ctx context.Context, owner string, c *views.DelegateCmd, l i18n.Locale) (string, error) {
+	if c.SlackUserId == owner {
+		return l.T("slack.delegation.self"), nil
+	}
+
+	err := s.store.Delegate(owner, c.SlackUserId)
+
+	if err != nil {
+		return "", err
+	}
+
+	if s.HasBotToken() {
+		notice := s.Locale(c.SlackUserId).T("slack.delegation.granted_notice", owner, owner)
+		err := s.PostMessage(c.SlackUserId, views.RenderCommandError(notice))
+
+		if err != nil {
+			Logger(ctx).Warn("could not tell the delegate", "delegate", c.SlackUserId, "err", err.Error())
+		}
+	}
+
+	return l.T("slack.delegation.granted", c.SlackUserId), nil
+}
+
+// revoke withdraws a delegation of the owner's account.
+func (s *SlackService) revoke(owner string, c *views.RevokeCmd, l i18n.Locale) (string, error) {
+	revoked, err := s.store.Revoke(owner, c.SlackUserId)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !revoked {
+		return l.T("slack.delegation.not_delegate", c.SlackUserId), nil
+	}
+
+	return l.T("slack.delegation.revoked", c.SlackUserId), nil
+}
+
+// delegations lists the delegations of slackUserId's account and to it,
+// with the last bookings made through them.
+func (s *SlackService) delegations(slackUserId string, l i18n.Locale) (slack.Block, error) {
+	delegates, err := s.store.GetDelegates(slackUserId)
+
+	if err != nil {
+		return slack.Block{}, err
+	}
+
+	principals, err := s.principals(slackUserId)
+
+	if err != nil {
+		return slack.Block{}, err
+	}
+
+	bookings, err := s.store.GetDelegatedBookings(slackUserId, maxAuditLines)
+
+	if err != nil {
+		return slack.Block{}, err
+	}
+
+	return views.RenderDelegations(delegates, principals, bookings, l), nil
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"cosoft-cli/internal/slackbot/views"
+	"cosoft-cli/shared/models"
+	"strings"
+	"testing"
+)
+
+const ownerId = "U0OWNER"
+
+// loginOwner logs in a second account, whose owner can delegate it.
+func (b *testBot) loginOwner(t *testing.T) {
+	t.Helper()
+
+	user := b.fake.LoginResponse()
+	user.Id = "00000000-0000-0000-0000-0000000000aa"
+	user.Email = "owner@example.com"
+	user.FirstName = "Alice"
+	user.LastName = "Martin"
+	id := ownerId
+
+	if err := b.store.SetUser(user, user.JwtToken, user.RefreshToken, &id); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDelegation(t *testing.T) {
+	b := newTestBot(t)
+	b.login(t)
+	b.loginOwner(t)
+
+	bookAs := "as <@" + ownerId + "> " + tomorrowAt("10:00") + " 1h"
+
+	b.command(t, bookAs)
+
+	if msg := b.slack.last(t); !strings.Contains(msg, "ne vous a pas autorisé") {
+		t.Fatalf("message = %s, want the booking refused before the delegation", msg)
+	}
+
+	b.commandAs(t, ownerId, "delegate <@"+slackUserId+">")
+
+	if msg := b.slack.last(t); !strings.Contains(msg, "peut maintenant réserver") {
+		t.Fatalf("message = %s, want the delegation confirmed", msg)
+	}
+
+	b.command(t, bookAs)
+
+	if msg := b.slack.last(t); !strings.Contains(msg, "Réservation réussie") {
+		t.Fatalf("message = %s, want the booking made", msg)
+	}
+
+	audit, err := b.store.GetDelegatedBookings(ownerId, maxAuditLines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(audit) != 1 || audit[0].DelegateSlackUserId != slackUserId || audit[0].RoomName != "Salle Bleue" {
+		t.Fatalf("audit = %+v, want the booking made by %s", audit, slackUserId)
+	}
+
+	b.commandAs(t, ownerId, "delegations")
+
+	if msg := b.slack.last(t); !strings.Contains(msg, `pour \u003c@`+ownerId) || !strings.Contains(msg, "Salle Bleue") {
+		t.Errorf("message = %s, want the delegated booking listed", msg)
+	}
+
+	b.commandAs(t, ownerId, "revoke <@"+slackUserId+">")
+	b.command(t, bookAs)
+
+	if msg := b.slack.last(t); !strings.Contains(msg, "ne vous a pas autorisé") {
+		t.Errorf("message = %s, want the booking refused once revoked", msg)
+	}
+}
+
+func TestDelegationRequiresSignedRequest(t *testing.T) {
+	b := newTestBot(t)
+	b.login(t)
+	b.loginOwner(t)
+
+	if err := b.store.Delegate(ownerId, slackUserId); err != nil {
+		t.Fatal(err)
+	}
+
+	// A request whose user id nobody checked, which may come from anyone.
+	err := b.service.HandleCommand(t.Context(), models.Request{
+		UserId:      slackUserId,
+		Text:        "as <@" + ownerId + "> " + tomorrowAt("10:00") + " 1h",
+		ResponseUrl: b.slack.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg := b.slack.last(t); !strings.Contains(msg, "ne vous a pas autorisé") {
+		t.Errorf("message = %s, want the booking refused", msg)
+	}
+
+	if bookings := b.fake.Bookings(); len(bookings) != 0 {
+		t.Errorf("bookings = %+v, want none made with the owner's account", bookings)
+	}
+}
+
+func TestQuickBookAs(t *testing.T) {
+	b := newTestBot(t)
+	b.login(t)
+	b.loginOwner(t)
+
+	if err := b.store.Delegate(ownerId, slackUserId); err != nil {
+		t.Fatal(err)
+	}
+
+	id := slackUserId
+
+	user, err := b.store.GetUserData(&id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	landing, err := b.service.Landing(*user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.service.SetSlackState(slackUserId, landing); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.interact(t, "quick-book", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if msg := b.slack.last(t); !strings.Contains(msg, "Alice Martin") {
+		t.Fatalf("message = %s, want the owner offered to book as", msg)
+	}
+
+	values := merge(selectValue("duration", "30"), selectValue("nbPeople", "1"), selectValue("bookAs", ownerId))
+
+	if err := b.interact(t, "quick-book", values); err != nil {
+		t.Fatal(err)
+	}
+
+	if msg := b.slack.last(t); !strings.Contains(msg, "Réservé avec le compte de Alice Martin") {
+		t.Errorf("message = %s, want the booking made with the owner's account", msg)
+	}
+
+	if qb, ok := b.currentView(t).(*views.QuickBookView); !ok || qb.BookAs != ownerId {
+		t.Errorf("view = %+v, want the quick book made as %s", b.currentView(t), ownerId)
+	}
+
+	audit, err := b.store.GetDelegatedBookings(slackUserId, maxAuditLines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(audit) != 1 || audit[0].OwnerSlackUserId != ownerId {
+		t.Errorf("audit = %+v, want the quick book recorded", audit)
+	}
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"cosoft-cli/internal/i18n"
+	cliservices "cosoft-cli/internal/services"
+	"errors"
+)
+
+// reasons words the errors of the booking services.
+var reasons = []struct {
+	err error
+	key string
+}{
+	{cliservices.ErrDateInPast, "slack.reason.date_in_past"},
+	{cliservices.ErrNotOnQuarter, "slack.reason.not_on_quarter"},
+	{cliservices.ErrDurationNotQuarter, "slack.reason.duration_not_quarter"},
+	{cliservices.ErrTooLong, "slack.reason.too_long"},
+	{cliservices.ErrAfterClosing, "slack.reason.after_closing"},
+	{cliservices.ErrNoRoomAvailable, "slack.reason.no_room"},
+	{cliservices.ErrNoRoomWithFeatures, "slack.reason.no_room_with_features"},
+	{cliservices.ErrRoomNotAvailable, "slack.reason.room_not_available"},
+	{cliservices.ErrNotEnoughCredits, "slack.reason.not_enough_credits"},
+	{cliservices.ErrResizeNotQuarter, "slack.reason.resize_not_quarter"},
+	{cliservices.ErrSlotTaken, "slack.reason.slot_taken"},
+	{cliservices.ErrAlreadyStarted, "slack.reason.already_started"},
+	{cliservices.ErrEndBeforeStart, "slack.reason.end_before_start"},
+	{cliservices.ErrEndInPast, "slack.reason.end_in_past"},
+	{cliservices.ErrReservationNotFound, "slack.reason.not_found"},
+}
+
+// failureReason words err in the user's language. Errors the services don't
+// document, from Cosoft or the network, are logged and shown as unexpected.
+func failureReason(ctx context.Context, err error, l i18n.Locale) string {
+	for _, r := range reasons {
+		if errors.Is(err, r.err) {
+			return l.T(r.key)
+		}
+	}
+
+	Logger(ctx).Warn("unexpected failure", "err", err.Error())
+
+	return l.T("slack.reason.unexpected")
+}
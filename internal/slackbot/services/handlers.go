@@ -0,0 +1,309 @@
+package services
+
+import (
+	"context"
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/slackbot/views"
+	"cosoft-cli/internal/storage"
+	"cosoft-cli/internal/ui/slack"
+	"cosoft-cli/shared/models"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// execution is what a command handler runs with: the user who issued the
+// command, and where its view is displayed.
+type execution struct {
+	ctx         context.Context
+	s           *SlackService
+	slackUserId string
+	target      surface
+	user        *storage.User
+	locale      i18n.Locale
+
+	// progress is what has been displayed while a retryable command loads
+	// its view, see step and timeout.
+	progress struct {
+		mu       sync.Mutex
+		updates  int
+		shownAt  time.Time
+		timedOut bool
+		loaded   bool
+	}
+}
+
+// show displays view while the command goes on, such as the rooms found
+// before one of them is booked.
+func (e *execution) show(view views.View) error {
+	return e.s.commit(e.ctx, e.slackUserId, e.target, view)
+}
+
+// step displays how far the command loading view went, done out of total
+// steps being finished. Updates are throttled: a response_url can only be
+// used five times, and Slack rate limits views.update.
+func (e *execution) step(view views.View, done, total int) {
+	p, ok := views.AsProgress(view)
+
+	if !ok || !p.Loading {
+		return
+	}
+
+	e.progress.mu.Lock()
+	defer e.progress.mu.Unlock()
+
+	p.Step(done, total)
+
+	if e.progress.timedOut || e.progress.updates >= maxProgressUpdates || time.Since(e.progress.shownAt) < progressInterval {
+		return
+	}
+
+	e.progress.updates++
+	e.progress.shownAt = time.Now()
+
+	err := e.s.deliver(e.ctx, e.slackUserId, e.target, view, 0)
+
+	if err != nil {
+		Logger(e.ctx).Warn("could not display the progress", "err", err.Error())
+	}
+}
+
+// timeout replaces the view being loaded with a message offering to try
+// again, Cosoft being too slow to answer. The command still runs, and its
+// view replaces the message once loaded.
+func (e *execution) timeout(messageType string) {
+	e.progress.mu.Lock()
+	defer e.progress.mu.Unlock()
+
+	if e.progress.loaded {
+		return
+	}
+
+	e.progress.timedOut = true
+	blocks := views.RenderTimeout(e.locale)
+
+	var err error
+
+	if e.s.HasBotToken() && e.target.ViewId != "" {
+		err = e.s.UpdateModal(e.target.ViewId, slack.NewModal("Cosoft", e.locale.T("slack.close"), messageType, blocks))
+	} else {
+		err = e.s.SendToSlack(e.target.ResponseUrl, slack.Block{Blocks: blocks})
+	}
+
+	if err != nil {
+		Logger(e.ctx).Warn("could not display the timeout", "err", err.Error())
+	}
+}
+
+// announce records the booking of room made from view, see
+// SlackService.announce.
+func (e *execution) announce(view views.View, room models.Room, start time.Time, duration int) int64 {
+	responseUrl := e.target.ResponseUrl
+
+	// Modals are shared in the conversation they were opened from.
+	if modal, ok := views.AsModal(view); ok && responseUrl == "" {
+		responseUrl = modal.ResponseUrl
+	}
+
+	return e.s.announce(e.ctx, storage.Announcement{
+		SlackUserId: e.slackUserId,
+		RoomName:    room.Name,
+		RoomImage:   room.Image,
+		Start:       start,
+		End:         start.Add(time.Duration(duration) * time.Minute),
+		ResponseUrl: responseUrl,
+	})
+}
+
+// bookingUser returns the account the user books with, see
+// SlackService.bookingUser.
+func (e *execution) bookingUser(bookAs string) (*storage.User, error) {
+	return e.s.bookingUser(e.ctx, e.slackUserId, bookAs, e.user)
+}
+
+// failDelegation displays with fail why the user couldn't book as bookAs,
+// returning other errors.
+func (e *execution) failDelegation(err error, bookAs string, fail func(string)) error {
+	reason, ok := delegationFailure(err, bookAs, e.locale)
+
+	if !ok {
+		return err
+	}
+
+	fail(reason)
+
+	return nil
+}
+
+// reason words err in the user's language, see failureReason.
+func (e *execution) reason(err error) string {
+	return failureReason(e.ctx, err, e.locale)
+}
+
+// recordDelegatedBooking audits a booking made with booker's account when
+// it isn't the user's own.
+func (e *execution) recordDelegatedBooking(booker *storage.User, room models.Room, start time.Time, duration int) {
+	if booker == e.user || booker.SlackUserID == nil {
+		return
+	}
+
+	e.s.recordDelegatedBooking(e.ctx, *booker.SlackUserID, e.slackUserId, room, start, duration)
+}
+
+const (
+	// progressInterval is the least time between two progress updates.
+	progressInterval = time.Second
+	// maxProgressUpdates leaves enough uses of the response_url for the
+	// loader, the timeout and the loaded view.
+	maxProgressUpdates = 2
+)
+
+// handler runs a command's side effects, and returns the view to display.
+type handler struct {
+	run func(e *execution, cmd views.Cmd, view views.View) (views.View, error)
+	// retryable commands only read from Cosoft: their view displays its
+	// progress while loading, and can be loaded again when Cosoft is slow.
+	retryable bool
+}
+
+// handlerKey identifies a handler: the same command may be run differently
+// depending on the view it comes from.
+type handlerKey struct {
+	cmd, view reflect.Type
+}
+
+var (
+	handlers = map[handlerKey]handler{}
+	// anyView is the view type of the handlers running a command whichever
+	// view it comes from.
+	anyView = reflect.TypeFor[views.View]()
+)
+
+// handle registers f as the handler of the commands of type C, returned
+// along with views of type V by Update. Each handler registers itself from
+// its file's init function.
+func handle[C views.Cmd, V views.View](f func(e *execution, cmd C, view V) (views.View, error)) {
+	register(f, false)
+}
+
+// handleRetryable registers f like handle, for commands which only read
+// from Cosoft, see handler.
+func handleRetryable[C views.Cmd, V views.ProgressView](f func(e *execution, cmd C, view V) (views.View, error)) {
+	register(f, true)
+}
+
+func register[C views.Cmd, V views.View](f func(e *execution, cmd C, view V) (views.View, error), retryable bool) {
+	cmdType := reflect.TypeFor[C]()
+	key := handlerKey{cmd: cmdType, view: reflect.TypeFor[V]()}
+
+	if _, ok := handlers[key]; ok {
+		panic(fmt.Sprintf("services: %s is handled twice from %s", cmdType, key.view))
+	}
+
+	handlers[key] = handler{
+		run: func(e *execution, cmd views.Cmd, view views.View) (views.View, error) {
+			return f(e, cmd.(C), view.(V))
+		},
+		retryable: retryable,
+	}
+}
+
+// handlerOf returns the handler of cmd, returned along with view by Update.
+func handlerOf(cmd views.Cmd, view views.View) (handler, error) {
+	cmdType := reflect.TypeOf(cmd)
+
+	for _, viewType := range []reflect.Type{reflect.TypeOf(view), anyView} {
+		if h, ok := handlers[handlerKey{cmd: cmdType, view: viewType}]; ok {
+			return h, nil
+		}
+	}
+
+	for key := range handlers {
+		if key.cmd == cmdType {
+			return handler{}, fmt.Errorf("%s cannot be run from the %s view", cmdType, views.ViewType(view))
+		}
+	}
+
+	return handler{}, fmt.Errorf("no handler for %s", cmdType)
+}
+
+// execute runs the side effects requested by a view's Update, then stores
+// and displays the resulting view. Views which aren't stored, such as the
+// home tab, are displayed by their handlers.
+func (s *SlackService) execute(ctx context.Context, slackUserId string, target surface, newView views.View, cmd views.Cmd) error {
+	if cmd != nil {
+		h, err := handlerOf(cmd, newView)
+
+		if err != nil {
+			return err
+		}
+
+		user, err := s.store.GetUserData(&slackUserId)
+
+		if err != nil {
+			return err
+		}
+
+		e := &execution{
+			ctx:         ctx,
+			s:           s,
+			slackUserId: slackUserId,
+			target:      target,
+			user:        user,
+			locale:      s.Locale(slackUserId),
+		}
+
+		if h.retryable {
+			newView, err = s.load(e, h, cmd, newView)
+		} else {
+			newView, err = h.run(e, cmd, newView)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, stored := views.TypeOf(newView); !stored {
+		return nil
+	}
+
+	return s.commit(ctx, slackUserId, target, newView)
+}
+
+// load runs a retryable command, displaying its view's progress meanwhile,
+// and a timeout when Cosoft is slow.
+func (s *SlackService) load(e *execution, h handler, cmd views.Cmd, view views.View) (views.View, error) {
+	p, ok := views.AsProgress(view)
+
+	if !ok {
+		return h.run(e, cmd, view)
+	}
+
+	p.Start()
+
+	err := e.show(view)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The timeout is displayed in the modal show may have opened.
+	if modal, ok := views.AsModal(view); ok && !modal.Inline {
+		e.target.ViewId = modal.ViewId
+	}
+
+	messageType := views.ViewType(view)
+	timer := time.AfterFunc(s.slowAfter, func() { e.timeout(messageType) })
+	loaded, err := h.run(e, cmd, view)
+	timer.Stop()
+
+	e.progress.mu.Lock()
+	defer e.progress.mu.Unlock()
+
+	e.progress.loaded = true
+	p.Stop()
+
+	return loaded, err
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"cosoft-cli/internal/api/cosofttest"
+	"cosoft-cli/internal/slackbot/views"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name    string
+		view    views.View
+		cmd     views.Cmd
+		wantErr string
+	}{
+		{name: "unknown_command", view: &views.LandingView{}, cmd: &views.RemindCmd{}, wantErr: "no handler"},
+		{name: "wrong_view", view: &views.LandingView{}, cmd: &views.BookCmd{}, wantErr: "cannot be run from the landing view"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestBot(t)
+			b.login(t)
+
+			err := b.service.execute(t.Context(), slackUserId, surface{ResponseUrl: b.slack.URL}, tt.view, tt.cmd)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("execute() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExecuteDetached(t *testing.T) {
+	b := newTestBot(t)
+	b.login(t)
+
+	// Releasing a room from a reminder runs its own handler, and leaves the
+	// stored view alone.
+	b.clickAs(t, slackUserId, "reminder-not-going:missing")
+
+	if msg := b.slack.last(t); !strings.Contains(msg, "Réservation introuvable") {
+		t.Errorf("message = %s, want the reservation not to be found", msg)
+	}
+
+	if got := views.ViewType(b.currentView(t)); got != "landing" {
+		t.Errorf("view = %s, want landing", got)
+	}
+}
+
+func TestRunReservations(t *testing.T) {
+	b := newTestBot(t)
+	b.login(t)
+
+	id := slackUserId
+	user, err := b.store.GetUserData(&id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := &execution{ctx: t.Context(), s: b.service, slackUserId: slackUserId, user: user, locale: b.service.Locale(slackUserId)}
+
+	view, err := runReservations(e, &views.ReservationCmd{}, &views.ReservationView{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rView := view.(*views.ReservationView)
+	if rView.Error != nil || rView.Reservations == nil {
+		t.Errorf("runReservations() = %+v, want the reservations to be listed", rView)
+	}
+}
+
+func TestLoadProgress(t *testing.T) {
+	b := newTestBot(t)
+	b.login(t)
+
+	if err := b.interact(t, "calendar", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	b.slack.mu.Lock()
+	messages := slices.Clone(b.slack.messages)
+	b.slack.mu.Unlock()
+
+	if len(messages) < 3 {
+		t.Fatalf("messages = %v, want the loader, the progress and the calendar", messages)
+	}
+	if !strings.Contains(messages[0], "Chargement en cours") {
+		t.Errorf("first message = %s, want the loader", messages[0])
+	}
+	if !strings.Contains(messages[1], "1/3") {
+		t.Errorf("second message = %s, want the first room's progress", messages[1])
+	}
+	if last := messages[len(messages)-1]; strings.Contains(last, "Chargement") || !strings.Contains(last, "Salle Rouge") {
+		t.Errorf("last message = %s, want the whole calendar", last)
+	}
+
+	if view := b.currentView(t).(*views.CalendarView); view.Loading {
+		t.Error("the stored calendar is still loading")
+	}
+}
+
+func TestCalendarUnknownRooms(t *testing.T) {
+	b := newTestBot(t)
+	b.login(t)
+	b.fake.Fail(cosofttest.BusyTimes, http.StatusInternalServerError)
+
+	if err := b.interact(t, "calendar", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := b.slack.last(t)
+	if !strings.Contains(msg, "Salle Rouge") || !strings.Contains(msg, "?") || !strings.Contains(msg, "n'a pas pu être chargée") {
+		t.Errorf("message = %s, want the rooms shown as unknown rather than free", msg)
+	}
+}
+
+func TestLoadTimeout(t *testing.T) {
+	b := newTestBot(t)
+	b.login(t)
+	b.service.slowAfter = 20 * time.Millisecond
+	b.fake.Delay(cosofttest.Reservations, 200*time.Millisecond)
+
+	if err := b.interact(t, "reservations", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	b.slack.mu.Lock()
+	messages := slices.Clone(b.slack.messages)
+	b.slack.mu.Unlock()
+
+	if !slices.ContainsFunc(messages, func(msg string) bool { return strings.Contains(msg, `"retry"`) }) {
+		t.Errorf("messages = %v, want a retry to be offered", messages)
+	}
+	if last := b.slack.last(t); !strings.Contains(last, "réservation") || strings.Contains(last, `"retry"`) {
+		t.Errorf("last message = %s, want the reservations once loaded", last)
+	}
+
+	b.fake.Delay(cosofttest.Reservations, 0)
+
+	if err := b.interact(t, "retry", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if last := b.slack.last(t); !strings.Contains(last, "pas de réservation") {
+		t.Errorf("last message = %s, want the reservations to be loaded again", last)
+	}
+}
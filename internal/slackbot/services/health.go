@@ -0,0 +1,21 @@
+package services
+
+import (
+	"context"
+	"cosoft-cli/internal/api"
+)
+
+// Check is the outcome of one of the checks telling whether the bot is ready.
+type Check struct {
+	Name string
+	Err  error
+}
+
+// Ready checks the database and Cosoft can be reached, which every request
+// relies on.
+func (s *SlackService) Ready(ctx context.Context) []Check {
+	return []Check{
+		{Name: "database", Err: s.store.Ping(ctx)},
+		{Name: "cosoft", Err: api.NewApi().Ping(ctx)},
+	}
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"cosoft-cli/internal/slackbot/views"
+	"cosoft-cli/internal/ui/slack"
+	"strings"
+	"time"
+)
+
+func init() {
+	handle(runHomeCancel)
+}
+
+// PublishHome builds the user's dashboard and publishes it in the App Home tab.
+func (s *SlackService) PublishHome(ctx context.Context, slackUserId string) error {
+	home, err := s.buildHome(ctx, slackUserId)
+
+	if err != nil {
+		return err
+	}
+
+	return s.publishHomeView(slackUserId, views.RenderHomeView(home, s.Locale(slackUserId)))
+}
+
+func (s *SlackService) buildHome(ctx context.Context, slackUserId string) (*views.HomeView, error) {
+	home := &views.HomeView{}
+	l := s.Locale(slackUserId)
+
+	cookies, err := s.store.HasActiveToken(&slackUserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Not logged in yet, the home tab will tell how to.
+	if cookies == nil {
+		return home, nil
+	}
+
+	user, err := s.RefreshAndGetUser(slackUserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	home.User = user
+
+	reservations, err := s.fetchReservations(*user)
+
+	if err != nil {
+		errMsg := l.T("slack.error.reservations")
+		home.Error = &errMsg
+		return home, nil
+	}
+
+	home.Reservations = reservations
+
+	rooms, err := s.getAllRooms(*user)
+
+	if err != nil {
+		errMsg := l.T("slack.error.rooms")
+		home.Error = &errMsg
+		return home, nil
+	}
+
+	calendar, unknown, err := s.getRoomsPlanning(ctx, user, rooms, time.Now(), reservations, nil)
+
+	if err != nil {
+		errMsg := l.T("slack.error.calendar")
+		home.Error = &errMsg
+		return home, nil
+	}
+
+	home.Calendar = calendar
+
+	if len(unknown) > 0 {
+		errMsg := l.T("slack.calendar.unknown_rooms", strings.Join(unknown, ", "))
+		home.Error = &errMsg
+	}
+
+	return home, nil
+}
+
+// runHomeCancel cancels a reservation from the App Home tab, which is then
+// published again.
+func runHomeCancel(e *execution, c *views.CancelReservationCmd, v *views.HomeView) (views.View, error) {
+	err := e.s.cancelReservation(e.ctx, *e.user, *c.ReservationId)
+
+	if err == nil {
+		return v, e.s.PublishHome(e.ctx, e.slackUserId)
+	}
+
+	home, err := e.s.buildHome(e.ctx, e.slackUserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	errMsg := e.locale.T("slack.error.cancel")
+	home.Error = &errMsg
+
+	return v, e.s.publishHomeView(e.slackUserId, views.RenderHomeView(home, e.locale))
+}
+
+func (s *SlackService) publishHomeView(slackUserId string, home slack.Home) error {
+	_, err := s.callSlackApi("views.publish", map[string]any{
+		"user_id": slackUserId,
+		"view":    home,
+	})
+
+	return err
+}
@@ -0,0 +1,17 @@
+package services
+
+import "cosoft-cli/internal/slackbot/views"
+
+func init() {
+	handle(runLanding)
+}
+
+func runLanding(e *execution, _ *views.LandingCmd, _ views.View) (views.View, error) {
+	user, err := e.s.RefreshAndGetUser(e.slackUserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return e.s.Landing(*user)
+}
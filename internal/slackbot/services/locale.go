@@ -0,0 +1,81 @@
+package services
+
+import (
+	"cosoft-cli/internal/i18n"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+)
+
+// Locale returns the language the bot speaks to slackUserId in: the one
+// picked with /book lang, else the user's Slack language, else the
+// server's.
+func (s *SlackService) Locale(slackUserId string) i18n.Locale {
+	var stored string
+
+	user, err := s.store.GetUserData(&slackUserId)
+
+	if err == nil && user != nil {
+		stored = user.Locale
+	}
+
+	return i18n.Pick(stored, s.slackLocale(slackUserId), i18n.Env())
+}
+
+// slackLocale returns the language set in the user's Slack preferences,
+// cached for the lifetime of the bot. It is empty without a bot token.
+func (s *SlackService) slackLocale(slackUserId string) string {
+	if !s.HasBotToken() || slackUserId == "" {
+		return ""
+	}
+
+	if locale, ok := s.locales.Load(slackUserId); ok {
+		return locale.(string)
+	}
+
+	locale, err := s.fetchSlackLocale(slackUserId)
+
+	if err != nil {
+		slog.Warn("could not fetch the user's locale", "user", slackUserId, "err", err.Error())
+		return ""
+	}
+
+	s.locales.Store(slackUserId, locale)
+
+	return locale
+}
+
+// fetchSlackLocale calls users.info, which only takes form parameters.
+func (s *SlackService) fetchSlackLocale(slackUserId string) (string, error) {
+	query := url.Values{"user": {slackUserId}, "include_locale": {"true"}}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/users.info?%s", slackApiUrl, query.Encode()), nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+s.botToken)
+
+	resp, err := http.DefaultClient.Do(req)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	response := slackApiResponse{}
+
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", err
+	}
+
+	if !response.Ok {
+		return "", fmt.Errorf("users.info failed: %s", response.Error)
+	}
+
+	return response.User.Locale, nil
+}
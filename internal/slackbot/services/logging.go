@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+)
+
+type loggerKey struct{}
+
+type signedKey struct{}
+
+// WithLogger returns ctx carrying log, to which everything done on behalf
+// of a request is logged, including what happens in the background once it
+// has been answered.
+func WithLogger(ctx context.Context, log *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, log)
+}
+
+// Logger returns the logger ctx carries, the default one when it has none.
+func Logger(ctx context.Context) *slog.Logger {
+	if log, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return log
+	}
+
+	return slog.Default()
+}
+
+// WithSignedRequest returns ctx telling that the request it is about was
+// signed by Slack, so that its user id can be trusted.
+func WithSignedRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, signedKey{}, true)
+}
+
+// signedRequest tells whether ctx is about a request signed by Slack.
+func signedRequest(ctx context.Context) bool {
+	signed, _ := ctx.Value(signedKey{}).(bool)
+
+	return signed
+}
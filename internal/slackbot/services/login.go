@@ -0,0 +1,24 @@
+package services
+
+import "cosoft-cli/internal/slackbot/views"
+
+func init() {
+	handle(runLogin)
+}
+
+func runLogin(e *execution, c *views.LoginCmd, v *views.LoginView) (views.View, error) {
+	err := e.s.LogInUser(c.Email, c.Password, e.slackUserId)
+
+	if err != nil {
+		v.Fail(":red_circle: " + e.locale.T("slack.error.login"))
+		return v, nil
+	}
+
+	user, err := e.s.store.GetUserData(&e.slackUserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return e.s.Landing(*user)
+}
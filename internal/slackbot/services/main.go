@@ -2,12 +2,35 @@ package services
 
 import (
 	"cosoft-cli/internal/storage"
+	"os"
+	"sync"
+	"time"
 )
 
 type SlackService struct {
-	store *storage.Store
+	store    *storage.Store
+	botToken string
+	// locales caches the users' Slack language, see Locale.
+	locales sync.Map
+	// pending tracks the work done in the background, see Go.
+	pending    sync.WaitGroup
+	queues     queues
+	deliveries deliveries
+	// slowAfter is how long a view loads before the user is offered to
+	// try again.
+	slowAfter time.Duration
+	// admins are the Slack users allowed to run /book admin.
+	admins []string
+	// refusedTokens are the tokens Cosoft refused while sending reminders,
+	// by Slack user, see remind.
+	refusedTokens sync.Map
 }
 
 func NewSlackService(store *storage.Store) *SlackService {
-	return &SlackService{store}
+	return &SlackService{
+		store:     store,
+		botToken:  os.Getenv("SLACK_BOT_TOKEN"),
+		slowAfter: 15 * time.Second,
+		admins:    adminsFromEnv(),
+	}
 }
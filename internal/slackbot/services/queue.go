@@ -0,0 +1,187 @@
+package services
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// duplicateWindow is how long a delivery is remembered, longer than Slack
+// keeps retrying.
+const duplicateWindow = 10 * time.Minute
+
+// queues runs each user's work one at a time, in the order it came in, so
+// that two clicks can't book twice nor overwrite each other's view.
+type queues struct {
+	mu      sync.Mutex
+	pending map[string][]func()
+}
+
+// deliveries remembers what Slack has already delivered, see Duplicate.
+type deliveries struct {
+	mu     sync.Mutex
+	seen   map[string]time.Time
+	pruned time.Time
+}
+
+// Serialize runs f in the background, once the work queued before for the
+// same user is done.
+func (s *SlackService) Serialize(slackUserId string, f func()) {
+	s.queues.mu.Lock()
+	defer s.queues.mu.Unlock()
+
+	if s.queues.pending == nil {
+		s.queues.pending = map[string][]func(){}
+	}
+
+	queue, running := s.queues.pending[slackUserId]
+	s.queues.pending[slackUserId] = append(queue, f)
+
+	if !running {
+		s.Go(func() { s.drain(slackUserId) })
+	}
+}
+
+// RunSerialized runs f in turn with the user's queued work, and waits for it.
+func (s *SlackService) RunSerialized(slackUserId string, f func()) {
+	done := make(chan struct{})
+
+	s.Serialize(slackUserId, func() {
+		defer close(done)
+		f()
+	})
+
+	<-done
+}
+
+// RunSerializedWithin is RunSerialized, except that f is dropped when the
+// user's previous work isn't done within wait. It tells whether f ran.
+func (s *SlackService) RunSerializedWithin(slackUserId string, wait time.Duration, f func()) bool {
+	const (
+		pending int32 = iota
+		started
+		dropped
+	)
+
+	var state atomic.Int32
+	begun := make(chan struct{})
+	done := make(chan struct{})
+
+	s.Serialize(slackUserId, func() {
+		defer close(done)
+
+		if !state.CompareAndSwap(pending, started) {
+			return
+		}
+
+		close(begun)
+		f()
+	})
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-begun:
+	case <-timer.C:
+		if state.CompareAndSwap(pending, dropped) {
+			return false
+		}
+	}
+
+	<-done
+
+	return true
+}
+
+// drain runs the user's work until there is none left.
+func (s *SlackService) drain(slackUserId string) {
+	for {
+		s.queues.mu.Lock()
+		queue := s.queues.pending[slackUserId]
+
+		if len(queue) == 0 {
+			delete(s.queues.pending, slackUserId)
+			s.queues.mu.Unlock()
+			return
+		}
+
+		s.queues.pending[slackUserId] = queue[1:]
+		s.queues.mu.Unlock()
+
+		runSafely(slackUserId, queue[0])
+	}
+}
+
+// runSafely runs f, logging its panic rather than letting one bad job stop
+// the bot, and the user's next jobs.
+func runSafely(slackUserId string, f func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("queued work panicked", "user", slackUserId, "panic", fmt.Sprint(r), "stack", string(debug.Stack()))
+		}
+	}()
+
+	f()
+}
+
+// Duplicate tells whether key, identifying something Slack delivered, was
+// already seen in the last duplicateWindow, remembering it otherwise. An
+// empty key is never a duplicate.
+func (s *SlackService) Duplicate(key string) bool {
+	if key == "" {
+		return false
+	}
+
+	d := &s.deliveries
+	now := time.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.seen == nil {
+		d.seen = map[string]time.Time{}
+	}
+
+	if now.Sub(d.pruned) > duplicateWindow {
+		for k, at := range d.seen {
+			if now.Sub(at) > duplicateWindow {
+				delete(d.seen, k)
+			}
+		}
+
+		d.pruned = now
+	}
+
+	if at, ok := d.seen[key]; ok && now.Sub(at) <= duplicateWindow {
+		return true
+	}
+
+	d.seen[key] = now
+
+	return false
+}
+
+// outdated tells whether the user acted on a modal displaying a version of
+// their view which has been replaced since, such as the second click of a
+// double click. Messages and the modals displaying views which aren't stored
+// carry no version, and are never outdated.
+func (s *SlackService) outdated(slackUserId, version string) (bool, error) {
+	displayed, err := strconv.ParseInt(version, 10, 64)
+
+	if err != nil {
+		return false, nil
+	}
+
+	state, err := s.store.GetSlackState(slackUserId)
+
+	if err != nil || state == nil {
+		return false, err
+	}
+
+	return displayed != state.Version, nil
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"cosoft-cli/internal/slackbot/views"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSerialize(t *testing.T) {
+	s := &SlackService{}
+
+	var mu sync.Mutex
+	var order []int
+	running := map[string]int{}
+
+	for i := range 20 {
+		user := fmt.Sprintf("U%d", i%2)
+
+		s.Serialize(user, func() {
+			mu.Lock()
+			running[user]++
+			if running[user] > 1 {
+				t.Errorf("%s has two jobs running at once", user)
+			}
+			if user == "U0" {
+				order = append(order, i)
+			}
+			mu.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			running[user]--
+			mu.Unlock()
+		})
+	}
+
+	if err := s.Wait(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+
+	for j := 1; j < len(order); j++ {
+		if order[j] < order[j-1] {
+			t.Fatalf("jobs ran out of order: %v", order)
+		}
+	}
+
+	if len(order) != 10 {
+		t.Errorf("ran %d jobs of U0, want 10", len(order))
+	}
+}
+
+func TestSerializeRecovers(t *testing.T) {
+	s := &SlackService{}
+	ran := false
+
+	s.Serialize("U0", func() { panic("bad job") })
+	s.Serialize("U0", func() { ran = true })
+
+	if err := s.Wait(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !ran {
+		t.Error("the job queued after a panic didn't run")
+	}
+}
+
+func TestRunSerializedWithin(t *testing.T) {
+	tests := []struct {
+		name    string
+		busy    time.Duration
+		wantRan bool
+	}{
+		{name: "idle", wantRan: true},
+		{name: "short_wait", busy: 10 * time.Millisecond, wantRan: true},
+		{name: "still_busy", busy: time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &SlackService{}
+			release := make(chan struct{})
+			ran := false
+
+			s.Serialize("U0", func() {
+				select {
+				case <-release:
+				case <-time.After(tt.busy):
+				}
+			})
+
+			got := s.RunSerializedWithin("U0", 100*time.Millisecond, func() { ran = true })
+			close(release)
+
+			if err := s.Wait(t.Context()); err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.wantRan || ran != tt.wantRan {
+				t.Errorf("RunSerializedWithin() = %v, ran %v, want %v", got, ran, tt.wantRan)
+			}
+		})
+	}
+}
+
+func TestHandleInteractionWithoutAction(t *testing.T) {
+	b := newTestBot(t)
+	b.login(t)
+
+	payload := `{"type": "block_suggestion", "user": {"id": "` + slackUserId + `"}}`
+
+	if err := b.service.HandleInteraction(t.Context(), payload); err != nil {
+		t.Errorf("HandleInteraction() error = %v, want the payload ignored", err)
+	}
+}
+
+func TestDuplicate(t *testing.T) {
+	s := &SlackService{}
+
+	if s.Duplicate("event:1") {
+		t.Error("first delivery reported as a duplicate")
+	}
+	if !s.Duplicate("event:1") {
+		t.Error("second delivery not reported as a duplicate")
+	}
+	if s.Duplicate("") || s.Duplicate("") {
+		t.Error("empty keys reported as duplicates")
+	}
+}
+
+func TestHandleInteractionOutdated(t *testing.T) {
+	b := newTestBot(t)
+	b.login(t)
+
+	state, err := b.store.GetSlackState(slackUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clicked on the view displayed before the landing one, such as the
+	// second click of a double click once the first one has been handled.
+	before := strconv.FormatInt(state.Version-1, 10)
+
+	if err := b.interactOn(t, "quick-book", before, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := views.ViewType(b.currentView(t)); got != "landing" {
+		t.Fatalf("view = %s, want landing to be left alone", got)
+	}
+
+	current := strconv.FormatInt(state.Version, 10)
+
+	if err := b.interactOn(t, "quick-book", current, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := views.ViewType(b.currentView(t)); got != "quick-book" {
+		t.Fatalf("view = %s, want quick-book", got)
+	}
+}
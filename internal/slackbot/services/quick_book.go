@@ -0,0 +1,63 @@
+package services
+
+import (
+	cliservices "cosoft-cli/internal/services"
+	"cosoft-cli/internal/slackbot/views"
+	"cosoft-cli/shared/models"
+)
+
+func init() {
+	handle(runQuickBook)
+}
+
+func runQuickBook(e *execution, c *views.QuickBookCmd, v *views.QuickBookView) (views.View, error) {
+	booker, err := e.bookingUser(c.BookAs)
+
+	if err != nil {
+		return v, e.failDelegation(err, c.BookAs, v.Fail)
+	}
+
+	rooms, err := e.s.getRoomAvailabilities(*e.user, c.NbPeople, c.Duration, c.Datetime, nil)
+
+	if err != nil {
+		v.Fail(e.locale.T("slack.error.booking_reason", e.reason(err)))
+		return v, nil
+	}
+
+	v.Phase = 2
+	v.Rooms = &rooms
+
+	err = e.show(v)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var pickedRoom *models.Room
+
+	for _, room := range rooms {
+		if room.NbUsers >= c.NbPeople {
+			pickedRoom = &room
+			break
+		}
+	}
+
+	if pickedRoom == nil {
+		err = cliservices.ErrNoRoomAvailable
+	} else if booker.Credits < pickedRoom.Price {
+		err = cliservices.ErrNotEnoughCredits
+	} else {
+		err = e.s.bookRoom(*booker, c.NbPeople, c.Duration, *pickedRoom, c.Datetime)
+	}
+
+	if err != nil {
+		v.Fail(e.locale.T("slack.error.booking_reason", e.reason(err)))
+	} else {
+		v.PickedRoom = pickedRoom
+		v.Phase = 3
+		v.AnnouncementId = e.announce(v, *pickedRoom, c.Datetime, c.Duration)
+		e.recordDelegatedBooking(booker, *pickedRoom, c.Datetime, c.Duration)
+	}
+
+	return v, nil
+}
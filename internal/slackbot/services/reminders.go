@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/slackbot/views"
+	"cosoft-cli/internal/storage"
+	"errors"
+	"time"
+)
+
+func init() {
+	handle(runReminderExtend)
+	handle(runReminderCancel)
+	handle(runNotGoing)
+}
+
+// StartReminders sends a direct message to each user before their
+// reservations start, checking every interval until ctx is done.
+func (s *SlackService) StartReminders(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.remind(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// remind sends the reminders which are due, and forgets the old ones. The
+// users whose token Cosoft refused are skipped until they log in again.
+func (s *SlackService) remind(ctx context.Context) {
+	log := Logger(ctx)
+
+	if _, err := s.store.PurgeReminders(time.Now().Add(-common.ReminderRetention)); err != nil {
+		log.Error("could not purge the reminders", "err", err.Error())
+	}
+
+	users, err := s.store.GetSlackUsersWithReminders()
+
+	if err != nil {
+		log.Error("could not load users for reminders", "err", err.Error())
+		return
+	}
+
+	for _, user := range users {
+		if refused, ok := s.refusedTokens.Load(*user.SlackUserID); ok && refused == user.WAuth {
+			continue
+		}
+
+		err := s.sendReminders(user)
+
+		if errors.Is(err, api.ErrUnauthorized) {
+			log.Warn("stopping the reminders until the user logs in again", "user", *user.SlackUserID, "err", err.Error())
+			s.refusedTokens.Store(*user.SlackUserID, user.WAuth)
+			continue
+		}
+
+		if err != nil {
+			log.Error("could not send reminders", "user", *user.SlackUserID, "err", err.Error())
+		}
+	}
+}
+
+func (s *SlackService) sendReminders(user storage.User) error {
+	reservations, err := s.fetchReservations(user)
+
+	if err != nil {
+		return err
+	}
+
+	lead := time.Duration(user.ReminderLeadTime) * time.Minute
+	l := s.Locale(*user.SlackUserID)
+
+	for _, r := range common.DueReminders(reservations, lead, time.Now()) {
+		sent, err := s.store.HasSentReminder(r.OrderResourceRentId)
+
+		if err != nil {
+			return err
+		}
+
+		if sent {
+			continue
+		}
+
+		err = s.PostMessage(*user.SlackUserID, views.RenderReminder(r, l))
+
+		if err != nil {
+			return err
+		}
+
+		err = s.store.SetReminderSent(r.OrderResourceRentId, user.SlackUserID)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// replyToReminder replaces the reminder with the outcome of the command,
+// run by outcome for the user.
+func replyToReminder(e *execution, v *views.ReminderView, outcome func(user storage.User) string) (views.View, error) {
+	user, err := e.s.RefreshAndGetUser(e.slackUserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return v, e.s.SendToSlack(e.target.ResponseUrl, views.RenderReminderOutcome(outcome(*user)))
+}
+
+func runReminderExtend(e *execution, c *views.ResizeReservationCmd, v *views.ReminderView) (views.View, error) {
+	return replyToReminder(e, v, func(user storage.User) string {
+		reservation, credits, err := e.s.resizeReservation(user, c.ReservationId, c.Minutes)
+
+		if err != nil {
+			return e.locale.T("slack.error.extend", e.reason(err))
+		}
+
+		return e.locale.T("slack.reminder.extended", reservation.ItemName, c.Minutes, credits)
+	})
+}
+
+func runReminderCancel(e *execution, c *views.CancelReservationCmd, v *views.ReminderView) (views.View, error) {
+	return replyToReminder(e, v, func(user storage.User) string {
+		if err := e.s.cancelReservation(e.ctx, user, *c.ReservationId); err != nil {
+			return e.locale.T("slack.error.cancel")
+		}
+
+		return e.locale.T("slack.cancel_success")
+	})
+}
+
+func runNotGoing(e *execution, c *views.NotGoingCmd, v *views.ReminderView) (views.View, error) {
+	return replyToReminder(e, v, func(user storage.User) string {
+		reservation, err := e.s.findReservation(user, c.ReservationId)
+
+		if err != nil || reservation == nil {
+			return e.locale.T("slack.error.not_found")
+		}
+
+		location, _ := common.LoadLocalTime()
+		start, _ := time.ParseInLocation("2006-01-02T15:04:05", reservation.Start, location)
+
+		if !start.After(time.Now()) {
+			return e.locale.T("slack.reminder.already_started")
+		}
+
+		if err := e.s.cancelReservation(e.ctx, user, c.ReservationId); err != nil {
+			return e.locale.T("slack.error.release")
+		}
+
+		return e.locale.T("slack.reminder.released", reservation.ItemName)
+	})
+}
+
+func (s *SlackService) findReservation(user storage.User, reservationId string) (*api.Reservation, error) {
+	reservations, err := s.fetchReservations(user)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range reservations {
+		if r.OrderResourceRentId == reservationId {
+			return &r, nil
+		}
+	}
+
+	return nil, nil
+}
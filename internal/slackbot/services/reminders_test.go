@@ -0,0 +1,66 @@
+package services
+
+import (
+	"bytes"
+	"cosoft-cli/internal/api/cosofttest"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRemindRefusedToken(t *testing.T) {
+	b := newTestBot(t)
+	b.login(t)
+	b.fake.Fail(cosofttest.Reservations, http.StatusUnauthorized)
+
+	var logs bytes.Buffer
+	ctx := WithLogger(t.Context(), slog.New(slog.NewTextHandler(&logs, nil)))
+
+	// The refused token isn't tried again.
+	b.service.remind(ctx)
+	b.service.remind(ctx)
+
+	if got := strings.Count(logs.String(), "stopping the reminders"); got != 1 {
+		t.Errorf("reminders stopped %d times, want 1:\n%s", got, logs.String())
+	}
+
+	// Logging in again, with another token, tries again.
+	user := b.fake.LoginResponse()
+	id := slackUserId
+
+	if err := b.store.SetUser(user, "renewed-token", user.RefreshToken, &id); err != nil {
+		t.Fatal(err)
+	}
+
+	b.service.remind(ctx)
+
+	if got := strings.Count(logs.String(), "stopping the reminders"); got != 2 {
+		t.Errorf("reminders stopped %d times, want 2 once logged in again:\n%s", got, logs.String())
+	}
+}
+
+func TestRemindPurges(t *testing.T) {
+	b := newTestBot(t)
+	id := slackUserId
+
+	if err := b.store.SetReminderSent("recent", &id); err != nil {
+		t.Fatal(err)
+	}
+
+	b.service.remind(t.Context())
+
+	if sent, err := b.store.HasSentReminder("recent"); err != nil || !sent {
+		t.Errorf("HasSentReminder() = %v, %v, want the recent reminder kept", sent, err)
+	}
+
+	purged, err := b.store.PurgeReminders(time.Now().Add(time.Minute))
+	if err != nil || purged != 1 {
+		t.Errorf("PurgeReminders() = %d, %v, want 1", purged, err)
+	}
+
+	if sent, err := b.store.HasSentReminder("recent"); err != nil || sent {
+		t.Errorf("HasSentReminder() = %v, %v, want the reminder forgotten", sent, err)
+	}
+}
@@ -2,15 +2,33 @@ package services
 
 import (
 	"bytes"
+	"context"
 	"cosoft-cli/internal/slackbot/views"
 	"cosoft-cli/internal/ui/slack"
 	"cosoft-cli/shared/models"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 )
 
-func (s *SlackService) HandleInteraction(payload string) error {
+// surface is where a view is displayed: the message behind ResponseUrl, or
+// the modal identified by ViewId. TriggerId allows opening a new modal.
+type surface struct {
+	TriggerId   string
+	ResponseUrl string
+	ViewId      string
+}
+
+func surfaceOf(result models.InteractionDiscovery) surface {
+	return surface{
+		TriggerId:   result.TriggerID,
+		ResponseUrl: result.ResponseURL,
+		ViewId:      result.View.ID,
+	}
+}
+
+func (s *SlackService) HandleInteraction(ctx context.Context, payload string) error {
 	var result models.InteractionDiscovery
 
 	err := json.Unmarshal([]byte(payload), &result)
@@ -19,263 +37,310 @@ func (s *SlackService) HandleInteraction(payload string) error {
 		return err
 	}
 
-	dbView, err := s.store.GetSlackState(result.User.ID)
+	if result.Type == "view_closed" {
+		return s.closeModal(result.User.ID)
+	}
 
-	if err != nil {
+	if len(result.Actions) == 0 {
+		Logger(ctx).Warn("ignoring an interaction without action", "type", result.Type)
+		return nil
+	}
+
+	view, target, err := s.interactionView(ctx, result)
+
+	if err != nil || view == nil {
 		return err
 	}
 
-	var view views.View
+	// Interactions happening inside a modal carry their state in the view.
+	values := result.State.Values
+	if result.View.ID != "" {
+		values = result.View.State.Values
+	}
 
-	if dbView != nil {
-		view, err = views.RestoreView(dbView.MessageType, dbView.Payload)
+	newView, cmd := view.Update(views.Action{
+		ActionID: result.Actions[0].ActionID,
+		Values:   values,
+		Locale:   s.Locale(result.User.ID),
+		Log:      Logger(ctx),
+	})
+
+	if newView == nil {
+		return fmt.Errorf("view %s could not handle %s", views.ViewType(view), result.Actions[0].ActionID)
+	}
+
+	return s.execute(ctx, result.User.ID, target, newView, cmd)
+}
+
+// interactionView returns the view handling the interaction, and where the
+// views it leads to are displayed. The home tab and the detached views
+// aren't stored; the others are restored, unless the action is outdated.
+func (s *SlackService) interactionView(ctx context.Context, result models.InteractionDiscovery) (views.View, surface, error) {
+	if result.View.Type == "home" {
+		principals, err := s.principals(result.User.ID)
 
 		if err != nil {
-			return err
+			return nil, surface{}, err
 		}
 
-		if view == nil {
-			return fmt.Errorf("no active view for user %s", result.User.ID)
-		}
+		// The home tab opens modals, it is never updated in place.
+		return &views.HomeView{Principals: principals}, surface{TriggerId: result.TriggerID}, nil
 	}
 
+	if view, ok := views.Detached(result.Actions[0].ActionID); ok {
+		return view, surfaceOf(result), nil
+	}
+
+	outdated, err := s.outdated(result.User.ID, result.View.PrivateMetadata)
+
+	if err != nil {
+		return nil, surface{}, err
+	}
+
+	if outdated {
+		Logger(ctx).Info("ignoring an action on an outdated view", "action", result.Actions[0].ActionID)
+		return nil, surface{}, nil
+	}
+
+	view, err := s.restoreView(result.User.ID)
+
+	return view, surfaceOf(result), err
+}
+
+// HandleSubmission handles view_submission payloads. Slack expects the
+// answer in the HTTP response, so validation errors are returned inline and
+// long-running commands are executed in the background behind a loader.
+func (s *SlackService) HandleSubmission(ctx context.Context, payload string) (*slack.ModalResponse, error) {
+	var result models.InteractionDiscovery
+
+	err := json.Unmarshal([]byte(payload), &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := s.restoreView(result.User.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	locale := s.Locale(result.User.ID)
+
 	newView, cmd := view.Update(views.Action{
-		ActionID: result.Actions[0].ActionID,
-		Values:   result.State.Values,
+		ActionID: result.View.CallbackID,
+		Values:   result.View.State.Values,
+		Locale:   locale,
+		Log:      Logger(ctx),
 	})
 
-	user, err := s.store.GetUserData(&result.User.ID)
-	if err != nil {
-		return err
+	if newView == nil {
+		return nil, fmt.Errorf("view %s could not handle %s", views.ViewType(view), result.View.CallbackID)
+	}
+
+	modal, ok := views.AsModal(newView)
+
+	if !ok {
+		s.Serialize(result.User.ID, func() {
+			s.executeInBackground(context.WithoutCancel(ctx), result.User.ID, surfaceOf(result), newView, cmd)
+		})
+		return &slack.ModalResponse{ResponseAction: "clear"}, nil
 	}
 
-	switch c := cmd.(type) {
-	case *views.LoginCmd:
+	modal.ViewId = result.View.ID
+
+	if c, ok := cmd.(*views.LoginCmd); ok {
 		err = s.LogInUser(c.Email, c.Password, result.User.ID)
 
 		if err != nil {
-			errMsg := ":red_circle: Identifiant / mot de passe incorrect"
-			if loginView, ok := newView.(*views.LoginView); ok {
-				loginView.Error = &errMsg
-			}
+			modal.Invalidate("password", locale.T("slack.error.login"))
 		} else {
 			user, err := s.store.GetUserData(&result.User.ID)
 
 			if err != nil {
-				return err
+				return nil, err
 			}
 
-			newView = &views.LandingView{
-				User: *user,
+			landing, err := s.Landing(*user)
+
+			if err != nil {
+				return nil, err
 			}
-		}
-	case *views.LandingCmd:
-		user, err := s.RefreshAndGetUser(result.User.ID)
 
-		if err != nil {
-			return err
-		}
+			target := surface{ResponseUrl: modal.ResponseUrl}
+
+			s.Serialize(result.User.ID, func() {
+				s.executeInBackground(context.WithoutCancel(ctx), result.User.ID, target, landing, nil)
+			})
 
-		newView = &views.LandingView{
-			User: *user,
+			return &slack.ModalResponse{ResponseAction: "clear"}, nil
 		}
-	case *views.QuickBookCmd:
-		rooms, err := s.getRoomAvailabilities(
-			*user,
-			c.NbPeople,
-			c.Duration,
-			c.Datetime,
-		)
+	}
 
-		if err != nil {
-			errMsg := ":red_circle: La réservation a échoué"
-			if qbView, ok := newView.(*views.QuickBookView); ok {
-				qbView.Error = &errMsg
-			}
-		} else {
-			qbView := newView.(*views.QuickBookView)
-			qbView.Phase = 2
-			qbView.Rooms = &rooms
+	version, err := s.nextVersion(result.User.ID)
 
-			err := s.store.SetSlackState(result.User.ID, views.ViewType(qbView), qbView)
+	if err != nil {
+		return nil, err
+	}
 
-			if err != nil {
-				return err
-			}
+	err = s.SetSlackState(result.User.ID, newView)
 
-			blocks := views.RenderView(qbView)
-			err = s.SendToSlack(result.ResponseURL, blocks)
+	if err != nil {
+		return nil, err
+	}
 
-			if err != nil {
-				return err
-			}
+	if len(modal.FieldErrors) > 0 {
+		return &slack.ModalResponse{
+			ResponseAction: "errors",
+			Errors:         modal.FieldErrors,
+		}, nil
+	}
 
-			var pickedRoom *models.Room
+	if cmd == nil {
+		rendered := versioned(views.RenderModal(newView, locale), version)
+		return &slack.ModalResponse{ResponseAction: "update", View: &rendered}, nil
+	}
 
-			for _, room := range rooms {
-				if room.NbUsers >= c.NbPeople {
-					pickedRoom = &room
-					break
-				}
-			}
+	s.Serialize(result.User.ID, func() {
+		s.executeInBackground(context.WithoutCancel(ctx), result.User.ID, surfaceOf(result), newView, cmd)
+	})
 
-			if pickedRoom == nil {
-				return fmt.Errorf(":red_circle: Aucune salle disponible")
-			}
+	loading := views.RenderLoadingModal(newView, locale)
+	return &slack.ModalResponse{ResponseAction: "update", View: &loading}, nil
+}
 
-			if user.Credits < pickedRoom.Price {
-				return fmt.Errorf(":red_circle: Pas assez de crédits pour faire une réservation")
-			}
+// Present displays view in answer to a slash command, in a modal when
+// the view supports it.
+func (s *SlackService) Present(ctx context.Context, request models.Request, view views.View) error {
+	return s.commit(ctx, request.UserId, surface{
+		TriggerId:   request.TriggerId,
+		ResponseUrl: request.ResponseUrl,
+	}, view)
+}
 
-			err = s.bookRoom(
-				*user,
-				c.NbPeople,
-				c.Duration,
-				*pickedRoom,
-				c.Datetime,
-			)
+func (s *SlackService) restoreView(slackUserId string) (views.View, error) {
+	dbView, err := s.store.GetSlackState(slackUserId)
 
-			if err != nil {
-				errMsg := err.Error()
-				qbView.Error = &errMsg
-			} else {
-				qbView.PickedRoom = pickedRoom
-				qbView.Phase = 3
-			}
+	if err != nil {
+		return nil, err
+	}
 
-			err = s.store.SetSlackState(result.User.ID, views.ViewType(qbView), qbView)
+	if dbView == nil {
+		return nil, fmt.Errorf("no active view for user %s", slackUserId)
+	}
 
-			if err != nil {
-				return err
-			}
+	return views.RestoreView(dbView.MessageType, dbView.Payload)
+}
 
-			blocks = views.RenderView(qbView)
-			return s.SendToSlack(result.ResponseURL, blocks)
-		}
+// closeModal brings the user back to the landing view, which is still
+// displayed in the message the modal has been opened from.
+func (s *SlackService) closeModal(slackUserId string) error {
+	user, err := s.store.GetUserData(&slackUserId)
 
-	case *views.BrowseCmd:
-		rooms, err := s.getRoomAvailabilities(
-			*user,
-			c.NbPeople,
-			c.Duration,
-			c.Datetime,
-		)
+	if err != nil {
+		return err
+	}
 
-		if err != nil {
-			errMsg := ":red_circle: La réservation a échoué"
-			if bView, ok := newView.(*views.BrowseView); ok {
-				bView.Error = &errMsg
-			}
-		} else {
-			bView := newView.(*views.BrowseView)
-			bView.Phase = 1
-			bView.Rooms = &rooms
+	if user == nil {
+		return s.store.ResetUserSlackState(slackUserId)
+	}
 
-			err = s.store.SetSlackState(result.User.ID, views.ViewType(bView), bView)
+	landing, err := s.Landing(*user)
 
-			if err != nil {
-				return err
-			}
+	if err != nil {
+		return err
+	}
 
-			blocks := views.RenderView(bView)
-			return s.SendToSlack(result.ResponseURL, blocks)
-		}
+	return s.SetSlackState(slackUserId, landing)
+}
 
-	case *views.BookCmd:
-		err = s.bookRoom(
-			*user,
-			c.NbPeople,
-			c.Duration,
-			c.PickedRoom,
-			c.Datetime,
-		)
+func (s *SlackService) executeInBackground(ctx context.Context, slackUserId string, target surface, view views.View, cmd views.Cmd) {
+	err := s.execute(ctx, slackUserId, target, view, cmd)
 
-		if err != nil {
-			errMsg := ":red_circle: La réservation a échoué"
-			if bView, ok := newView.(*views.BrowseView); ok {
-				bView.Error = &errMsg
-			}
-		} else {
-			bView := newView.(*views.BrowseView)
-			bView.Phase = 2
+	if err != nil {
+		Logger(ctx).Error("interaction failed", "err", err.Error())
+	}
+}
 
-			err = s.store.SetSlackState(result.User.ID, views.ViewType(bView), bView)
+// commit displays view on target, then stores it as the user's current view.
+func (s *SlackService) commit(ctx context.Context, slackUserId string, target surface, view views.View) error {
+	version, err := s.nextVersion(slackUserId)
 
-			if err != nil {
-				return err
-			}
+	if err != nil {
+		return err
+	}
 
-			blocks := views.RenderView(bView)
-			return s.SendToSlack(result.ResponseURL, blocks)
+	err = s.deliver(ctx, slackUserId, target, view, version)
+
+	if err != nil {
+		return err
+	}
+
+	return s.SetSlackState(slackUserId, view)
+}
+
+// deliver renders view where the user expects it: in its modal when it has
+// one, marked with version, or in the message behind the response_url
+// otherwise.
+func (s *SlackService) deliver(ctx context.Context, slackUserId string, target surface, view views.View, version int64) error {
+	l := s.Locale(slackUserId)
+
+	if modal, ok := views.AsModal(view); ok && !modal.Inline && s.HasBotToken() {
+		if modal.ViewId == "" {
+			modal.ViewId = target.ViewId
 		}
-	case *views.ReservationCmd:
-		reservations, err := s.fetchReservations(*user)
-		rView := newView.(*views.ReservationView)
 
-		if err != nil {
-			errMsg := ":red_circle: Impossible de charger les réservations"
-			rView.Error = &errMsg
-		} else {
-			rView.Reservations = &reservations
+		if modal.ViewId != "" {
+			return s.UpdateModal(modal.ViewId, versioned(views.RenderModal(view, l), version))
 		}
 
-	case *views.CancelReservationCmd:
-		rView := newView.(*views.ReservationView)
-		err := s.cancelReservation(*user, *c.ReservationId)
-		if err != nil {
-			errMsg := ":red_circle: Impossible d'annuler la réservation les réservations"
-			rView.Error = &errMsg
-		} else {
-			rView.Phase = 1
+		viewId, err := s.OpenModal(target.TriggerId, versioned(views.RenderModal(view, l), version))
+
+		if err == nil {
+			modal.ViewId = viewId
+			modal.ResponseUrl = target.ResponseUrl
+			return nil
 		}
-	case *views.CalendarCmd:
-		// Note: the thing is completely stateless.
-		cView := newView.(*views.CalendarView)
 
-		// Get user's future reservations
-		reservations, err := s.fetchReservations(*user)
+		Logger(ctx).Warn("could not open modal, falling back to a message", "err", err.Error())
+		modal.Inline = true
+	}
 
-		if err != nil {
-			errMsg := ":red_circle: Impossible de charger les réservations"
-			cView.Error = &errMsg
-		} else {
-			// Ensure we have all rooms available.
-			rooms, err := s.getAllRooms(*user)
+	return s.SendToSlack(target.ResponseUrl, views.RenderView(view, l))
+}
 
-			if err != nil {
-				errMsg := ":red_circle: Impossible de récupérer les salles de réunion"
-				cView.Error = &errMsg
-			} else {
-				rows, err := s.getRoomsPlanning(
-					user,
-					rooms,
-					cView.CurrentDate,
-					reservations,
-				)
-
-				if err != nil {
-					fmt.Println(err)
-					errMsg := ":red_circle: Impossible de charger le calendrier"
-					cView.Error = &errMsg
-				} else {
-					cView.Calendar = rows
-				}
-			}
-		}
+// nextVersion returns the version the user's view gets once stored, for the
+// modal displaying it to tell.
+func (s *SlackService) nextVersion(slackUserId string) (int64, error) {
+	state, err := s.store.GetSlackState(slackUserId)
+
+	if err != nil || state == nil {
+		return 1, err
 	}
 
-	err = s.store.SetSlackState(result.User.ID, views.ViewType(newView), newView)
+	return state.Version + 1, nil
+}
 
-	if err != nil {
-		return err
+// versioned marks modal as displaying the given version of the user's view,
+// see outdated. Version 0 leaves it unmarked.
+func versioned(modal slack.Modal, version int64) slack.Modal {
+	if version > 0 {
+		modal.PrivateMetadata = strconv.FormatInt(version, 10)
 	}
 
-	blocks := views.RenderView(newView)
-	return s.SendToSlack(result.ResponseURL, blocks)
+	return modal
 }
 
-func (s *SlackService) SetSlackState(slackUserId, messageType string, state any) error {
-	return s.store.SetSlackState(slackUserId, messageType, state)
+// SetSlackState stores view as the user's current view, under the name it
+// is registered with.
+func (s *SlackService) SetSlackState(slackUserId string, view views.View) error {
+	messageType, ok := views.TypeOf(view)
+
+	if !ok {
+		return fmt.Errorf("%T is not a registered view", view)
+	}
+
+	return s.store.SetSlackState(slackUserId, messageType, view)
 }
 
 func (s *SlackService) SendToSlack(responseUrl string, blocks slack.Block) error {
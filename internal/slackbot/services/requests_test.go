@@ -0,0 +1,529 @@
+package services
+
+import (
+	"cosoft-cli/internal/api/cosofttest"
+	"cosoft-cli/internal/i18n"
+	cliservices "cosoft-cli/internal/services"
+	"cosoft-cli/internal/slackbot/metrics"
+	"cosoft-cli/internal/slackbot/views"
+	"cosoft-cli/internal/storage"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const slackUserId = "U0TEST"
+
+// slackClient records the messages sent to a response_url.
+type slackClient struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	messages []string
+}
+
+func newSlackClient(t *testing.T) *slackClient {
+	t.Helper()
+
+	c := &slackClient{}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		c.mu.Lock()
+		c.messages = append(c.messages, string(body))
+		c.mu.Unlock()
+	}))
+	t.Cleanup(c.Close)
+
+	return c
+}
+
+func (c *slackClient) last(t *testing.T) string {
+	t.Helper()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.messages) == 0 {
+		t.Fatal("no message sent to Slack")
+	}
+
+	return c.messages[len(c.messages)-1]
+}
+
+type testBot struct {
+	service *SlackService
+	store   *storage.Store
+	fake    *cosofttest.Server
+	slack   *slackClient
+}
+
+// newTestBot returns a bot without bot token, so every view is sent to the
+// response_url, backed by a fake Cosoft and a temporary database.
+func newTestBot(t *testing.T) *testBot {
+	t.Helper()
+	t.Setenv("SLACK_BOT_TOKEN", "")
+	// The messages follow the server's language unless the user picked one.
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "fr_FR.UTF-8")
+
+	store, err := storage.NewStore(filepath.Join(t.TempDir(), "database.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	if err := store.SetupDatabase(); err != nil {
+		t.Fatal(err)
+	}
+
+	return &testBot{
+		service: NewSlackService(store),
+		store:   store,
+		fake:    cosofttest.NewServer(t),
+		slack:   newSlackClient(t),
+	}
+}
+
+// login stores the fake's user as logged in from Slack, displaying the
+// landing view.
+func (b *testBot) login(t *testing.T) {
+	t.Helper()
+
+	user := b.fake.LoginResponse()
+	id := slackUserId
+
+	if err := b.store.SetUser(user, user.JwtToken, user.RefreshToken, &id); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := b.store.GetUserData(&id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	landing := &views.LandingView{User: *stored}
+	if err := b.store.SetSlackState(slackUserId, views.ViewType(landing), landing); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// interact sends a block_actions payload, as Slack does when a button of a
+// message is clicked.
+func (b *testBot) interact(t *testing.T, actionId string, values any) error {
+	t.Helper()
+
+	return b.interactOn(t, actionId, "", values)
+}
+
+// interactOn sends a block_actions payload for a click on a modal displaying
+// the given version of the user's view, signed by Slack. Without version, the
+// click is made on a message.
+func (b *testBot) interactOn(t *testing.T, actionId, version string, values any) error {
+	t.Helper()
+
+	payload := map[string]any{
+		"type":         "block_actions",
+		"user":         map[string]string{"id": slackUserId},
+		"response_url": b.slack.URL,
+		"actions":      []map[string]string{{"action_id": actionId}},
+		"state":        map[string]any{"values": values},
+	}
+
+	if version != "" {
+		payload["view"] = map[string]string{"id": "V0TEST", "type": "modal", "private_metadata": version}
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b.service.HandleInteraction(WithSignedRequest(t.Context()), string(raw))
+}
+
+func (b *testBot) currentView(t *testing.T) views.View {
+	t.Helper()
+
+	view, err := b.service.restoreView(slackUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return view
+}
+
+func inputValue(blockId, value string) map[string]any {
+	return map[string]any{blockId: map[string]any{blockId: map[string]string{"type": "plain_text_input", "value": value}}}
+}
+
+func selectValue(blockId, value string) map[string]any {
+	return map[string]any{blockId: map[string]any{blockId: map[string]any{"selected_option": map[string]string{"value": value}}}}
+}
+
+func merge(values ...map[string]any) map[string]any {
+	merged := map[string]any{}
+
+	for _, v := range values {
+		for key, value := range v {
+			merged[key] = value
+		}
+	}
+
+	return merged
+}
+
+func TestLocale(t *testing.T) {
+	tests := []struct {
+		name   string
+		stored string
+		want   i18n.Locale
+	}{
+		{name: "server", stored: "", want: i18n.French},
+		{name: "stored", stored: "en", want: i18n.English},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestBot(t)
+			b.login(t)
+			id := slackUserId
+
+			if err := b.store.SetLocale(&id, tt.stored); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := b.service.Locale(slackUserId); got != tt.want {
+				t.Errorf("Locale() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleInteractionLogin(t *testing.T) {
+	tests := []struct {
+		name        string
+		password    string
+		wantMessage string
+		wantView    string
+	}{
+		{name: "success", password: "password", wantMessage: "Menu principal", wantView: "landing"},
+		{name: "wrong_password", password: "nope", wantMessage: "mot de passe incorrect", wantView: "login"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestBot(t)
+			login := &views.LoginView{}
+
+			if err := b.store.SetSlackState(slackUserId, views.ViewType(login), login); err != nil {
+				t.Fatal(err)
+			}
+
+			values := merge(inputValue("email", b.fake.Email), inputValue("password", tt.password))
+
+			if err := b.interact(t, "login", values); err != nil {
+				t.Fatal(err)
+			}
+
+			if msg := b.slack.last(t); !strings.Contains(msg, tt.wantMessage) {
+				t.Errorf("message = %s, want it to contain %q", msg, tt.wantMessage)
+			}
+
+			if got := views.ViewType(b.currentView(t)); got != tt.wantView {
+				t.Errorf("view = %s, want %s", got, tt.wantView)
+			}
+
+			state, err := b.store.GetSlackState(slackUserId)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if strings.Contains(string(state.Payload), tt.password) {
+				t.Errorf("stored view contains the password: %s", state.Payload)
+			}
+		})
+	}
+}
+
+func TestHandleInteractionQuickBook(t *testing.T) {
+	tests := []struct {
+		name         string
+		prepare      func(fake *cosofttest.Server)
+		wantMessage  string
+		wantBookings int
+		wantResult   string
+	}{
+		{name: "success", wantMessage: "Réservation réussie", wantBookings: 1, wantResult: "ok"},
+		{
+			name: "payment_failure",
+			prepare: func(fake *cosofttest.Server) {
+				fake.Fail(cosofttest.Payment, http.StatusInternalServerError)
+			},
+			wantMessage:  "La réservation a échoué : une erreur inattendue est survenue",
+			wantBookings: 0,
+			wantResult:   "error",
+		},
+		{
+			name: "not_enough_credits",
+			prepare: func(fake *cosofttest.Server) {
+				fake.SetCredits(0)
+			},
+			wantMessage:  "La réservation a échoué : pas assez de crédits",
+			wantBookings: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestBot(t)
+
+			if tt.prepare != nil {
+				tt.prepare(b.fake)
+			}
+
+			b.login(t)
+
+			if err := b.interact(t, "quick-book", nil); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := views.ViewType(b.currentView(t)); got != "quick-book" {
+				t.Fatalf("view = %s, want quick-book", got)
+			}
+
+			values := merge(selectValue("duration", "30"), selectValue("nbPeople", "1"))
+			counted := metrics.Bookings.Value(tt.wantResult)
+
+			if err := b.interact(t, "quick-book", values); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := metrics.Bookings.Value(tt.wantResult) - counted; tt.wantResult != "" && got != 1 {
+				t.Errorf("bookings counted as %s = %v, want 1", tt.wantResult, got)
+			}
+
+			if msg := b.slack.last(t); !strings.Contains(msg, tt.wantMessage) {
+				t.Errorf("message = %s, want it to contain %q", msg, tt.wantMessage)
+			}
+
+			if got := len(b.fake.Bookings()); got != tt.wantBookings {
+				t.Errorf("bookings = %d, want %d", got, tt.wantBookings)
+			}
+		})
+	}
+}
+
+func TestHandleInteractionCancelReservation(t *testing.T) {
+	b := newTestBot(t)
+	b.login(t)
+
+	// Quick bookings may have already started, book tomorrow instead.
+	id := slackUserId
+	user, err := b.store.GetUserData(&id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	_, err = cliservices.BookFirstAvailable(*user, cliservices.BookingRequest{
+		Capacity: 1,
+		Duration: 30,
+		DateTime: time.Date(now.Year(), now.Month(), now.Day()+1, 10, 0, 0, 0, time.Local),
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bookings := b.fake.Bookings()
+	if len(bookings) != 1 {
+		t.Fatalf("bookings = %+v, want one", bookings)
+	}
+
+	for _, action := range []string{"reservations", bookings[0].Id} {
+		if err := b.interact(t, action, nil); err != nil {
+			t.Fatalf("%s: %v", action, err)
+		}
+	}
+
+	if msg := b.slack.last(t); !strings.Contains(msg, "Confirmer l'annulation") {
+		t.Fatalf("message = %s, want the cancellation to be confirmed", msg)
+	}
+
+	b.fake.FailOnce(cosofttest.Cancel, http.StatusInternalServerError)
+
+	if err := b.interact(t, "cancel", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if msg := b.slack.last(t); !strings.Contains(msg, "Impossible d'annuler") {
+		t.Errorf("message = %s, want the failure to be reported", msg)
+	}
+
+	if len(b.fake.Bookings()) != 1 {
+		t.Fatal("reservation cancelled despite the failure")
+	}
+
+	// Errors stick to the view, start over from the list.
+	for _, action := range []string{"back", "reservations", bookings[0].Id, "cancel"} {
+		if err := b.interact(t, action, nil); err != nil {
+			t.Fatalf("%s: %v", action, err)
+		}
+	}
+
+	if msg := b.slack.last(t); !strings.Contains(msg, "Annulation réussie") {
+		t.Errorf("message = %s, want the cancellation to succeed", msg)
+	}
+
+	if len(b.fake.Bookings()) != 0 {
+		t.Errorf("bookings = %+v, want none", b.fake.Bookings())
+	}
+}
+
+func TestHandleInteractionBrowseSuggestions(t *testing.T) {
+	b := newTestBot(t)
+	b.login(t)
+
+	now := time.Now()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.Local)
+
+	for _, room := range cosofttest.DefaultRooms {
+		b.fake.Occupy(room.Id, tomorrow.Add(10*time.Hour), tomorrow.Add(11*time.Hour))
+	}
+
+	if err := b.interact(t, "browse", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	values := merge(
+		map[string]any{"date": map[string]any{"date": map[string]string{"selected_date": tomorrow.Format(time.DateOnly)}}},
+		map[string]any{"time": map[string]any{"time": map[string]string{"selected_time": "10:00"}}},
+		selectValue("duration", "30"),
+		selectValue("nbPeople", "1"),
+	)
+
+	if err := b.interact(t, "browse", values); err != nil {
+		t.Fatal(err)
+	}
+
+	if msg := b.slack.last(t); !strings.Contains(msg, "Suggestions") || !strings.Contains(msg, "suggestion-0") {
+		t.Fatalf("message = %s, want suggestions", msg)
+	}
+
+	// The closest slot is right before the requested time.
+	for _, action := range []string{"suggestion-0", "book"} {
+		if err := b.interact(t, action, nil); err != nil {
+			t.Fatalf("%s: %v", action, err)
+		}
+	}
+
+	bookings := b.fake.Bookings()
+	if len(bookings) != 1 || !bookings[0].Start.Equal(tomorrow.Add(9*time.Hour+30*time.Minute)) {
+		t.Errorf("bookings = %+v, want one at 09:30", bookings)
+	}
+}
+
+func TestHandleInteractionBrowseFeatures(t *testing.T) {
+	b := newTestBot(t)
+	b.login(t)
+
+	now := time.Now()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.Local)
+
+	if err := b.interact(t, "browse", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	values := merge(
+		map[string]any{"date": map[string]any{"date": map[string]string{"selected_date": tomorrow.Format(time.DateOnly)}}},
+		map[string]any{"time": map[string]any{"time": map[string]string{"selected_time": "10:00"}}},
+		map[string]any{"features": map[string]any{"features": map[string]any{"selected_options": []map[string]string{{"value": "whiteboard"}}}}},
+		selectValue("duration", "30"),
+		selectValue("nbPeople", "1"),
+	)
+
+	if err := b.interact(t, "browse", values); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := b.slack.last(t)
+	if !strings.Contains(msg, "Salle Bleue") || strings.Contains(msg, "Salle Rouge") || strings.Contains(msg, "Salle Verte") {
+		t.Errorf("message = %s, want only the room with a whiteboard", msg)
+	}
+
+	if !strings.Contains(msg, "Équipement : Tableau blanc") {
+		t.Errorf("message = %s, want the equipment filter to be recalled", msg)
+	}
+}
+
+func TestHandleInteractionBulkCancel(t *testing.T) {
+	b := newTestBot(t)
+	b.login(t)
+
+	id := slackUserId
+	user, err := b.store.GetUserData(&id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.Local)
+
+	for _, hour := range []int{10, 14} {
+		_, err = cliservices.BookFirstAvailable(*user, cliservices.BookingRequest{
+			Capacity: 1,
+			Duration: 30,
+			DateTime: tomorrow.Add(time.Duration(hour) * time.Hour),
+		}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, action := range []string{"reservations", "cancel-day:" + tomorrow.Format(time.DateOnly)} {
+		if err := b.interact(t, action, nil); err != nil {
+			t.Fatalf("%s: %v", action, err)
+		}
+	}
+
+	if msg := b.slack.last(t); !strings.Contains(msg, "Annuler 2 réservations") {
+		t.Fatalf("message = %s, want both reservations to be confirmed", msg)
+	}
+
+	b.fake.FailOnce(cosofttest.Cancel, http.StatusInternalServerError)
+
+	if err := b.interact(t, "confirm-cancel-selected", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := b.slack.last(t)
+	if strings.Count(msg, ":x:") != 1 || strings.Count(msg, ":white_check_mark:") != 1 {
+		t.Errorf("message = %s, want one failure and one success", msg)
+	}
+
+	if got := len(b.fake.Bookings()); got != 1 {
+		t.Errorf("bookings = %d, want the failed one left", got)
+	}
+}
+
+func TestReady(t *testing.T) {
+	b := newTestBot(t)
+
+	for _, check := range b.service.Ready(t.Context()) {
+		if check.Err != nil {
+			t.Errorf("%s: %v", check.Name, check.Err)
+		}
+	}
+
+	b.store.Close()
+
+	for _, check := range b.service.Ready(t.Context()) {
+		if check.Name == "database" && check.Err == nil {
+			t.Error("database: expected an error once closed")
+		}
+	}
+}
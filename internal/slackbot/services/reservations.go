@@ -0,0 +1,68 @@
+package services
+
+import "cosoft-cli/internal/slackbot/views"
+
+func init() {
+	handleRetryable(runReservations)
+	handle(runCancelReservation)
+	handle(runCancelReservations)
+	handle(runResizeReservation)
+}
+
+func runReservations(e *execution, _ *views.ReservationCmd, v *views.ReservationView) (views.View, error) {
+	v.Error = nil
+	reservations, err := e.s.fetchReservations(*e.user)
+
+	if err != nil {
+		v.Fail(e.locale.T("slack.error.reservations"))
+	} else {
+		v.Reservations = &reservations
+	}
+
+	return v, nil
+}
+
+func runCancelReservation(e *execution, c *views.CancelReservationCmd, v *views.ReservationView) (views.View, error) {
+	err := e.s.cancelReservation(e.ctx, *e.user, *c.ReservationId)
+
+	if err != nil {
+		v.Fail(e.locale.T("slack.error.cancel"))
+	} else {
+		v.Phase = 1
+	}
+
+	return v, nil
+}
+
+func runCancelReservations(e *execution, c *views.CancelReservationsCmd, v *views.ReservationView) (views.View, error) {
+	v.Results = nil
+
+	for _, reservation := range v.SelectedReservations(c.ReservationIds) {
+		result := views.CancellationResult{Reservation: reservation}
+
+		if err := e.s.cancelReservation(e.ctx, *e.user, reservation.OrderResourceRentId); err != nil {
+			reason := e.reason(err)
+			result.Error = &reason
+		}
+
+		v.Results = append(v.Results, result)
+	}
+
+	v.SelectedIds = nil
+	v.Phase = 4
+
+	return v, nil
+}
+
+func runResizeReservation(e *execution, c *views.ResizeReservationCmd, v *views.ReservationView) (views.View, error) {
+	_, credits, err := e.s.resizeReservation(*e.user, c.ReservationId, c.Minutes)
+
+	if err != nil {
+		v.Fail(e.locale.T("slack.error.resize", e.reason(err)))
+	} else {
+		v.Credits = credits
+		v.Phase = 2
+	}
+
+	return v, nil
+}
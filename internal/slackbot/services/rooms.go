@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"cosoft-cli/internal/api"
+	cliservices "cosoft-cli/internal/services"
+	"cosoft-cli/internal/storage"
+	"errors"
+	"time"
+)
+
+// StartRoomRefresh fetches the rooms from Cosoft again whenever they are
+// stale, checking every interval, so that renamed rooms and new prices show
+// up without waiting for a user to need them.
+func (s *SlackService) StartRoomRefresh(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.refreshStaleRooms(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshStaleRooms fetches the rooms again if they are stale.
+func (s *SlackService) refreshStaleRooms(ctx context.Context) {
+	rooms, err := s.store.GetRooms()
+
+	if err != nil {
+		Logger(ctx).Error("could not load the rooms", "err", err.Error())
+		return
+	}
+
+	if !cliservices.RoomsStale(rooms) {
+		return
+	}
+
+	_, err = s.SyncRooms(ctx)
+
+	if err != nil {
+		Logger(ctx).Warn("could not refresh the rooms", "err", err.Error())
+	}
+}
+
+// SyncRooms fetches the rooms from Cosoft again, with the first token which
+// works, and updates the stored ones. It returns what changed.
+func (s *SlackService) SyncRooms(ctx context.Context) ([]storage.RoomChange, error) {
+	users, err := s.store.GetSlackUsers()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = errors.New("no user is logged in")
+
+	for _, user := range users {
+		changes, syncErr := s.refreshRooms(ctx, user)
+
+		if syncErr != nil {
+			Logger(ctx).Warn("could not fetch the rooms", "user", *user.SlackUserID, "err", syncErr.Error())
+			err = syncErr
+			continue
+		}
+
+		return changes, nil
+	}
+
+	return nil, err
+}
+
+// refreshRooms fetches the rooms with the user's token and updates the
+// stored ones, logging what changed.
+func (s *SlackService) refreshRooms(ctx context.Context, user storage.User) ([]storage.RoomChange, error) {
+	rooms, err := api.NewApi().GetAllRooms(user.WAuth, user.WAuthRefresh)
+
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.store.SyncRooms(rooms)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range changes {
+		Logger(ctx).Info("room changed", "room", c.Id, "name", c.Name, "kind", c.Kind.String(), "old_name", c.OldName, "price", c.Price, "old_price", c.OldPrice)
+	}
+
+	return changes, nil
+}
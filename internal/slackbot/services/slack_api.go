@@ -0,0 +1,101 @@
+package services
+
+import (
+	"bytes"
+	"cosoft-cli/internal/ui/slack"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const slackApiUrl = "https://slack.com/api"
+
+type slackApiResponse struct {
+	Ok    bool   `json:"ok"`
+	Error string `json:"error"`
+	View  struct {
+		Id string `json:"id"`
+	} `json:"view"`
+	User struct {
+		Locale string `json:"locale"`
+	} `json:"user"`
+}
+
+// OpenModal opens modal on top of the user's screen, and returns its id.
+// trigger_ids expire after 3 seconds, so it must be called right away.
+func (s *SlackService) OpenModal(triggerId string, modal slack.Modal) (string, error) {
+	response, err := s.callSlackApi("views.open", map[string]any{
+		"trigger_id": triggerId,
+		"view":       modal,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return response.View.Id, nil
+}
+
+func (s *SlackService) UpdateModal(viewId string, modal slack.Modal) error {
+	_, err := s.callSlackApi("views.update", map[string]any{
+		"view_id": viewId,
+		"view":    modal,
+	})
+
+	return err
+}
+
+// PostMessage sends blocks to channel. A user id as channel sends a direct
+// message from the bot.
+func (s *SlackService) PostMessage(channel string, blocks slack.Block) error {
+	_, err := s.callSlackApi("chat.postMessage", map[string]any{
+		"channel": channel,
+		"blocks":  blocks.Blocks,
+	})
+
+	return err
+}
+
+// HasBotToken reports whether a bot token is configured. Without it, every
+// view is sent as a message through its response_url, and neither the home
+// tab nor reminders are available.
+func (s *SlackService) HasBotToken() bool {
+	return s.botToken != ""
+}
+
+func (s *SlackService) callSlackApi(method string, payload any) (*slackApiResponse, error) {
+	jsonPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/%s", slackApiUrl, method), bytes.NewBuffer(jsonPayload))
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+s.botToken)
+
+	resp, err := http.DefaultClient.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	response := slackApiResponse{}
+
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	if !response.Ok {
+		return nil, fmt.Errorf("%s failed: %s", method, response.Error)
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,76 @@
+package slackbot
+
+import (
+	"bytes"
+	"cosoft-cli/internal/slackbot/services"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// maxSignatureAge is how old a request may be, against replays.
+	maxSignatureAge = 5 * time.Minute
+	// maxBodySize bounds what is read before the signature is checked.
+	maxBodySize = 1 << 20
+)
+
+// verifySlack only lets through the requests signed by Slack with the
+// signing secret, see https://api.slack.com/authentication/verifying-requests-from-slack.
+// The user ids of the requests let through can be trusted.
+func (b *Bot) verifySlack(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+
+		if err != nil {
+			http.Error(w, "invalid body", http.StatusBadRequest)
+			return
+		}
+
+		timestamp := r.Header.Get("X-Slack-Request-Timestamp")
+
+		if !validSignature(b.signingSecret, timestamp, r.Header.Get("X-Slack-Signature"), body, time.Now()) {
+			services.Logger(r.Context()).Warn("refusing a request not signed by Slack", "path", r.URL.Path)
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r = r.WithContext(services.WithSignedRequest(r.Context()))
+
+		next(w, r)
+	}
+}
+
+// validSignature tells whether signature is Slack's signature of body,
+// sent at timestamp, recently enough.
+func validSignature(secret []byte, timestamp, signature string, body []byte, now time.Time) bool {
+	if len(secret) == 0 {
+		return false
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+
+	if err != nil {
+		return false
+	}
+
+	if age := now.Sub(time.Unix(seconds, 0)); age > maxSignatureAge || age < -maxSignatureAge {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(sign(secret, timestamp, body)))
+}
+
+// sign returns the signature Slack sends along with body.
+func sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
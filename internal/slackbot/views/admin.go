@@ -0,0 +1,149 @@
+package views
+
+import (
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/storage"
+	"cosoft-cli/internal/ui/slack"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AdminUser is a user linked to a Slack account, as operators see them.
+type AdminUser struct {
+	SlackUserId string
+	Name        string
+	Email       string
+	Credits     float64
+	// LastSeen is when their last view was displayed, zero if none is
+	// stored.
+	LastSeen time.Time
+	// TokenError is why their Cosoft token doesn't work, empty if it does.
+	TokenError string
+}
+
+// AdminUsersCmd lists the linked users and the health of their token.
+type AdminUsersCmd struct{}
+
+// AdminLogoutCmd logs a stuck user out.
+type AdminLogoutCmd struct {
+	SlackUserId string
+}
+
+// AdminPurgeCmd deletes the views nobody displayed for Days.
+type AdminPurgeCmd struct {
+	Days int
+}
+
+// AdminRoomsCmd fetches the rooms from Cosoft again.
+type AdminRoomsCmd struct{}
+
+// adminCmd is implemented by the commands restricted to admins.
+type adminCmd interface {
+	adminOnly()
+}
+
+func (*AdminUsersCmd) adminOnly()  {}
+func (*AdminLogoutCmd) adminOnly() {}
+func (*AdminPurgeCmd) adminOnly()  {}
+func (*AdminRoomsCmd) adminOnly()  {}
+
+// IsAdminCmd tells whether cmd is restricted to admins.
+func IsAdminCmd(cmd Cmd) bool {
+	_, ok := cmd.(adminCmd)
+
+	return ok
+}
+
+// parseAdminCommand parses the arguments of /book admin.
+func parseAdminCommand(original []string, l i18n.Locale) (Cmd, error) {
+	if len(original) < 2 {
+		return nil, errors.New(l.T("slack.admin.usage"))
+	}
+
+	switch strings.ToLower(original[1]) {
+	case "users":
+		if len(original) == 2 {
+			return &AdminUsersCmd{}, nil
+		}
+	case "logout":
+		if len(original) == 3 {
+			slackUserId, err := parseMention(original[2], l)
+			if err != nil {
+				return nil, err
+			}
+
+			return &AdminLogoutCmd{SlackUserId: slackUserId}, nil
+		}
+	case "purge":
+		if len(original) == 3 {
+			days, err := strconv.Atoi(strings.TrimSuffix(original[2], "d"))
+			if err != nil || days < 1 {
+				return nil, errors.New(l.T("slack.admin.invalid_days", original[2]))
+			}
+
+			return &AdminPurgeCmd{Days: days}, nil
+		}
+	case "rooms":
+		if len(original) == 2 {
+			return &AdminRoomsCmd{}, nil
+		}
+	}
+
+	return nil, errors.New(l.T("slack.admin.usage"))
+}
+
+// RoomChangesText tells what fetching the rooms again changed.
+func RoomChangesText(changes []storage.RoomChange, l i18n.Locale) string {
+	lines := []string{l.T("slack.admin.rooms_synced", len(changes))}
+
+	for _, c := range changes {
+		switch c.Kind {
+		case storage.RoomAdded:
+			lines = append(lines, l.T("slack.admin.room_added", c.Name, c.Price))
+		case storage.RoomRemoved:
+			lines = append(lines, l.T("slack.admin.room_removed", c.Name))
+		case storage.RoomRenamed:
+			lines = append(lines, l.T("slack.admin.room_renamed", c.OldName, c.Name))
+		case storage.RoomRepriced:
+			lines = append(lines, l.T("slack.admin.room_repriced", c.Name, c.Price, c.OldPrice))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// RenderAdminUsers lists the linked users, flagging those whose token
+// doesn't work.
+func RenderAdminUsers(users []AdminUser, l i18n.Locale) slack.Block {
+	if len(users) == 0 {
+		return RenderCommandError(l.T("slack.admin.no_user"))
+	}
+
+	lines := make([]string, len(users))
+
+	for i, u := range users {
+		health := l.T("slack.admin.token_ok")
+
+		if u.TokenError != "" {
+			health = l.T("slack.admin.token_broken", u.TokenError)
+		}
+
+		lastSeen := l.T("slack.admin.never")
+
+		if !u.LastSeen.IsZero() {
+			lastSeen = l.DateTime(u.LastSeen)
+		}
+
+		lines[i] = l.T("slack.admin.user_line", u.SlackUserId, u.Name, u.Email, u.Credits, lastSeen, health)
+	}
+
+	return slack.Block{
+		ResponseType: "ephemeral",
+		Blocks: []slack.BlockElement{
+			slack.NewMrkDwn(l.T("slack.admin.users", len(users))),
+			slack.NewMrkDwn(strings.Join(lines, "\n")),
+		},
+	}
+}
@@ -0,0 +1,125 @@
+package views
+
+import (
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/storage"
+	"cosoft-cli/internal/ui/slack"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AnnouncementView handles the buttons sharing a booking in a channel, and
+// those of the shared message. Like the reminders, it isn't stored: the
+// announcement's id is carried by the action ids.
+type AnnouncementView struct{}
+
+// ShareCmd posts the announcement of a booking in the channel it was made
+// from.
+type ShareCmd struct {
+	AnnouncementId int64
+}
+
+// AttendCmd records whether the user attends an announced meeting.
+type AttendCmd struct {
+	AnnouncementId int64
+	Attending      bool
+}
+
+const (
+	announcementPrefix = "announcement-"
+	announcementShare  = announcementPrefix + "share:"
+	announcementJoin   = announcementPrefix + "join:"
+	announcementLeave  = announcementPrefix + "leave:"
+)
+
+func init() {
+	RegisterDetached(announcementPrefix, func() *AnnouncementView { return &AnnouncementView{} })
+}
+
+func (a *AnnouncementView) Update(action Action) (View, Cmd) {
+	if id, ok := cutId(action.ActionID, announcementShare); ok {
+		return a, &ShareCmd{AnnouncementId: id}
+	}
+
+	if id, ok := cutId(action.ActionID, announcementJoin); ok {
+		return a, &AttendCmd{AnnouncementId: id, Attending: true}
+	}
+
+	if id, ok := cutId(action.ActionID, announcementLeave); ok {
+		return a, &AttendCmd{AnnouncementId: id}
+	}
+
+	return a, nil
+}
+
+func cutId(actionId, prefix string) (int64, bool) {
+	raw, ok := strings.CutPrefix(actionId, prefix)
+
+	if !ok {
+		return 0, false
+	}
+
+	id, err := strconv.ParseInt(raw, 10, 64)
+
+	return id, err == nil
+}
+
+// ShareButton offers to announce a booking in the channel, once booked.
+func ShareButton(announcementId int64, l i18n.Locale) slack.BlockElement {
+	return slack.NewButtons([]slack.ChoicePayload{{
+		Text:  l.T("slack.announcement.share"),
+		Value: fmt.Sprintf("%s%d", announcementShare, announcementId),
+	}})
+}
+
+// RenderAnnouncement is the message shared in the channel, visible to all
+// its members, who can join the meeting.
+func RenderAnnouncement(a storage.Announcement, attendees []string, l i18n.Locale) slack.Block {
+	key := "slack.announcement.booked"
+
+	if a.Team {
+		key = "slack.announcement.team_booked"
+	}
+
+	text := l.T(key, a.SlackUserId, a.RoomName, l.DateTime(a.Start), l.Time(a.End))
+	summary := slack.BlockElement(slack.NewMrkDwn(text))
+
+	if a.RoomImage != "" {
+		summary = slack.NewPreview(text, a.RoomImage, a.RoomName)
+	}
+
+	attending := l.T("slack.announcement.nobody")
+
+	if len(attendees) > 0 {
+		mentions := make([]string, len(attendees))
+
+		for i, attendee := range attendees {
+			mentions[i] = fmt.Sprintf("<@%s>", attendee)
+		}
+
+		attending = l.T("slack.announcement.attendees", strings.Join(mentions, ", "))
+	}
+
+	return slack.Block{
+		ResponseType: "in_channel",
+		Blocks: []slack.BlockElement{
+			summary,
+			slack.NewMrkDwn(attending),
+			slack.NewButtons([]slack.ChoicePayload{
+				{Text: l.T("slack.announcement.join"), Value: fmt.Sprintf("%s%d", announcementJoin, a.Id)},
+				{Text: l.T("slack.announcement.leave"), Value: fmt.Sprintf("%s%d", announcementLeave, a.Id)},
+			}),
+		},
+	}
+}
+
+// RenderTeamCancellation tells the channel a team booking was cancelled.
+func RenderTeamCancellation(a storage.Announcement, l i18n.Locale) slack.Block {
+	return slack.Block{
+		ResponseType: "in_channel",
+		Blocks: []slack.BlockElement{
+			slack.NewMrkDwn(l.T("slack.announcement.cancelled", a.SlackUserId, a.RoomName, l.DateTime(a.Start))),
+		},
+	}
+}
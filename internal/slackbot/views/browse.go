@@ -2,6 +2,7 @@ package views
 
 import (
 	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/i18n"
 	"cosoft-cli/internal/ui/slack"
 	"cosoft-cli/shared/models"
 	"encoding/json"
@@ -13,20 +14,38 @@ import (
 )
 
 type BrowseView struct {
-	Phase      int
-	NbPeople   string
-	Duration   string
-	Date       string
-	Time       string
+	Modal
+	Phase    int
+	NbPeople string
+	Duration string
+	Date     string
+	Time     string
+	// Features are the equipment the rooms must have.
+	Features   []models.Feature
 	Rooms      *[]models.Room
 	PickedRoom *models.Room
-	Error      *string
+	// Suggestions are the free slots closest to the requested time, offered
+	// when no room is.
+	Suggestions []models.SlotSuggestion
+	// AnnouncementId allows sharing the booking, once done.
+	AnnouncementId int64
+	Alert
+	Progress
+	Delegation
 }
 
+func init() {
+	Register("browse", func() *BrowseView { return &BrowseView{} }, RenderBrowseView, RenderBrowseModal)
+}
+
+// maxSuggestions keeps the message well under Slack's 50 blocks.
+const maxSuggestions = 10
+
 type BrowseCmd struct {
 	NbPeople int
 	Duration int
 	Datetime time.Time
+	Features []models.Feature
 	Rooms    []models.Room
 }
 
@@ -35,6 +54,8 @@ type BookCmd struct {
 	Duration   int
 	Datetime   time.Time
 	PickedRoom models.Room
+	// BookAs is the principal whose account books, empty for the user's own.
+	BookAs string
 }
 
 type BrowsePayload struct {
@@ -66,6 +87,13 @@ type BrowsePayload struct {
 			} `json:"selected_option"`
 		} `json:"nbPeople"`
 	} `json:"nbPeople"`
+	Features struct {
+		Features struct {
+			SelectedOptions []struct {
+				Value string `json:"value"`
+			} `json:"selected_options"`
+		} `json:"features"`
+	} `json:"features"`
 }
 
 type PickedRoomPayload struct {
@@ -85,10 +113,15 @@ func (b *BrowseView) Update(action Action) (View, Cmd) {
 	} else if action.ActionID == "browse" {
 		var values BrowsePayload
 
+		b.Error = nil
+		b.FieldErrors = nil
+		b.PickedRoom = nil
+		b.Suggestions = nil
+
 		err := json.Unmarshal(action.Values, &values)
 
 		if err != nil {
-			fmt.Println(err)
+			action.Logger().Error("could not read the browse filters", "err", err.Error())
 			return nil, err
 		}
 
@@ -97,13 +130,20 @@ func (b *BrowseView) Update(action Action) (View, Cmd) {
 
 		b.Date = values.Date.Date.SelectedDate
 		b.Time = values.Time.Time.SelectedTime
+		b.Features = nil
+
+		for _, option := range values.Features.Features.SelectedOptions {
+			if f, ok := models.ParseFeature(option.Value); ok {
+				b.Features = append(b.Features, f)
+			}
+		}
+
+		b.readBookAs(action.Values)
 
 		if b.NbPeople == "" || b.Duration == "" {
-			s := ":warning: Tous les champs sont requis"
+			s := ":warning: " + action.Locale.T("slack.fields_required")
 			b.Error = &s
 
-			fmt.Println(s)
-
 			return b, nil
 		}
 
@@ -120,41 +160,39 @@ func (b *BrowseView) Update(action Action) (View, Cmd) {
 		parsedDt, err := b.criteriaToTime()
 
 		if err != nil {
-			fmt.Println(err)
+			action.Logger().Error("invalid browse date", "err", err.Error())
 			return nil, err
 		}
 
 		if parsedDt.Before(time.Now()) {
-			s := ":warning: Veuillez choisir une date dans le futur"
+			s := ":warning: " + action.Locale.T("slack.date_in_future")
 			b.Error = &s
+			b.Invalidate("date", action.Locale.T("slack.date_in_future"))
 
 			return b, nil
 		}
 
 		if parsedDt.Minute()%15 != 0 {
-			s := ":warning: Veuillez choisir un quart d'heure (14h00, 15h15, 16h30, 17h45....)"
+			s := ":warning: " + action.Locale.T("slack.time_on_quarter")
 			b.Error = &s
+			b.Invalidate("time", action.Locale.T("slack.time_on_quarter"))
 
 			return b, nil
 		}
 
-		nbPeople, duration, err := b.filtersToNumber()
+		cmd, err := b.searchCmd()
 		if err != nil {
-			fmt.Println(err)
+			action.Logger().Error("invalid browse filters", "err", err.Error())
 			return nil, err
 		}
 
-		return b, &BrowseCmd{
-			NbPeople: nbPeople,
-			Duration: duration,
-			Datetime: *parsedDt,
-		}
+		return b, cmd
 	} else if action.ActionID == "pick-room" {
 		var pickedRoom PickedRoomPayload
 
 		err := json.Unmarshal(action.Values, &pickedRoom)
 		if err != nil {
-			fmt.Println(err)
+			action.Logger().Error("could not read the picked room", "err", err.Error())
 			return nil, err
 		}
 
@@ -168,7 +206,7 @@ func (b *BrowseView) Update(action Action) (View, Cmd) {
 		}
 
 		if b.PickedRoom == nil {
-			fmt.Println("PickedRoom not found")
+			action.Logger().Warn("picked room not found", "room", roomId)
 			return b, nil
 		}
 	} else if action.ActionID == "book" {
@@ -180,10 +218,32 @@ func (b *BrowseView) Update(action Action) (View, Cmd) {
 			Duration:   duration,
 			PickedRoom: *b.PickedRoom,
 			Datetime:   *t,
+			BookAs:     b.BookAs,
 		}
 	} else if action.ActionID == "back" {
 		b.Phase = 0
 		return b, nil
+	} else if action.ActionID == "retry" {
+		cmd, err := b.searchCmd()
+
+		if err != nil {
+			b.Phase = 0
+			return b, nil
+		}
+
+		return b, cmd
+	} else if index, ok := strings.CutPrefix(action.ActionID, "suggestion-"); ok {
+		// The suggestion becomes the only room found, at its time.
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 || i >= len(b.Suggestions) {
+			return b, nil
+		}
+
+		suggestion := b.Suggestions[i]
+		b.Time = suggestion.Start.Format("15:04")
+		b.Rooms = &[]models.Room{suggestion.Room}
+		b.PickedRoom = &suggestion.Room
+		b.Suggestions = nil
 	} else if strings.HasPrefix(action.ActionID, "book-") {
 		// A room has been picked
 		// Return this for now.
@@ -193,107 +253,44 @@ func (b *BrowseView) Update(action Action) (View, Cmd) {
 	return b, nil
 }
 
-func RenderBrowseView(b *BrowseView) slack.Block {
+func RenderBrowseView(b *BrowseView, l i18n.Locale) slack.Block {
+	if b.Loading {
+		return slack.Block{
+			Blocks: []slack.BlockElement{
+				slack.NewHeader(l.T("slack.browse.title")),
+				RenderProgress(&b.Progress, l),
+			},
+		}
+	}
+
 	switch b.Phase {
 	case 0:
-		blocks := slack.BrowseMenu()
+		blocks := slack.BrowseMenu(l)
 		if b.Error != nil {
 			blocks.Blocks = slices.Insert(
 				blocks.Blocks,
-				5,
+				len(blocks.Blocks)-1,
 				slack.BlockElement(slack.NewContext(*b.Error)),
 			)
 		}
 
+		blocks.Blocks = b.withBookAs(blocks.Blocks, l)
+
 		return blocks
 	case 1:
-		if len(*b.Rooms) == 0 {
-			return slack.Block{
-				Blocks: []slack.BlockElement{
-					slack.NewMenuItem(
-						"*Aucune salle disponible*\nVeuillez changer vos filtres",
-						"Retour",
-						"back",
-					),
-				},
-			}
-		}
-
-		nbPeople, duration, _ := b.filtersToNumber()
-		t, _ := b.criteriaToTime()
-		end := t.Add(time.Duration(duration) * time.Minute)
-		choices := make([]slack.ChoicePayload, len(*b.Rooms))
-
-		for i, room := range *b.Rooms {
-			choices[i] = slack.ChoicePayload{
-				Text:  room.Name,
-				Value: room.Id,
-			}
-		}
-
-		blocks := []slack.BlockElement{
-			slack.NewHeader(fmt.Sprintf("%d salles ont été trouvées", len(*b.Rooms))),
-			slack.NewMrkDwn(fmt.Sprintf(
-				"%s → %s — %d personnes",
-				t.Format("02/01/2006 15:04"),
-				end.Format("02/01/2006 15:04"),
-				nbPeople,
-			)),
-			slack.NewDivider(),
-			slack.NewSelect(
-				"Sélectionez une salle",
-				"Salle",
-				"pick-room",
-				choices,
-			),
-		}
-
-		if b.PickedRoom != nil {
-			blocks = append(
-				blocks,
-				slack.NewPreview(
-					fmt.Sprintf("*%s*\n%.2f crédits", b.PickedRoom.Name, b.PickedRoom.Price),
-					b.PickedRoom.Image,
-					b.PickedRoom.Name,
-				),
-				slack.NewButtons([]slack.ChoicePayload{{Text: "Réserver", Value: "book"}}),
-			)
-		}
-
-		blocks = append(blocks,
-			slack.NewDivider(),
-			slack.NewButtons([]slack.ChoicePayload{
-				{Text: "Retour à l'accueil", Value: "cancel"},
-				{Text: "Modifier les filtres", Value: "back"},
-			}),
-		)
-
 		return slack.Block{
-			Blocks: blocks,
+			Blocks: browseRoomsBlocks(b, false, l),
 		}
-
 	case 2:
-		duration, _ := strconv.Atoi(b.Duration)
-		startTime, _ := b.criteriaToTime()
-		endTime := startTime.Add(time.Duration(duration) * time.Minute)
-		dateFormat := "02/01/2006 15:04"
-		paidPrice := b.PickedRoom.Price * (float64(duration) / 60)
-
 		return slack.Block{
-			Blocks: []slack.BlockElement{
-				slack.BlockElement(slack.NewMrkDwn(":white_check_mark: *Réservation réussie !*")),
-
-				slack.BlockElement(slack.NewMultiMarkdown([]string{
-					fmt.Sprintf("*Salle de réunion :*\n%s", b.PickedRoom.Name),
-					fmt.Sprintf("*Durée :*\n%s → %s", startTime.Format(dateFormat), endTime.Format(dateFormat)),
-					fmt.Sprintf("*Coût :*\n%.2f credits", paidPrice),
-				})),
+			Blocks: append(
+				browseSuccessBlocks(b, l),
 				slack.BlockElement(slack.NewMenuItem(
-					"Vous pouvez maintenant revenir à l'accueil",
-					"Retour",
+					l.T("slack.back_to_landing_hint"),
+					l.T("slack.back"),
 					"cancel",
 				)),
-			},
+			),
 		}
 	}
 	return slack.Block{}
@@ -304,31 +301,219 @@ func (b *BrowseView) criteriaToTime() (*time.Time, error) {
 
 	location, err := common.LoadLocalTime()
 	if err != nil {
-		fmt.Println(err)
 		return nil, err
 	}
 
 	parsedDt, err := time.ParseInLocation("2006-01-02 15:04", dt, location)
 	if err != nil {
-		fmt.Println(err)
 		return nil, err
 	}
 
 	return &parsedDt, nil
 }
 
+// searchCmd looks for the rooms matching the filters.
+func (b *BrowseView) searchCmd() (*BrowseCmd, error) {
+	nbPeople, duration, err := b.filtersToNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := b.criteriaToTime()
+	if err != nil {
+		return nil, err
+	}
+
+	return &BrowseCmd{
+		NbPeople: nbPeople,
+		Duration: duration,
+		Datetime: *t,
+		Features: b.Features,
+	}, nil
+}
+
 func (b *BrowseView) filtersToNumber() (int, int, error) {
 	nbPeople, err := strconv.Atoi(b.NbPeople)
 	if err != nil {
-		fmt.Println(err)
 		return 0, 0, err
 	}
 
 	duration, err := strconv.Atoi(b.Duration)
 	if err != nil {
-		fmt.Println(err)
 		return 0, 0, err
 	}
 
 	return nbPeople, duration, nil
 }
+
+func RenderBrowseModal(b *BrowseView, l i18n.Locale) slack.Modal {
+	var blocks []slack.BlockElement
+
+	if b.Loading {
+		return slack.NewModal(l.T("slack.browse.title"), l.T("slack.close"), "browse", []slack.BlockElement{RenderProgress(&b.Progress, l)})
+	}
+
+	switch b.Phase {
+	case 0:
+		blocks = b.withBookAsInput(slack.BrowseForm(l), l)
+		if b.Error != nil {
+			blocks = append(blocks, slack.NewContext(*b.Error))
+		}
+
+		return slack.NewModal(l.T("slack.browse.title"), l.T("slack.close"), "browse", blocks).WithSubmit(l.T("slack.browse.search"))
+	case 1:
+		blocks = browseRoomsBlocks(b, true, l)
+	case 2:
+		blocks = browseSuccessBlocks(b, l)
+	}
+
+	if b.Error != nil {
+		blocks = append(blocks, slack.NewContext(*b.Error))
+	}
+
+	return slack.NewModal(l.T("slack.browse.title"), l.T("slack.close"), "browse", blocks)
+}
+
+// browseRoomsBlocks lists the rooms matching the filters. The "back to
+// landing" button is left out of modals, which are closed instead.
+func browseRoomsBlocks(b *BrowseView, modal bool, l i18n.Locale) []slack.BlockElement {
+	if len(*b.Rooms) == 0 {
+		blocks := []slack.BlockElement{
+			slack.NewMenuItem(
+				l.T("slack.browse.no_room"),
+				l.T("slack.back"),
+				"back",
+			),
+		}
+
+		return append(blocks, suggestionBlocks(b, l)...)
+	}
+
+	nbPeople, duration, _ := b.filtersToNumber()
+	t, _ := b.criteriaToTime()
+	end := t.Add(time.Duration(duration) * time.Minute)
+	choices := make([]slack.ChoicePayload, len(*b.Rooms))
+
+	for i, room := range *b.Rooms {
+		choices[i] = slack.ChoicePayload{
+			Text:  room.Name,
+			Value: room.Id,
+		}
+	}
+
+	blocks := []slack.BlockElement{
+		slack.NewHeader(l.T("slack.browse.found", len(*b.Rooms))),
+		slack.NewMrkDwn(l.T("slack.browse.criteria", l.DateTime(*t), l.DateTime(end), nbPeople)),
+	}
+
+	if len(b.Features) > 0 {
+		blocks = append(blocks, slack.NewContext(l.T("slack.browse.features", featureNames(b.Features, l))))
+	}
+
+	blocks = append(
+		blocks,
+		slack.NewDivider(),
+		slack.NewSelect(
+			l.T("slack.browse.pick_room"),
+			l.T("slack.browse.room"),
+			"pick-room",
+			choices,
+		),
+	)
+
+	if b.PickedRoom != nil {
+		blocks = append(
+			blocks,
+			slack.NewPreview(roomDescription(*b.PickedRoom, l), b.PickedRoom.Image, b.PickedRoom.Name),
+			slack.NewButtons([]slack.ChoicePayload{{Text: l.T("slack.book"), Value: "book"}}),
+		)
+	}
+
+	buttons := []slack.ChoicePayload{{Text: l.T("slack.browse.change_filters"), Value: "back"}}
+
+	if !modal {
+		buttons = append([]slack.ChoicePayload{{Text: l.T("slack.back_to_landing"), Value: "cancel"}}, buttons...)
+	}
+
+	return append(blocks, slack.NewDivider(), slack.NewButtons(buttons))
+}
+
+// roomDescription presents the room, with what Cosoft tells about it.
+func roomDescription(room models.Room, l i18n.Locale) string {
+	lines := []string{fmt.Sprintf("*%s*", room.Name), l.T("slack.credits", room.Price)}
+
+	if room.Floor != "" {
+		lines = append(lines, l.T("slack.browse.floor", room.Floor))
+	}
+
+	if len(room.Equipment) > 0 {
+		lines = append(lines, l.T("slack.browse.equipment", strings.Join(room.Equipment, ", ")))
+	}
+
+	if room.Description != "" {
+		lines = append(lines, "_"+room.Description+"_")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// featureNames lists the features, as the browse form names them.
+func featureNames(features []models.Feature, l i18n.Locale) string {
+	names := make([]string, len(features))
+
+	for i, f := range features {
+		names[i] = l.T("slack.feature." + string(f))
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// suggestionBlocks offers to pick one of the free slots closest to the
+// requested time.
+func suggestionBlocks(b *BrowseView, l i18n.Locale) []slack.BlockElement {
+	if len(b.Suggestions) == 0 {
+		return nil
+	}
+
+	_, duration, _ := b.filtersToNumber()
+	length := time.Duration(duration) * time.Minute
+
+	blocks := []slack.BlockElement{
+		slack.NewDivider(),
+		slack.NewMrkDwn(l.T("slack.browse.suggestions")),
+	}
+
+	for i, suggestion := range b.Suggestions[:min(len(b.Suggestions), maxSuggestions)] {
+		blocks = append(blocks, slack.NewMenuItem(
+			fmt.Sprintf(
+				"*%s*\n%s → %s — %s",
+				suggestion.Room.Name,
+				l.Time(suggestion.Start),
+				l.Time(suggestion.Start.Add(length)),
+				l.T("slack.credits", suggestion.Room.Price*float64(duration)/60),
+			),
+			l.T("slack.browse.choose"),
+			fmt.Sprintf("suggestion-%d", i),
+		))
+	}
+
+	return blocks
+}
+
+func browseSuccessBlocks(b *BrowseView, l i18n.Locale) []slack.BlockElement {
+	duration, _ := strconv.Atoi(b.Duration)
+	startTime, _ := b.criteriaToTime()
+
+	blocks := []slack.BlockElement{
+		slack.NewMrkDwn(l.T("slack.booking_success")),
+		bookingSummary(*b.PickedRoom, *startTime, duration, l),
+	}
+
+	blocks = b.bookedFor(blocks, l)
+
+	if b.AnnouncementId != 0 {
+		blocks = append(blocks, ShareButton(b.AnnouncementId, l))
+	}
+
+	return blocks
+}
@@ -1,6 +1,7 @@
 package views
 
 import (
+	"cosoft-cli/internal/i18n"
 	"cosoft-cli/internal/ui/slack"
 	"fmt"
 	"time"
@@ -9,7 +10,12 @@ import (
 type CalendarView struct {
 	CurrentDate time.Time
 	Calendar    string
-	Error       *string
+	Alert
+	Progress
+}
+
+func init() {
+	Register("calendar", NewCalendarView, RenderCalendarView, nil)
 }
 
 type CalendarCmd struct {
@@ -44,34 +50,57 @@ func (c *CalendarView) Update(action Action) (View, Cmd) {
 		}
 		c.CurrentDate = c.CurrentDate.Add(-24 * time.Hour)
 		return c, &CalendarCmd{}
+	case "retry":
+		return c, &CalendarCmd{}
 	default:
 		return c, nil
 	}
 }
 
-func RenderCalendarView(c *CalendarView) slack.Block {
-	dt := c.CurrentDate.Format("02/01/2006")
+func RenderCalendarView(c *CalendarView, l i18n.Locale) slack.Block {
+	dt := l.Day(c.CurrentDate)
 	isToday := sameDay(c.CurrentDate, time.Now())
-	actions := []slack.ChoicePayload{{Text: "Jour suivant", Value: "next-day"}}
+	actions := []slack.ChoicePayload{{Text: l.T("slack.calendar.next_day"), Value: "next-day"}}
 
 	if !isToday {
 		actions = append(
-			[]slack.ChoicePayload{{Text: "Jour précédent", Value: "prev-day"}},
+			[]slack.ChoicePayload{{Text: l.T("slack.calendar.prev_day"), Value: "prev-day"}},
 			actions...,
 		)
 	}
 
-	return slack.Block{
-		Blocks: []slack.BlockElement{
-			slack.NewHeader("Calendrier"),
-			slack.NewMrkDwn(fmt.Sprintf("*%s*", dt)),
-			slack.NewDivider(),
-			slack.NewKitchenSink(c.Calendar),
-			slack.NewMrkDwn("`█`: Créneau réservé par vous"),
-			slack.NewMrkDwn("`░`: Créneau réservé par quelqu'un d'autre"),
+	blocks := []slack.BlockElement{
+		slack.NewHeader(l.T("slack.calendar.title")),
+		slack.NewMrkDwn(fmt.Sprintf("*%s*", dt)),
+	}
+
+	// The rooms are listed as their busy times arrive.
+	if c.Loading {
+		blocks = append(blocks, RenderProgress(&c.Progress, l))
+	}
+
+	if c.Error != nil {
+		blocks = append(blocks, slack.NewContext(*c.Error))
+	}
+
+	if c.Calendar != "" {
+		blocks = append(blocks, slack.NewDivider(), slack.NewKitchenSink(c.Calendar))
+	}
+
+	if !c.Loading {
+		blocks = append(
+			blocks,
+			slack.NewMrkDwn(l.T("slack.calendar.legend_own")),
+			slack.NewMrkDwn(l.T("slack.calendar.legend_other")),
 			slack.NewButtons(actions),
+		)
+	}
+
+	return slack.Block{
+		Blocks: append(
+			blocks,
 			slack.NewDivider(),
-			slack.NewButtons([]slack.ChoicePayload{{Text: "Retour", Value: "cancel"}}),
-		},
+			slack.NewButtons([]slack.ChoicePayload{{Text: l.T("slack.back"), Value: "cancel"}}),
+		),
 	}
 }
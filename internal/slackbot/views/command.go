@@ -0,0 +1,379 @@
+package views
+
+import (
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/services"
+	"cosoft-cli/internal/ui/slack"
+	"cosoft-cli/shared/models"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DirectBookCmd books a room straight from the slash command's arguments.
+// Team bookings are announced in the channel, and linked to it.
+type DirectBookCmd struct {
+	Request services.BookingRequest
+	Team    bool
+	// BookAs is the principal whose account books, empty for the user's own.
+	BookAs string
+}
+
+// HelpCmd explains the command's arguments.
+type HelpCmd struct{}
+
+// CancelNextCmd cancels the user's next reservation which hasn't started yet.
+type CancelNextCmd struct{}
+
+// RemindCmd sets how many minutes before a reservation its reminder is
+// sent, 0 disabling reminders.
+type RemindCmd struct {
+	LeadTime int
+}
+
+// LangCmd sets the language of the bot's messages. An empty Locale follows
+// the user's Slack language again.
+type LangCmd struct {
+	Locale i18n.Locale
+}
+
+var (
+	datePattern     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	colonPattern    = regexp.MustCompile(`^(\d{1,2}):(\d{2})$`)
+	hoursPattern    = regexp.MustCompile(`^(\d{1,2})h(\d{2})?$`)
+	minutesPattern  = regexp.MustCompile(`^(\d+)(m|min)$`)
+	capacityPattern = regexp.MustCompile(`^(\d+)p$`)
+	// mentionPattern matches the users mentioned in a slash command, which
+	// Slack escapes as <@U123|name>.
+	mentionPattern = regexp.MustCompile(`^<@([A-Z0-9]+)(\|[^>]*)?>$`)
+)
+
+// ParseCommand turns the text following /book into a command. An empty text
+// returns a nil command, meaning the main menu should be displayed.
+func ParseCommand(text string, l i18n.Locale) (Cmd, error) {
+	fields := strings.Fields(strings.ToLower(text))
+	original := strings.Fields(text)
+
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	switch fields[0] {
+	case "help", "aide":
+		return &HelpCmd{}, nil
+	case "list", "liste":
+		if len(fields) > 1 {
+			return nil, errors.New(l.T("slack.command.no_argument", fields[0]))
+		}
+
+		return &ReservationCmd{}, nil
+	case "cancel", "annuler":
+		if len(fields) == 2 && (fields[1] == "next" || fields[1] == "prochaine") {
+			return &CancelNextCmd{}, nil
+		}
+
+		return nil, errors.New(l.T("slack.command.cancel_next_only"))
+	case "remind", "rappel":
+		if len(fields) != 2 {
+			return nil, errors.New(l.T("slack.command.remind_usage"))
+		}
+
+		if fields[1] == "off" {
+			return &RemindCmd{LeadTime: 0}, nil
+		}
+
+		minutes, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSuffix(fields[1], "min"), "m"))
+		if err != nil || minutes <= 0 || minutes > 120 {
+			return nil, errors.New(l.T("slack.command.invalid_lead_time", original[1]))
+		}
+
+		return &RemindCmd{LeadTime: minutes}, nil
+	case "lang", "langue":
+		if len(fields) != 2 {
+			return nil, errors.New(l.T("slack.command.lang_usage"))
+		}
+
+		if fields[1] == "auto" {
+			return &LangCmd{}, nil
+		}
+
+		locale, ok := i18n.Parse(fields[1])
+		if !ok {
+			return nil, errors.New(l.T("slack.command.invalid_lang", original[1]))
+		}
+
+		return &LangCmd{Locale: locale}, nil
+	case "team", "equipe", "équipe":
+		cmd, err := ParseCommand(strings.Join(original[1:], " "), l)
+		if err != nil {
+			return nil, err
+		}
+
+		book, ok := cmd.(*DirectBookCmd)
+		if !ok {
+			return nil, errors.New(l.T("slack.command.team_usage"))
+		}
+
+		book.Team = true
+
+		return book, nil
+	case "as", "pour":
+		if len(original) < 2 {
+			return nil, errors.New(l.T("slack.command.as_usage"))
+		}
+
+		owner, err := parseMention(original[1], l)
+		if err != nil {
+			return nil, err
+		}
+
+		cmd, err := ParseCommand(strings.Join(original[2:], " "), l)
+		if err != nil {
+			return nil, err
+		}
+
+		book, ok := cmd.(*DirectBookCmd)
+		if !ok {
+			return nil, errors.New(l.T("slack.command.as_usage"))
+		}
+
+		book.BookAs = owner
+
+		return book, nil
+	case "delegate", "deleguer", "déléguer":
+		delegate, err := mentionArgument(original, l)
+		if err != nil {
+			return nil, err
+		}
+
+		return &DelegateCmd{SlackUserId: delegate}, nil
+	case "revoke", "revoquer", "révoquer":
+		delegate, err := mentionArgument(original, l)
+		if err != nil {
+			return nil, err
+		}
+
+		return &RevokeCmd{SlackUserId: delegate}, nil
+	case "admin":
+		return parseAdminCommand(original, l)
+	case "delegations", "délégations":
+		if len(fields) > 1 {
+			return nil, errors.New(l.T("slack.command.no_argument", fields[0]))
+		}
+
+		return &DelegationsCmd{}, nil
+	}
+
+	location, err := common.LoadLocalTime()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	date := today
+	var clock *time.Duration
+
+	request := services.BookingRequest{
+		Capacity: 1,
+		Duration: 30,
+	}
+
+	for i, field := range fields {
+		if m := datePattern.FindString(field); m != "" {
+			date, err = time.ParseInLocation(time.DateOnly, m, location)
+			if err != nil {
+				return nil, errors.New(l.T("slack.command.invalid_date", original[i]))
+			}
+			continue
+		}
+
+		if m := colonPattern.FindStringSubmatch(field); m != nil {
+			c, err := clockDuration(m[1], m[2])
+			if err != nil {
+				return nil, errors.New(l.T("slack.command.invalid_time", original[i]))
+			}
+			clock = &c
+			continue
+		}
+
+		// "1h30" is a duration while "14h30" is a time: nobody can book a
+		// room for more than 2 hours, nor before 8 o'clock.
+		if m := hoursPattern.FindStringSubmatch(field); m != nil {
+			hours, _ := strconv.Atoi(m[1])
+			minutes := 0
+			if m[2] != "" {
+				minutes, _ = strconv.Atoi(m[2])
+			}
+
+			if hours <= 2 {
+				request.Duration = hours*60 + minutes
+				continue
+			}
+
+			c, err := clockDuration(m[1], fmt.Sprintf("%02d", minutes))
+			if err != nil {
+				return nil, errors.New(l.T("slack.command.invalid_time", original[i]))
+			}
+			clock = &c
+			continue
+		}
+
+		if m := minutesPattern.FindStringSubmatch(field); m != nil {
+			request.Duration, _ = strconv.Atoi(m[1])
+			continue
+		}
+
+		if m := capacityPattern.FindStringSubmatch(field); m != nil {
+			request.Capacity, _ = strconv.Atoi(m[1])
+			continue
+		}
+
+		// Anything else starts the room's name, which may contain spaces.
+		request.Name = strings.Join(original[i:], " ")
+		break
+	}
+
+	// Without a time, the booking starts right away, or at opening time on
+	// another day.
+	switch {
+	case clock != nil:
+		request.DateTime = date.Add(*clock)
+	case date.Equal(today):
+		request.DateTime = common.GetClosestQuarterHour()
+	default:
+		request.DateTime = date.Add(common.OpeningHour * time.Hour)
+	}
+
+	// Hard limits for filtering, same as `cosoft book`
+	request.Capacity = min(max(request.Capacity, 1), 2)
+
+	if request.Duration <= 0 || request.Duration%15 != 0 {
+		return nil, errors.New(l.T("slack.command.duration_quarter"))
+	}
+
+	if request.Duration > common.MaxBookingMinutes {
+		return nil, errors.New(l.T("slack.command.duration_max"))
+	}
+
+	if request.DateTime.Before(time.Now()) {
+		return nil, errors.New(l.T("slack.command.date_in_future"))
+	}
+
+	if request.DateTime.Minute()%15 != 0 {
+		return nil, errors.New(l.T("slack.command.time_on_quarter"))
+	}
+
+	return &DirectBookCmd{Request: request}, nil
+}
+
+// mentionArgument returns the id of the Slack user mentioned as the only
+// argument of a command.
+func mentionArgument(original []string, l i18n.Locale) (string, error) {
+	if len(original) != 2 {
+		return "", errors.New(l.T("slack.command.delegate_usage", original[0]))
+	}
+
+	return parseMention(original[1], l)
+}
+
+// parseMention returns the id of the Slack user mentioned by field.
+func parseMention(field string, l i18n.Locale) (string, error) {
+	m := mentionPattern.FindStringSubmatch(field)
+
+	if m == nil {
+		return "", errors.New(l.T("slack.command.invalid_mention", field))
+	}
+
+	return m[1], nil
+}
+
+func clockDuration(hours, minutes string) (time.Duration, error) {
+	h, _ := strconv.Atoi(hours)
+	m, _ := strconv.Atoi(minutes)
+
+	if h > 23 || m > 59 {
+		return 0, errors.New("invalid time")
+	}
+
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// RenderUsage explains the command's arguments, after reason when given.
+func RenderUsage(reason string, l i18n.Locale) slack.Block {
+	var blocks []slack.BlockElement
+
+	if reason != "" {
+		blocks = append(blocks, slack.NewMrkDwn(fmt.Sprintf(":warning: %s", reason)))
+	}
+
+	return slack.Block{
+		ResponseType: "ephemeral",
+		Blocks:       append(blocks, slack.NewMrkDwn(l.T("slack.command.usage"))),
+	}
+}
+
+func RenderCommandError(message string) slack.Block {
+	return slack.Block{
+		ResponseType: "ephemeral",
+		Blocks: []slack.BlockElement{
+			slack.NewMrkDwn(message),
+		},
+	}
+}
+
+// RenderDirectBooking confirms a booking, offering to share it when
+// announcementId isn't 0.
+func RenderDirectBooking(room models.Room, request services.BookingRequest, announcementId int64, l i18n.Locale) slack.Block {
+	blocks := []slack.BlockElement{
+		slack.NewMrkDwn(l.T("slack.booking_success")),
+		bookingSummary(room, request.DateTime, request.Duration, l),
+	}
+
+	if announcementId != 0 {
+		blocks = append(blocks, ShareButton(announcementId, l))
+	}
+
+	return slack.Block{
+		ResponseType: "ephemeral",
+		Blocks:       blocks,
+	}
+}
+
+func RenderReminderSettings(leadTime int, l i18n.Locale) slack.Block {
+	message := l.T("slack.command.reminders_off")
+
+	if leadTime > 0 {
+		message = l.T("slack.command.reminders_on", leadTime)
+	}
+
+	return RenderCommandError(message)
+}
+
+// RenderLanguageSettings confirms the language set by /book lang, in that
+// language.
+func RenderLanguageSettings(l i18n.Locale) slack.Block {
+	return RenderCommandError(l.T("slack.command.lang_set", l.Name()))
+}
+
+func RenderCancellation(reservation api.Reservation, l i18n.Locale) slack.Block {
+	location, _ := common.LoadLocalTime()
+	text, _, err := describeReservation(reservation, location, l)
+
+	if err != nil {
+		text = reservation.ItemName
+	}
+
+	return slack.Block{
+		ResponseType: "ephemeral",
+		Blocks: []slack.BlockElement{
+			slack.NewMrkDwn(l.T("slack.cancel_success")),
+			slack.NewMrkDwn(text),
+		},
+	}
+}
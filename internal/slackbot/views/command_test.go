@@ -0,0 +1,182 @@
+package views
+
+import (
+	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/services"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseCommand(t *testing.T) {
+	location, _ := common.LoadLocalTime()
+
+	tests := []struct {
+		name    string // description of this test case
+		text    string
+		want    Cmd
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			text: "  ",
+			want: nil,
+		},
+		{
+			name: "help",
+			text: "aide",
+			want: &HelpCmd{},
+		},
+		{
+			name: "list",
+			text: "list",
+			want: &ReservationCmd{},
+		},
+		{
+			name: "cancel_next",
+			text: "cancel next",
+			want: &CancelNextCmd{},
+		},
+		{
+			name:    "cancel_without_target",
+			text:    "cancel",
+			wantErr: true,
+		},
+		{
+			name: "remind",
+			text: "remind 15",
+			want: &RemindCmd{LeadTime: 15},
+		},
+		{
+			name: "remind_off",
+			text: "rappel off",
+			want: &RemindCmd{LeadTime: 0},
+		},
+		{
+			name:    "remind_invalid",
+			text:    "remind soon",
+			wantErr: true,
+		},
+		{
+			name: "full_booking",
+			text: "2099-01-02 14:30 1h 2p Salle Bleue",
+			want: &DirectBookCmd{
+				Request: services.BookingRequest{Capacity: 2, Duration: 60, Name: "Salle Bleue", DateTime: time.Date(2099, 1, 2, 14, 30, 0, 0, location)},
+			},
+		},
+		{
+			name: "team",
+			text: "équipe 2099-01-02 14:30 1h",
+			want: &DirectBookCmd{
+				Request: services.BookingRequest{Capacity: 1, Duration: 60, DateTime: time.Date(2099, 1, 2, 14, 30, 0, 0, location)},
+				Team:    true,
+			},
+		},
+		{
+			name:    "team_without_booking",
+			text:    "team list",
+			wantErr: true,
+		},
+		{
+			name: "book_as",
+			text: "as <@U0OWNER|alice> 2099-01-02 14:30 Salle Bleue",
+			want: &DirectBookCmd{
+				Request: services.BookingRequest{Capacity: 1, Duration: 30, Name: "Salle Bleue", DateTime: time.Date(2099, 1, 2, 14, 30, 0, 0, location)},
+				BookAs:  "U0OWNER",
+			},
+		},
+		{
+			name:    "book_as_without_mention",
+			text:    "pour alice 2099-01-02 14:30",
+			wantErr: true,
+		},
+		{
+			name: "delegate",
+			text: "déléguer <@U0DELEGATE>",
+			want: &DelegateCmd{SlackUserId: "U0DELEGATE"},
+		},
+		{
+			name: "revoke",
+			text: "revoke <@U0DELEGATE|bob>",
+			want: &RevokeCmd{SlackUserId: "U0DELEGATE"},
+		},
+		{
+			name: "admin_purge",
+			text: "admin purge 30d",
+			want: &AdminPurgeCmd{Days: 30},
+		},
+		{
+			name:    "admin_unknown",
+			text:    "admin reboot",
+			wantErr: true,
+		},
+		{
+			name:    "delegate_nobody",
+			text:    "delegate",
+			wantErr: true,
+		},
+		{
+			name: "french_notation",
+			text: "2099-01-02 14h 1h30",
+			want: &DirectBookCmd{
+				Request: services.BookingRequest{Capacity: 1, Duration: 90, Name: "", DateTime: time.Date(2099, 1, 2, 14, 0, 0, 0, location)},
+			},
+		},
+		{
+			name: "date_without_time",
+			text: "2099-01-02 1h",
+			want: &DirectBookCmd{
+				Request: services.BookingRequest{Capacity: 1, Duration: 60, DateTime: time.Date(2099, 1, 2, common.OpeningHour, 0, 0, 0, location)},
+			},
+		},
+		{
+			name: "capacity_capped",
+			text: "2099-01-02 9:15 45m 6p",
+			want: &DirectBookCmd{
+				Request: services.BookingRequest{Capacity: 2, Duration: 45, Name: "", DateTime: time.Date(2099, 1, 2, 9, 15, 0, 0, location)},
+			},
+		},
+		{
+			name:    "not_a_quarter",
+			text:    "2099-01-02 14:10",
+			wantErr: true,
+		},
+		{
+			name:    "past_date",
+			text:    "2001-01-02 14:00",
+			wantErr: true,
+		},
+		{
+			name: "lang",
+			text: "lang fr",
+			want: &LangCmd{Locale: i18n.French},
+		},
+		{
+			name: "lang_auto",
+			text: "langue auto",
+			want: &LangCmd{},
+		},
+		{
+			name:    "lang_unsupported",
+			text:    "lang de",
+			wantErr: true,
+		},
+		{
+			name:    "duration_too_long",
+			text:    "2099-01-02 14:00 150m",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCommand(tt.text, i18n.English)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCommand() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package views
+
+import (
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/storage"
+	"cosoft-cli/internal/ui/slack"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Principal is a user who allowed the current one to book with their
+// account.
+type Principal struct {
+	SlackUserId string
+	Name        string
+}
+
+// Delegation is embedded by the booking views, which can book with the
+// account of a principal instead of the user's own.
+type Delegation struct {
+	Principals []Principal
+	// BookAs is the principal whose account books, empty for the user's own.
+	BookAs string
+}
+
+// DelegateCmd allows another Slack user to book with the user's account.
+type DelegateCmd struct {
+	SlackUserId string
+}
+
+// RevokeCmd withdraws a DelegateCmd.
+type RevokeCmd struct {
+	SlackUserId string
+}
+
+// DelegationsCmd lists the user's delegations, and the last bookings made
+// through them.
+type DelegationsCmd struct{}
+
+type BookAsPayload struct {
+	BookAs struct {
+		BookAs struct {
+			SelectedOption *struct {
+				Value string `json:"value"`
+			} `json:"selected_option"`
+		} `json:"bookAs"`
+	} `json:"bookAs"`
+}
+
+// readBookAs picks the principal selected in the submitted values, leaving
+// BookAs empty when none is, or when they no longer are one.
+func (d *Delegation) readBookAs(values json.RawMessage) {
+	var payload BookAsPayload
+
+	d.BookAs = ""
+
+	if json.Unmarshal(values, &payload) != nil || payload.BookAs.BookAs.SelectedOption == nil {
+		return
+	}
+
+	if _, ok := d.principal(payload.BookAs.BookAs.SelectedOption.Value); ok {
+		d.BookAs = payload.BookAs.BookAs.SelectedOption.Value
+	}
+}
+
+func (d *Delegation) principal(slackUserId string) (Principal, bool) {
+	for _, p := range d.Principals {
+		if p.SlackUserId == slackUserId {
+			return p, true
+		}
+	}
+
+	return Principal{}, false
+}
+
+func (d *Delegation) bookAsChoices() []slack.ChoicePayload {
+	choices := make([]slack.ChoicePayload, len(d.Principals))
+
+	for i, p := range d.Principals {
+		choices[i] = slack.ChoicePayload{Text: p.Name, Value: p.SlackUserId}
+	}
+
+	return choices
+}
+
+// withBookAs adds the choice of the account booking before the last of
+// blocks, its buttons, when the user has principals.
+func (d *Delegation) withBookAs(blocks []slack.BlockElement, l i18n.Locale) []slack.BlockElement {
+	if len(d.Principals) == 0 {
+		return blocks
+	}
+
+	bookAs := slack.NewSelect(l.T("slack.delegation.book_as"), l.T("slack.delegation.myself"), "bookAs", d.bookAsChoices())
+	last := len(blocks) - 1
+
+	return append(blocks[:last:last], bookAs, blocks[last])
+}
+
+// withBookAsInput adds the optional choice of the account booking to a
+// modal's form, when the user has principals.
+func (d *Delegation) withBookAsInput(blocks []slack.BlockElement, l i18n.Locale) []slack.BlockElement {
+	if len(d.Principals) == 0 {
+		return blocks
+	}
+
+	bookAs := slack.NewSelectInput(l.T("slack.delegation.book_as"), l.T("slack.delegation.myself"), "bookAs", d.bookAsChoices())
+	bookAs.Optional = true
+
+	return append(blocks, bookAs)
+}
+
+// bookedFor tells whose account a booking was made with, when it isn't the
+// user's own.
+func (d *Delegation) bookedFor(blocks []slack.BlockElement, l i18n.Locale) []slack.BlockElement {
+	p, ok := d.principal(d.BookAs)
+
+	if !ok {
+		return blocks
+	}
+
+	return append(blocks, slack.NewContext(l.T("slack.delegation.booked_for", p.Name)))
+}
+
+// RenderDelegations lists who may book with the user's account, whose
+// accounts the user may book with, and the last bookings made so.
+func RenderDelegations(delegates []string, principals []Principal, bookings []storage.DelegatedBooking, l i18n.Locale) slack.Block {
+	mentions := func(ids []string) string {
+		if len(ids) == 0 {
+			return l.T("slack.delegation.nobody")
+		}
+
+		quoted := make([]string, len(ids))
+
+		for i, id := range ids {
+			quoted[i] = fmt.Sprintf("<@%s>", id)
+		}
+
+		return strings.Join(quoted, ", ")
+	}
+
+	owners := make([]string, len(principals))
+
+	for i, p := range principals {
+		owners[i] = p.SlackUserId
+	}
+
+	blocks := []slack.BlockElement{
+		slack.NewMrkDwn(l.T("slack.delegation.delegates", mentions(delegates))),
+		slack.NewMrkDwn(l.T("slack.delegation.principals", mentions(owners))),
+	}
+
+	if len(bookings) > 0 {
+		lines := make([]string, len(bookings))
+
+		for i, b := range bookings {
+			lines[i] = l.T(
+				"slack.delegation.audit_line",
+				b.DelegateSlackUserId,
+				b.RoomName,
+				l.DateTime(b.Start),
+				b.OwnerSlackUserId,
+				b.Cost,
+			)
+		}
+
+		blocks = append(blocks, slack.NewMrkDwn(l.T("slack.delegation.audit", strings.Join(lines, "\n"))))
+	}
+
+	return slack.Block{
+		ResponseType: "ephemeral",
+		Blocks:       blocks,
+	}
+}
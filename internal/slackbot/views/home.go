@@ -0,0 +1,118 @@
+package views
+
+import (
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/storage"
+	"cosoft-cli/internal/ui/slack"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// HomeView is the dashboard displayed in the bot's App Home tab. Unlike the
+// other views, it isn't stored: it is rebuilt each time the tab is opened.
+type HomeView struct {
+	User         *storage.User
+	Reservations []api.Reservation
+	Calendar     string
+	// Principals are passed on to the booking views.
+	Principals []Principal
+	Alert
+}
+
+type HomeCmd struct{}
+
+const homeCancelPrefix = "cancel-"
+
+func (h *HomeView) Update(action Action) (View, Cmd) {
+	switch action.ActionID {
+	case "quick-book":
+		return &QuickBookView{Delegation: Delegation{Principals: h.Principals}}, nil
+	case "browse":
+		return &BrowseView{Delegation: Delegation{Principals: h.Principals}}, nil
+	case "refresh":
+		return h, &HomeCmd{}
+	}
+
+	if id, ok := strings.CutPrefix(action.ActionID, homeCancelPrefix); ok {
+		return h, &CancelReservationCmd{ReservationId: &id}
+	}
+
+	return h, nil
+}
+
+func RenderHomeView(h *HomeView, l i18n.Locale) slack.Home {
+	if h.User == nil {
+		return slack.NewHome([]slack.BlockElement{
+			slack.NewHeader("Cosoft"),
+			slack.NewMrkDwn(l.T("slack.home.login")),
+		})
+	}
+
+	blocks := []slack.BlockElement{
+		slack.NewHeader(l.T("slack.home.title")),
+		slack.NewMrkDwn(l.T("slack.logged_in_as", h.User.FirstName, h.User.LastName, h.User.Email)),
+		slack.NewMrkDwn(l.T("slack.credits_left", h.User.Credits)),
+		slack.NewButtons([]slack.ChoicePayload{
+			{Text: l.T("slack.quick_book.title"), Value: "quick-book"},
+			{Text: l.T("slack.menu.browse"), Value: "browse"},
+			{Text: l.T("slack.home.refresh"), Value: "refresh"},
+		}),
+	}
+
+	if h.Error != nil {
+		blocks = append(blocks, slack.NewContext(*h.Error))
+	}
+
+	blocks = append(
+		blocks,
+		slack.NewDivider(),
+		slack.NewHeader(l.T("slack.reservations.title")),
+	)
+
+	location, err := common.LoadLocalTime()
+	if err != nil {
+		slog.Error("could not load the local time", "err", err.Error())
+		return slack.NewHome(blocks)
+	}
+
+	for _, r := range h.Reservations {
+		text, start, err := describeReservation(r, location, l)
+		if err != nil {
+			slog.Error("could not describe a reservation", "reservation", r.OrderResourceRentId, "err", err.Error())
+			continue
+		}
+
+		// Reservations already started cannot be cancelled.
+		if start.Before(time.Now()) {
+			blocks = append(blocks, slack.NewMrkDwn(text))
+			continue
+		}
+
+		blocks = append(blocks, slack.NewMenuItem(text, l.T("slack.cancel"), homeCancelPrefix+r.OrderResourceRentId).
+			WithConfirm(cancelConfirm(r, l)))
+	}
+
+	if len(h.Reservations) == 0 {
+		blocks = append(blocks, slack.NewMrkDwn(l.T("slack.reservations.none")))
+	}
+
+	blocks = append(
+		blocks,
+		slack.NewDivider(),
+		slack.NewHeader(l.T("slack.home.occupancy")),
+	)
+
+	if h.Calendar != "" {
+		blocks = append(
+			blocks,
+			slack.NewKitchenSink(h.Calendar),
+			slack.NewMrkDwn(l.T("slack.calendar.legend_own")),
+			slack.NewMrkDwn(l.T("slack.calendar.legend_other")),
+		)
+	}
+
+	return slack.NewHome(blocks)
+}
@@ -1,12 +1,19 @@
 package views
 
 import (
+	"cosoft-cli/internal/i18n"
 	"cosoft-cli/internal/storage"
 	"cosoft-cli/internal/ui/slack"
 )
 
 type LandingView struct {
 	User storage.User
+	// Principals are passed on to the booking views.
+	Principals []Principal
+}
+
+func init() {
+	Register("landing", func() *LandingView { return &LandingView{} }, RenderLandingView, nil)
 }
 
 type LandingCmd struct{}
@@ -15,9 +22,9 @@ func (lv *LandingView) Update(action Action) (View, Cmd) {
 
 	switch action.ActionID {
 	case "quick-book":
-		return &QuickBookView{}, nil
+		return &QuickBookView{Delegation: Delegation{Principals: lv.Principals}}, nil
 	case "browse":
-		return &BrowseView{}, nil
+		return &BrowseView{Delegation: Delegation{Principals: lv.Principals}}, nil
 	case "reservations":
 		return &ReservationView{}, &ReservationCmd{}
 	case "calendar":
@@ -27,6 +34,6 @@ func (lv *LandingView) Update(action Action) (View, Cmd) {
 	}
 }
 
-func RenderLandingView(lv *LandingView) slack.Block {
-	return slack.MainMenu(lv.User)
+func RenderLandingView(lv *LandingView, l i18n.Locale) slack.Block {
+	return slack.MainMenu(lv.User, l)
 }
@@ -1,16 +1,23 @@
 package views
 
 import (
+	"cosoft-cli/internal/i18n"
 	"cosoft-cli/internal/ui/slack"
 	"encoding/json"
-	"fmt"
+	"log/slog"
 	"slices"
 )
 
+// LoginView is stored in slack_messages like any other view, so it must never
+// hold the credentials: they only live in the LoginCmd built from the
+// submitted values.
 type LoginView struct {
-	Email    string
-	Password string
-	Error    *string
+	Modal
+	Alert
+}
+
+func init() {
+	Register("login", func() *LoginView { return &LoginView{} }, RenderLoginView, RenderLoginModal)
 }
 
 type LoginCmd struct {
@@ -18,6 +25,14 @@ type LoginCmd struct {
 	Password string
 }
 
+// LogValue keeps the credentials out of the logs, should a LoginCmd be logged.
+func (c LoginCmd) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("email", "[REDACTED]"),
+		slog.String("password", "[REDACTED]"),
+	)
+}
+
 type LoginValues struct {
 	Email struct {
 		Email struct {
@@ -38,34 +53,43 @@ func (l *LoginView) Update(action Action) (View, Cmd) {
 
 	err := json.Unmarshal(action.Values, &values)
 	if err != nil {
-		fmt.Println(err)
+		action.Logger().Error("could not read the login form", "err", err.Error())
 		return nil, err
 	}
 
-	l.Email = values.Email.Email.Value
-	l.Password = values.Password.Password.Value
+	email := values.Email.Email.Value
+	password := values.Password.Password.Value
 	l.Error = nil
+	l.FieldErrors = nil
 
-	if l.Email == "" || l.Password == "" {
-		s := ":warning: Tous les champs sont requis"
+	if email == "" || password == "" {
+		s := ":warning: " + action.Locale.T("slack.fields_required")
 		l.Error = &s
 
+		if email == "" {
+			l.Invalidate("email", action.Locale.T("slack.login.required"))
+		}
+
+		if password == "" {
+			l.Invalidate("password", action.Locale.T("slack.login.required"))
+		}
+
 		return l, nil
 	}
 
 	return l, &LoginCmd{
-		Email:    l.Email,
-		Password: l.Password,
+		Email:    email,
+		Password: password,
 	}
 }
 
-func RenderLoginView(l *LoginView) slack.Block {
+func RenderLoginView(l *LoginView, locale i18n.Locale) slack.Block {
 	loginBlocks := []slack.BlockElement{
-		slack.NewMrkDwn(":information_source:  Pour réserver une salle, il faut d'abord vous identifier."),
-		slack.NewInput("Email", "email"),
-		slack.NewInput("Mot de passe", "password"),
-		slack.NewContext(":warning: Le mot de passe est affiché en clair dans le champ"),
-		slack.NewButtons([]slack.ChoicePayload{{Text: "Connexion", Value: "login"}}),
+		slack.NewMrkDwn(locale.T("slack.login.intro")),
+		slack.NewInput(locale.T("slack.login.email"), "email"),
+		slack.NewInput(locale.T("slack.login.password"), "password"),
+		slack.NewContext(locale.T("slack.login.password_visible")),
+		slack.NewButtons([]slack.ChoicePayload{{Text: locale.T("slack.login.submit"), Value: "login"}}),
 	}
 
 	if l.Error != nil {
@@ -83,3 +107,14 @@ func RenderLoginView(l *LoginView) slack.Block {
 
 	return blocks
 }
+
+func RenderLoginModal(l *LoginView, locale i18n.Locale) slack.Modal {
+	blocks := []slack.BlockElement{
+		slack.NewMrkDwn(locale.T("slack.login.intro")),
+		slack.NewInput(locale.T("slack.login.email"), "email"),
+		slack.NewInput(locale.T("slack.login.password"), "password"),
+		slack.NewContext(locale.T("slack.login.password_visible")),
+	}
+
+	return slack.NewModal(locale.T("slack.login.submit"), locale.T("slack.close"), "login", blocks).WithSubmit(locale.T("slack.login.submit"))
+}
@@ -0,0 +1,108 @@
+package views
+
+import (
+	"bytes"
+	"cosoft-cli/internal/storage"
+	"encoding/json"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	secretEmail    = "jane.doe@example.com"
+	secretPassword = "hunter2-very-secret"
+	secretToken    = "w-auth-token-value"
+)
+
+func loginValues(t *testing.T, email, password string) json.RawMessage {
+	t.Helper()
+
+	var values LoginValues
+	values.Email.Email.Type = "plain_text_input"
+	values.Email.Email.Value = email
+	values.Password.Password.Type = "plain_text_input"
+	values.Password.Password.Value = password
+
+	raw, err := json.Marshal(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return raw
+}
+
+func TestStoredViewsHaveNoCredentials(t *testing.T) {
+	store, err := storage.NewStore(filepath.Join(t.TempDir(), "database.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	if err := store.SetupDatabase(); err != nil {
+		t.Fatal(err)
+	}
+
+	login := &LoginView{}
+	_, cmd := login.Update(Action{ActionID: "login", Values: loginValues(t, secretEmail, secretPassword)})
+
+	if c, ok := cmd.(*LoginCmd); !ok || c.Password != secretPassword {
+		t.Fatalf("Update() = %+v, want a LoginCmd carrying the credentials", cmd)
+	}
+
+	// A failed attempt is stored too, with its error.
+	incomplete := &LoginView{}
+	incomplete.Update(Action{ActionID: "login", Values: loginValues(t, secretEmail, "")})
+
+	tests := []struct {
+		name string
+		view View
+	}{
+		{name: "login", view: login},
+		{name: "login_invalid", view: incomplete},
+		{
+			name: "landing",
+			view: &LandingView{User: storage.User{
+				Email:        secretEmail,
+				WAuth:        secretToken,
+				WAuthRefresh: secretToken,
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := store.SetSlackState("U123", ViewType(tt.view), tt.view); err != nil {
+				t.Fatal(err)
+			}
+
+			state, err := store.GetSlackState("U123")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			payload := string(state.Payload)
+
+			for _, secret := range []string{secretPassword, secretToken} {
+				if strings.Contains(payload, secret) {
+					t.Errorf("stored %s view contains %q: %s", tt.name, secret, payload)
+				}
+			}
+
+			if _, err := RestoreView(state.MessageType, state.Payload); err != nil {
+				t.Errorf("RestoreView() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestLoginCmdIsRedactedInLogs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	logger.Info("login", "cmd", LoginCmd{Email: secretEmail, Password: secretPassword})
+
+	if strings.Contains(buf.String(), secretPassword) || strings.Contains(buf.String(), secretEmail) {
+		t.Errorf("log contains credentials: %s", buf.String())
+	}
+}
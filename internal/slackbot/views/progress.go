@@ -0,0 +1,76 @@
+package views
+
+import (
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/ui/slack"
+	"time"
+)
+
+// Progress is embedded by the views displayed while a command loads them,
+// which can take a while when Cosoft is slow.
+type Progress struct {
+	// Loading is set while the command runs, since Started.
+	Loading bool
+	Started time.Time
+	// Done out of Total steps are finished, Total being 0 when the command
+	// has no steps.
+	Done  int
+	Total int
+}
+
+type ProgressView interface {
+	View
+	progress() *Progress
+}
+
+func (p *Progress) progress() *Progress {
+	return p
+}
+
+// Start marks the view as loading from now on.
+func (p *Progress) Start() {
+	*p = Progress{Loading: true, Started: time.Now()}
+}
+
+// Step records that done out of total steps are finished.
+func (p *Progress) Step(done, total int) {
+	p.Done = done
+	p.Total = total
+}
+
+// Stop marks the view as loaded.
+func (p *Progress) Stop() {
+	*p = Progress{}
+}
+
+// AsProgress returns the progress of v, if v displays one while loading.
+func AsProgress(v View) (*Progress, bool) {
+	pv, ok := v.(ProgressView)
+
+	if !ok {
+		return nil, false
+	}
+
+	return pv.progress(), true
+}
+
+// RenderProgress tells how long the view has been loading for, and how far
+// it went.
+func RenderProgress(p *Progress, l i18n.Locale) slack.BlockElement {
+	elapsed := int(time.Since(p.Started).Round(time.Second).Seconds())
+
+	if p.Total > 0 {
+		return slack.NewContext(l.T("slack.progress.steps", p.Done, p.Total, elapsed))
+	}
+
+	return slack.NewContext(l.T("slack.progress.elapsed", elapsed))
+}
+
+// RenderTimeout replaces a view Cosoft is too slow to load, offering to try
+// again. The view handles the "retry" action.
+func RenderTimeout(l i18n.Locale) []slack.BlockElement {
+	return []slack.BlockElement{
+		slack.NewMrkDwn(l.T("slack.progress.timeout")),
+		slack.NewButtons([]slack.ChoicePayload{{Text: l.T("slack.progress.retry"), Value: "retry"}}),
+	}
+}
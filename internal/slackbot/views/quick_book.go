@@ -2,22 +2,30 @@ package views
 
 import (
 	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/i18n"
 	"cosoft-cli/internal/ui/slack"
 	"cosoft-cli/shared/models"
 	"encoding/json"
-	"fmt"
 	"slices"
 	"strconv"
 	"time"
 )
 
 type QuickBookView struct {
+	Modal
 	Phase      int
 	NbPeople   string
 	Duration   string
 	Rooms      *[]models.Room
 	PickedRoom *models.Room
-	Error      *string
+	// AnnouncementId allows sharing the booking, once done.
+	AnnouncementId int64
+	Alert
+	Delegation
+}
+
+func init() {
+	Register("quick-book", func() *QuickBookView { return &QuickBookView{} }, RenderQuickBookView, RenderQuickBookModal)
 }
 
 type QuickBookCmd struct {
@@ -25,6 +33,8 @@ type QuickBookCmd struct {
 	Duration   int
 	Datetime   time.Time
 	PickedRoom models.Room
+	// BookAs is the principal whose account books, empty for the user's own.
+	BookAs string
 }
 
 type QuickBookValues struct {
@@ -54,16 +64,18 @@ func (qb *QuickBookView) Update(action Action) (View, Cmd) {
 		err := json.Unmarshal(action.Values, &values)
 
 		if err != nil {
-			fmt.Println(err)
+			action.Logger().Error("could not read the quick book filters", "err", err.Error())
 			return qb, nil
 		}
 
 		qb.Duration = values.Duration.Duration.SelectedOption.Value
 		qb.NbPeople = values.NbPeople.NbPeople.SelectedOption.Value
+		qb.readBookAs(action.Values)
 		qb.Error = nil
+		qb.FieldErrors = nil
 
 		if qb.NbPeople == "" || qb.Duration == "" {
-			s := ":warning: Tous les champs sont requis"
+			s := ":warning: " + action.Locale.T("slack.fields_required")
 			qb.Error = &s
 
 			return qb, nil
@@ -72,14 +84,14 @@ func (qb *QuickBookView) Update(action Action) (View, Cmd) {
 		nbPeople, err := strconv.Atoi(qb.NbPeople)
 
 		if err != nil {
-			fmt.Println(err)
+			action.Logger().Error("invalid number of people", "err", err.Error())
 			return qb, nil
 		}
 
 		duration, err := strconv.Atoi(qb.Duration)
 
 		if err != nil {
-			fmt.Println(err)
+			action.Logger().Error("invalid duration", "err", err.Error())
 			return qb, nil
 		}
 
@@ -90,6 +102,7 @@ func (qb *QuickBookView) Update(action Action) (View, Cmd) {
 			NbPeople: nbPeople,
 			Duration: duration,
 			Datetime: dt,
+			BookAs:   qb.BookAs,
 		}
 
 	default:
@@ -97,8 +110,8 @@ func (qb *QuickBookView) Update(action Action) (View, Cmd) {
 	}
 }
 
-func RenderQuickBookView(qb *QuickBookView) slack.Block {
-	blocks := slack.QuickBookMenu()
+func RenderQuickBookView(qb *QuickBookView, l i18n.Locale) slack.Block {
+	blocks := slack.QuickBookMenu(l)
 
 	switch qb.Phase {
 	case 0:
@@ -110,26 +123,31 @@ func RenderQuickBookView(qb *QuickBookView) slack.Block {
 			)
 		}
 
+		blocks.Blocks = qb.withBookAs(blocks.Blocks, l)
+
 		return blocks
 
 	case 2:
+		status := slack.BlockElement(slack.NewMrkDwn(l.T("slack.quick_book.booking")))
+
+		// The booking failed after the room was found.
+		if qb.Error != nil {
+			status = slack.NewContext(*qb.Error)
+		}
+
 		// Remove action buttons
 		blocks.Blocks = blocks.Blocks[:len(blocks.Blocks)-1]
 		// Add rest of the feedback
 		blocks.Blocks = slices.Insert(
 			blocks.Blocks,
 			len(blocks.Blocks),
-			slack.BlockElement(slack.NewMrkDwn(":large_green_circle: Une salle a été trouvée !")),
-			slack.BlockElement(slack.NewMrkDwn("Réservation en cours...")),
+			slack.BlockElement(slack.NewMrkDwn(l.T("slack.quick_book.found"))),
+			status,
 		)
 
 		return blocks
 	case 3:
 		duration, _ := strconv.Atoi(qb.Duration)
-		startTime := common.GetClosestQuarterHour()
-		endTime := startTime.Add(time.Duration(duration) * time.Minute)
-		dateFormat := "02/01/2006 15:04"
-		paidPrice := qb.PickedRoom.Price * (float64(duration) / 60)
 
 		// Remove action buttons
 		blocks.Blocks = blocks.Blocks[:len(blocks.Blocks)-1]
@@ -138,22 +156,81 @@ func RenderQuickBookView(qb *QuickBookView) slack.Block {
 			blocks.Blocks,
 			len(blocks.Blocks),
 			slack.BlockElement(slack.NewDivider()),
-			slack.BlockElement(slack.NewMrkDwn(":white_check_mark: *Réservation réussie !*")),
+			slack.BlockElement(slack.NewMrkDwn(l.T("slack.booking_success"))),
+			slack.BlockElement(bookingSummary(*qb.PickedRoom, common.GetClosestQuarterHour(), duration, l)),
+		)
 
-			slack.BlockElement(slack.NewMultiMarkdown([]string{
-				fmt.Sprintf("*Salle de réunion :*\n%s", qb.PickedRoom.Name),
-				fmt.Sprintf("*Durée :*\n%s → %s", startTime.Format(dateFormat), endTime.Format(dateFormat)),
-				fmt.Sprintf("*Coût :*\n%.2f credits", paidPrice),
-			})),
+		blocks.Blocks = qb.bookedFor(blocks.Blocks, l)
+
+		if qb.AnnouncementId != 0 {
+			blocks.Blocks = append(blocks.Blocks, ShareButton(qb.AnnouncementId, l))
+		}
+
+		blocks.Blocks = append(
+			blocks.Blocks,
 			slack.BlockElement(slack.NewMenuItem(
-				"Vous pouvez maintenant revenir à l'accueil",
-				"Retour",
+				l.T("slack.back_to_landing_hint"),
+				l.T("slack.back"),
 				"cancel",
 			)),
 		)
 
 		return blocks
 	default:
-		return slack.QuickBookMenu()
+		return slack.QuickBookMenu(l)
+	}
+}
+
+func RenderQuickBookModal(qb *QuickBookView, l i18n.Locale) slack.Modal {
+	var blocks []slack.BlockElement
+
+	switch qb.Phase {
+	case 0:
+		blocks = qb.withBookAsInput(slack.QuickBookForm(l), l)
+		if qb.Error != nil {
+			blocks = append(blocks, slack.NewContext(*qb.Error))
+		}
+
+		return slack.NewModal(l.T("slack.quick_book.title"), l.T("slack.close"), "quick-book", blocks).WithSubmit(l.T("slack.book"))
+	case 2:
+		blocks = []slack.BlockElement{
+			slack.NewMrkDwn(l.T("slack.quick_book.found")),
+			slack.NewMrkDwn(l.T("slack.quick_book.booking")),
+		}
+	case 3:
+		duration, _ := strconv.Atoi(qb.Duration)
+
+		blocks = []slack.BlockElement{
+			slack.NewMrkDwn(l.T("slack.booking_success")),
+			bookingSummary(*qb.PickedRoom, common.GetClosestQuarterHour(), duration, l),
+		}
+
+		blocks = qb.bookedFor(blocks, l)
+
+		if qb.AnnouncementId != 0 {
+			blocks = append(blocks, ShareButton(qb.AnnouncementId, l))
+		}
+	default:
+		blocks = []slack.BlockElement{
+			slack.NewMrkDwn(l.T("slack.loading")),
+		}
+	}
+
+	if qb.Error != nil {
+		blocks = append(blocks, slack.NewContext(*qb.Error))
 	}
+
+	return slack.NewModal(l.T("slack.quick_book.title"), l.T("slack.close"), "quick-book", blocks)
+}
+
+// bookingSummary lists the room, time range and cost of a completed booking.
+func bookingSummary(room models.Room, startTime time.Time, duration int, l i18n.Locale) slack.MultiMarkdown {
+	endTime := startTime.Add(time.Duration(duration) * time.Minute)
+	paidPrice := room.Price * (float64(duration) / 60)
+
+	return slack.NewMultiMarkdown([]string{
+		l.T("slack.summary.room", room.Name),
+		l.T("slack.summary.duration", l.DateTime(startTime), l.DateTime(endTime)),
+		l.T("slack.summary.cost", paidPrice),
+	})
 }
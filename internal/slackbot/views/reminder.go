@@ -0,0 +1,88 @@
+package views
+
+import (
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/ui/slack"
+	"strings"
+	"time"
+)
+
+// ReminderView handles the buttons of the reminders sent by DM before a
+// reservation starts. Like the home tab, it isn't stored: the reservation's
+// id is carried by the action ids.
+type ReminderView struct{}
+
+// NotGoingCmd releases the room without asking for a confirmation.
+type NotGoingCmd struct {
+	ReservationId string
+}
+
+const (
+	reminderPrefix   = "reminder-"
+	reminderExtend   = reminderPrefix + "extend:"
+	reminderCancel   = reminderPrefix + "cancel:"
+	reminderNotGoing = reminderPrefix + "not-going:"
+
+	// ReminderExtension is how many minutes the "extend" button adds.
+	ReminderExtension = 30
+)
+
+func init() {
+	RegisterDetached(reminderPrefix, func() *ReminderView { return &ReminderView{} })
+}
+
+func (r *ReminderView) Update(action Action) (View, Cmd) {
+	if id, ok := strings.CutPrefix(action.ActionID, reminderExtend); ok {
+		return r, &ResizeReservationCmd{ReservationId: id, Minutes: ReminderExtension}
+	}
+
+	if id, ok := strings.CutPrefix(action.ActionID, reminderCancel); ok {
+		return r, &CancelReservationCmd{ReservationId: &id}
+	}
+
+	if id, ok := strings.CutPrefix(action.ActionID, reminderNotGoing); ok {
+		return r, &NotGoingCmd{ReservationId: id}
+	}
+
+	return r, nil
+}
+
+func RenderReminder(r api.Reservation, l i18n.Locale) slack.Block {
+	location, _ := common.LoadLocalTime()
+	start, _ := time.ParseInLocation("2006-01-02T15:04:05", r.Start, location)
+	minutes := int(time.Until(start).Round(time.Minute).Minutes())
+
+	cancel := slack.ButtonPayload{
+		Type:     "button",
+		Text:     slack.BlockPayload{Type: "plain_text", Text: l.T("slack.cancel_reservation"), Emoji: true},
+		Value:    reminderCancel + r.OrderResourceRentId,
+		ActionId: reminderCancel + r.OrderResourceRentId,
+		Confirm:  cancelConfirm(r, l),
+	}
+
+	buttons := slack.NewButtons([]slack.ChoicePayload{
+		{Text: l.T("slack.reminder.extend", ReminderExtension), Value: reminderExtend + r.OrderResourceRentId},
+		{Text: l.T("slack.reminder.not_going"), Value: reminderNotGoing + r.OrderResourceRentId},
+	})
+	buttons.Elements = append(buttons.Elements, cancel)
+
+	return slack.Block{
+		Blocks: []slack.BlockElement{
+			slack.NewMrkDwn(l.T("slack.reminder.starts", r.ItemName, l.Time(start), minutes)),
+			buttons,
+		},
+	}
+}
+
+// RenderReminderOutcome replaces the reminder once one of its buttons has
+// been used.
+func RenderReminderOutcome(message string) slack.Block {
+	return slack.Block{
+		ReplaceOriginal: true,
+		Blocks: []slack.BlockElement{
+			slack.NewMrkDwn(message),
+		},
+	}
+}
@@ -3,8 +3,14 @@ package views
 import (
 	"cosoft-cli/internal/api"
 	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/i18n"
 	"cosoft-cli/internal/ui/slack"
+	"encoding/json"
 	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,7 +22,19 @@ type ReservationView struct {
 	PickedReservation *api.Reservation
 	ReservationId     *string
 	BookingStarted    bool
-	Error             *string
+	// Minutes and Credits describe the last resize, for its summary.
+	Minutes int
+	Credits float64
+	// SelectedIds are the reservations ticked to be cancelled together, and
+	// Results the outcome of each cancellation.
+	SelectedIds []string
+	Results     []CancellationResult
+	Alert
+	Progress
+}
+
+func init() {
+	Register("reservations", func() *ReservationView { return &ReservationView{} }, RenderReservationsView, nil)
 }
 
 type ReservationCmd struct {
@@ -28,17 +46,111 @@ type CancelReservationCmd struct {
 	ReservationId *string
 }
 
+// ResizeReservationCmd moves a reservation's end by Minutes: later when
+// positive, earlier when negative.
+type ResizeReservationCmd struct {
+	ReservationId string
+	Minutes       int
+}
+
+// CancelReservationsCmd cancels several reservations, carrying on after
+// failures.
+type CancelReservationsCmd struct {
+	ReservationIds []string
+}
+
+// CancellationResult is the outcome of cancelling one of several
+// reservations, Error being nil on success.
+type CancellationResult struct {
+	Reservation api.Reservation
+	Error       *string
+}
+
+const (
+	resizePrefix       = "resize:"
+	cancelDayPrefix    = "cancel-day:"
+	selectReservations = "select-reservations"
+	// maxCheckboxes is the most options Slack allows in a checkboxes element.
+	maxCheckboxes = 10
+)
+
+type selectedReservationsPayload struct {
+	Select *struct {
+		Select struct {
+			SelectedOptions []struct {
+				Value string `json:"value"`
+			} `json:"selected_options"`
+		} `json:"select-reservations"`
+	} `json:"select-reservations"`
+}
+
 func (r *ReservationView) Update(action Action) (View, Cmd) {
 	if action.ActionID == "back" {
 		return r, &LandingCmd{}
 	}
 
+	if action.ActionID == "retry" {
+		return r, &ReservationCmd{}
+	}
+
 	if action.ActionID == "cancel" {
 		return r, &CancelReservationCmd{
 			ReservationId: r.ReservationId,
 		}
 	}
 
+	switch action.ActionID {
+	case selectReservations:
+		r.readSelection(action.Values)
+		return r, nil
+	case "cancel-selected":
+		r.readSelection(action.Values)
+		r.SelectedIds = r.cancellable(r.SelectedIds)
+
+		if len(r.SelectedIds) > 0 {
+			r.Phase = 3
+		}
+
+		return r, nil
+	case "confirm-cancel-selected":
+		return r, &CancelReservationsCmd{ReservationIds: r.SelectedIds}
+	case "back-to-list":
+		r.Phase = 0
+		return r, nil
+	}
+
+	if day, ok := strings.CutPrefix(action.ActionID, cancelDayPrefix); ok {
+		var ids []string
+
+		for _, reservation := range *r.Reservations {
+			if strings.HasPrefix(reservation.Start, day+"T") {
+				ids = append(ids, reservation.OrderResourceRentId)
+			}
+		}
+
+		r.SelectedIds = r.cancellable(ids)
+
+		if len(r.SelectedIds) > 0 {
+			r.Phase = 3
+		}
+
+		return r, nil
+	}
+
+	if m, ok := strings.CutPrefix(action.ActionID, resizePrefix); ok && r.ReservationId != nil {
+		minutes, err := strconv.Atoi(m)
+		if err != nil {
+			return r, nil
+		}
+
+		r.Minutes = minutes
+
+		return r, &ResizeReservationCmd{
+			ReservationId: *r.ReservationId,
+			Minutes:       minutes,
+		}
+	}
+
 	// Action id is the uuid of a selected booking.
 	if err := uuid.Validate(action.ActionID); err == nil {
 		r.ReservationId = &action.ActionID
@@ -54,13 +166,13 @@ func (r *ReservationView) Update(action Action) (View, Cmd) {
 		}
 
 		if r.PickedReservation == nil {
-			fmt.Println("Could not find a picked reservation")
+			action.Logger().Warn("picked reservation not found")
 			return r, nil
 		}
 
 		location, err := common.LoadLocalTime()
 		if err != nil {
-			fmt.Println(err)
+			action.Logger().Error("could not load the local time", "err", err.Error())
 			return r, nil
 		}
 
@@ -68,7 +180,7 @@ func (r *ReservationView) Update(action Action) (View, Cmd) {
 		bookinStartsAt, err := time.ParseInLocation("2006-01-02T15:04:05", r.PickedReservation.Start, location)
 
 		if err != nil {
-			fmt.Println(err)
+			action.Logger().Error("invalid reservation start", "reservation", r.PickedReservation.OrderResourceRentId, "err", err.Error())
 			return r, nil
 		}
 
@@ -80,7 +192,120 @@ func (r *ReservationView) Update(action Action) (View, Cmd) {
 	return r, nil
 }
 
-func RenderReservationsView(r *ReservationView) slack.Block {
+// readSelection keeps the ticked reservations, when values has them.
+func (r *ReservationView) readSelection(values []byte) {
+	var payload selectedReservationsPayload
+
+	if err := json.Unmarshal(values, &payload); err != nil || payload.Select == nil {
+		return
+	}
+
+	r.SelectedIds = nil
+
+	for _, option := range payload.Select.Select.SelectedOptions {
+		r.SelectedIds = append(r.SelectedIds, option.Value)
+	}
+}
+
+// cancellable keeps the ids of the reservations which haven't started yet,
+// in the list's order.
+func (r *ReservationView) cancellable(ids []string) []string {
+	var kept []string
+
+	for _, reservation := range r.cancellableReservations() {
+		if slices.Contains(ids, reservation.OrderResourceRentId) {
+			kept = append(kept, reservation.OrderResourceRentId)
+		}
+	}
+
+	return kept
+}
+
+func (r *ReservationView) cancellableReservations() []api.Reservation {
+	location, err := common.LoadLocalTime()
+	if err != nil || r.Reservations == nil {
+		return nil
+	}
+
+	var reservations []api.Reservation
+
+	for _, reservation := range *r.Reservations {
+		start, err := time.ParseInLocation("2006-01-02T15:04:05", reservation.Start, location)
+		if err == nil && start.After(time.Now()) {
+			reservations = append(reservations, reservation)
+		}
+	}
+
+	return reservations
+}
+
+// bulkCancelBlocks offers to tick reservations to cancel together, or to
+// cancel every reservation of a day.
+func bulkCancelBlocks(r *ReservationView, location *time.Location, l i18n.Locale) []slack.BlockElement {
+	reservations := r.cancellableReservations()
+
+	if len(reservations) < 2 {
+		return nil
+	}
+
+	var choices []slack.ChoicePayload
+	var days []string
+
+	for _, reservation := range reservations {
+		start, _ := time.ParseInLocation("2006-01-02T15:04:05", reservation.Start, location)
+
+		if len(choices) < maxCheckboxes {
+			choices = append(choices, slack.ChoicePayload{
+				Text:  fmt.Sprintf("*%s* %s", reservation.ItemName, l.DateTime(start)),
+				Value: reservation.OrderResourceRentId,
+			})
+		}
+
+		if day := start.Format(time.DateOnly); !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+
+	buttons := []slack.ChoicePayload{{Text: l.T("slack.reservations.cancel_selected"), Value: "cancel-selected"}}
+
+	for _, day := range days[:min(len(days), 4)] {
+		date, _ := time.ParseInLocation(time.DateOnly, day, location)
+		buttons = append(buttons, slack.ChoicePayload{
+			Text:  l.T("slack.reservations.cancel_day", l.Day(date)),
+			Value: cancelDayPrefix + day,
+		})
+	}
+
+	return []slack.BlockElement{
+		slack.NewDivider(),
+		slack.NewCheckboxes(l.T("slack.reservations.cancel_several"), selectReservations, choices, r.SelectedIds),
+		slack.NewButtons(buttons),
+	}
+}
+
+// SelectedReservations returns the reservations whose id is in ids.
+func (r *ReservationView) SelectedReservations(ids []string) []api.Reservation {
+	var reservations []api.Reservation
+
+	for _, reservation := range *r.Reservations {
+		if slices.Contains(ids, reservation.OrderResourceRentId) {
+			reservations = append(reservations, reservation)
+		}
+	}
+
+	return reservations
+}
+
+func RenderReservationsView(r *ReservationView, l i18n.Locale) slack.Block {
+	if r.Loading {
+		return slack.Block{
+			Blocks: []slack.BlockElement{
+				slack.NewHeader(l.T("slack.reservations.title")),
+				RenderProgress(&r.Progress, l),
+			},
+		}
+	}
+
 	if r.Error != nil {
 		return slack.Block{
 			Blocks: []slack.BlockElement{
@@ -93,67 +318,60 @@ func RenderReservationsView(r *ReservationView) slack.Block {
 	case 0:
 		blocks := slack.Block{
 			Blocks: []slack.BlockElement{
-				slack.NewHeader("Mes réservations"),
-				slack.NewMrkDwn(fmt.Sprintf("*%d* réservation(s) à venir", len(*r.Reservations))),
+				slack.NewHeader(l.T("slack.reservations.title")),
+				slack.NewMrkDwn(l.T("slack.reservations.count", len(*r.Reservations))),
 			},
 		}
 
 		location, err := common.LoadLocalTime()
 		if err != nil {
-			fmt.Println(err)
+			slog.Error("could not load the local time", "err", err.Error())
 			return slack.Block{}
 		}
 
 		var list []slack.BlockElement
 
 		for _, r := range *r.Reservations {
-			parsedStart, err := time.ParseInLocation("2006-01-02T15:04:05", r.Start, location)
-			if err != nil {
-				fmt.Println(err)
-				return slack.Block{}
-			}
-
-			parsedEnd, err := time.ParseInLocation("2006-01-02T15:04:05", r.End, location)
+			text, _, err := describeReservation(r, location, l)
 			if err != nil {
-				fmt.Println(err)
+				slog.Error("could not describe a reservation", "reservation", r.OrderResourceRentId, "err", err.Error())
 				return slack.Block{}
 			}
 
-			duration := parsedEnd.Sub(parsedStart).Minutes()
-
-			paidPrice := r.Credits * (float64(duration) / 60)
-
-			dateFormat := "02/01/2006 15:04"
 			list = append(list, slack.BlockElement(slack.NewMenuItem(
-				fmt.Sprintf(
-					"*%s*\n%s → %s · %.02f crédits",
-					r.ItemName,
-					parsedStart.Format(dateFormat),
-					parsedEnd.Format(dateFormat),
-					paidPrice,
-				),
-				"Sélectionner",
+				text,
+				l.T("slack.select"),
 				r.OrderResourceRentId,
 			)))
 		}
 
 		if r.PickedReservation != nil {
+			price := r.PickedReservation.Credits
+			resize := []slack.ChoicePayload{
+				{Text: l.T("slack.reservations.extend", 15, price/4), Value: resizePrefix + "15"},
+				{Text: l.T("slack.reservations.extend", 30, price/2), Value: resizePrefix + "30"},
+				{Text: l.T("slack.reservations.shorten", 15, price/4), Value: resizePrefix + "-15"},
+			}
+
+			list = append(
+				list,
+				slack.BlockElement(slack.NewDivider()),
+				slack.BlockElement(slack.NewMrkDwn(l.T("slack.reservations.edit", r.PickedReservation.ItemName))),
+				slack.BlockElement(slack.NewButtons(resize)),
+			)
 
 			if r.BookingStarted {
 				list = append(
 					list,
-					slack.BlockElement(slack.NewContext(":warning: Impossible d'annuler une réservation déjà commencée")),
+					slack.BlockElement(slack.NewContext(l.T("slack.reservations.already_started"))),
 				)
 			} else {
 				list = append(
 					list,
 					slack.BlockElement(slack.NewDivider()),
 					slack.BlockElement(slack.NewMenuItem(
-						fmt.Sprintf(
-							"Confirmer l'annulation de \"%s\" ?",
-							r.PickedReservation.ItemName,
-						),
-						"Annuler réservation",
+						l.T("slack.confirm_cancel", r.PickedReservation.ItemName),
+						l.T("slack.cancel_reservation"),
 						"cancel",
 					)),
 				)
@@ -163,14 +381,16 @@ func RenderReservationsView(r *ReservationView) slack.Block {
 		if len(list) == 0 {
 			list = append(
 				list,
-				slack.BlockElement(slack.NewMrkDwn(":information_source: Vous n'avez pas de réservation à venir.")),
+				slack.BlockElement(slack.NewMrkDwn(l.T("slack.reservations.none"))),
 			)
 		}
 
+		list = append(list, bulkCancelBlocks(r, location, l)...)
+
 		list = append(
 			list,
 			slack.BlockElement(slack.NewDivider()),
-			slack.NewButtons([]slack.ChoicePayload{{Text: "Retour", Value: "back"}}),
+			slack.NewButtons([]slack.ChoicePayload{{Text: l.T("slack.back"), Value: "back"}}),
 		)
 
 		blocks.Blocks = list
@@ -179,14 +399,111 @@ func RenderReservationsView(r *ReservationView) slack.Block {
 	case 1:
 		return slack.Block{
 			Blocks: []slack.BlockElement{
-				slack.NewHeader(":white_check_mark: Annulation réussie réussie !"),
-				slack.NewMrkDwn("Vous pouvez maintenant retourner à l'accueil"),
+				slack.NewHeader(l.T("slack.cancel_success")),
+				slack.NewMrkDwn(l.T("slack.back_to_landing_hint")),
+				slack.NewDivider(),
+				slack.NewButtons([]slack.ChoicePayload{{Text: l.T("slack.back"), Value: "back"}}),
+			},
+		}
+	case 2:
+		title := l.T("slack.reservations.extended")
+		credits := l.T("slack.credits_spent", r.Credits)
+
+		if r.Minutes < 0 {
+			title = l.T("slack.reservations.shortened")
+			credits = l.T("slack.credits_refunded", -r.Credits)
+		}
+
+		return slack.Block{
+			Blocks: []slack.BlockElement{
+				slack.NewHeader(title),
+				slack.NewMrkDwn(credits),
 				slack.NewDivider(),
-				slack.NewButtons([]slack.ChoicePayload{{Text: "Retour", Value: "back"}}),
+				slack.NewButtons([]slack.ChoicePayload{{Text: l.T("slack.back"), Value: "back"}}),
 			},
 		}
+	case 3:
+		location, _ := common.LoadLocalTime()
+		blocks := []slack.BlockElement{
+			slack.NewHeader(l.T("slack.reservations.confirm_several", len(r.SelectedIds))),
+		}
+
+		for _, reservation := range r.SelectedReservations(r.SelectedIds) {
+			text, _, _ := describeReservation(reservation, location, l)
+			blocks = append(blocks, slack.NewMrkDwn(text))
+		}
+
+		return slack.Block{
+			Blocks: append(
+				blocks,
+				slack.NewDivider(),
+				slack.NewButtons([]slack.ChoicePayload{
+					{Text: l.T("slack.reservations.confirm"), Value: "confirm-cancel-selected"},
+					{Text: l.T("slack.back"), Value: "back-to-list"},
+				}),
+			),
+		}
+	case 4:
+		location, _ := common.LoadLocalTime()
+		blocks := []slack.BlockElement{slack.NewHeader(l.T("slack.reservations.cancellations"))}
+
+		for _, result := range r.Results {
+			start, _ := time.ParseInLocation("2006-01-02T15:04:05", result.Reservation.Start, location)
+			line := fmt.Sprintf("*%s* %s", result.Reservation.ItemName, l.DateTime(start))
+
+			if result.Error != nil {
+				blocks = append(blocks, slack.NewMrkDwn(fmt.Sprintf(":x: %s — %s", line, *result.Error)))
+			} else {
+				blocks = append(blocks, slack.NewMrkDwn(":white_check_mark: "+line))
+			}
+		}
+
+		return slack.Block{
+			Blocks: append(
+				blocks,
+				slack.NewDivider(),
+				slack.NewButtons([]slack.ChoicePayload{{Text: l.T("slack.back"), Value: "back"}}),
+			),
+		}
 	default:
 		return slack.Block{}
 	}
 
 }
+
+// describeReservation formats a reservation as "*Room*\nstart → end · cost",
+// and returns its start time.
+func describeReservation(r api.Reservation, location *time.Location, l i18n.Locale) (string, time.Time, error) {
+	parsedStart, err := time.ParseInLocation("2006-01-02T15:04:05", r.Start, location)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	parsedEnd, err := time.ParseInLocation("2006-01-02T15:04:05", r.End, location)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	duration := parsedEnd.Sub(parsedStart).Minutes()
+	paidPrice := r.Credits * (float64(duration) / 60)
+
+	text := fmt.Sprintf(
+		"*%s*\n%s → %s · %s",
+		r.ItemName,
+		l.DateTime(parsedStart),
+		l.DateTime(parsedEnd),
+		l.T("slack.credits", paidPrice),
+	)
+
+	return text, parsedStart, nil
+}
+
+// cancelConfirm asks to confirm the cancellation of r.
+func cancelConfirm(r api.Reservation, l i18n.Locale) *slack.Confirm {
+	return slack.NewConfirm(
+		l.T("slack.cancel_reservation"),
+		l.T("slack.confirm_cancel", r.ItemName),
+		l.T("slack.cancel_reservation"),
+		l.T("slack.back"),
+	)
+}
@@ -1,14 +1,34 @@
 package views
 
 import (
+	"cosoft-cli/internal/i18n"
 	"cosoft-cli/internal/ui/slack"
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
 )
 
 type Action struct {
 	ActionID string          `json:"action_id"`
 	Values   json.RawMessage `json:"values"`
+	// Locale is the language of the user who acted, for the messages set
+	// while updating the view.
+	Locale i18n.Locale `json:"-"`
+	// Log is the logger of the request the action comes from.
+	Log *slog.Logger `json:"-"`
+}
+
+// Logger returns the action's logger, the default one when it has none.
+func (a Action) Logger() *slog.Logger {
+	if a.Log != nil {
+		return a.Log
+	}
+
+	return slog.Default()
 }
 
 type View interface {
@@ -17,26 +37,157 @@ type View interface {
 
 type Cmd interface{}
 
+// Modal holds what is needed to keep a Slack modal in sync with the view
+// displayed in it. Views embedding it are opened in a modal whenever a
+// trigger_id is available.
+type Modal struct {
+	// ViewId is the id of the opened modal, used by views.update.
+	ViewId string
+	// ResponseUrl is the url of the message the modal has been opened from.
+	ResponseUrl string
+	// Inline is set when the modal could not be opened, and the view is
+	// displayed as a regular message instead.
+	Inline bool
+	// FieldErrors maps input block ids to the error displayed below them
+	// when the modal is submitted.
+	FieldErrors map[string]string
+}
+
+// Alert is embedded by the views which display an error above their content.
+type Alert struct {
+	Error *string
+}
+
+// Fail sets the error displayed by the view.
+func (a *Alert) Fail(msg string) {
+	a.Error = &msg
+}
+
+type ModalView interface {
+	View
+	modal() *Modal
+}
+
+func (m *Modal) modal() *Modal {
+	return m
+}
+
+// Invalidate records msg as the error of the input identified by blockId.
+func (m *Modal) Invalidate(blockId, msg string) {
+	if m.FieldErrors == nil {
+		m.FieldErrors = map[string]string{}
+	}
+
+	m.FieldErrors[blockId] = msg
+}
+
+// AsModal returns the modal state of v, if v can be displayed in a modal.
+func AsModal(v View) (*Modal, bool) {
+	mv, ok := v.(ModalView)
+
+	if !ok {
+		return nil, false
+	}
+
+	return mv.modal(), true
+}
+
+// screen is a registered kind of view, see Register.
+type screen struct {
+	name   string
+	new    func() View
+	render func(View, i18n.Locale) slack.Block
+	modal  func(View, i18n.Locale) slack.Modal
+}
+
+var (
+	screens       = map[string]*screen{}
+	screensByType = map[reflect.Type]*screen{}
+)
+
+// Register declares the views of type V, so that they can be stored under
+// name, restored with newView, and rendered by render in a message or by
+// modal in a modal. A nil modal displays the rendered blocks in a plain one.
+// Each view registers itself from its file's init function.
+func Register[V View](
+	name string,
+	newView func() V,
+	render func(V, i18n.Locale) slack.Block,
+	modal func(V, i18n.Locale) slack.Modal,
+) {
+	viewType := reflect.TypeFor[V]()
+
+	if _, ok := screens[name]; ok {
+		panic(fmt.Sprintf("views: %s is registered twice", name))
+	}
+
+	if _, ok := screensByType[viewType]; ok {
+		panic(fmt.Sprintf("views: %s is registered twice", viewType))
+	}
+
+	sc := &screen{
+		name:   name,
+		new:    func() View { return newView() },
+		render: func(v View, l i18n.Locale) slack.Block { return render(v.(V), l) },
+	}
+
+	if modal != nil {
+		sc.modal = func(v View, l i18n.Locale) slack.Modal { return modal(v.(V), l) }
+	} else {
+		sc.modal = func(v View, l i18n.Locale) slack.Modal {
+			return slack.NewModal("Cosoft", l.T("slack.close"), name, render(v.(V), l).Blocks)
+		}
+	}
+
+	screens[name] = sc
+	screensByType[viewType] = sc
+}
+
+// detached are the views which aren't stored, by the prefix of their
+// action ids, see RegisterDetached.
+var detached = map[string]func() View{}
+
+// RegisterDetached declares a view which isn't stored, its state being
+// carried by the ids of its actions, all starting with prefix. newView
+// returns the view handling such an action.
+func RegisterDetached[V View](prefix string, newView func() V) {
+	if _, ok := detached[prefix]; ok {
+		panic(fmt.Sprintf("views: %s is registered twice", prefix))
+	}
+
+	detached[prefix] = func() View { return newView() }
+}
+
+// Detached returns the view handling actionId when it belongs to a view
+// which isn't stored, see RegisterDetached.
+func Detached(actionId string) (View, bool) {
+	for prefix, newView := range detached {
+		if strings.HasPrefix(actionId, prefix) {
+			return newView(), true
+		}
+	}
+
+	return nil, false
+}
+
+// Types returns the names of the registered views, sorted.
+func Types() []string {
+	return slices.Sorted(maps.Keys(screens))
+}
+
+func screenOf(v View) (*screen, bool) {
+	sc, ok := screensByType[reflect.TypeOf(v)]
+	return sc, ok
+}
+
 func RestoreView(messageType string, payload []byte) (View, error) {
-	var view View
-
-	switch messageType {
-	case "login":
-		view = &LoginView{}
-	case "landing":
-		view = &LandingView{}
-	case "quick-book":
-		view = &QuickBookView{}
-	case "browse":
-		view = &BrowseView{}
-	case "reservations":
-		view = &ReservationView{}
-	case "calendar":
-		view = NewCalendarView()
-	default:
+	sc, ok := screens[messageType]
+
+	if !ok {
 		return nil, fmt.Errorf("unknown view type: %s", messageType)
 	}
 
+	view := sc.new()
 	err := json.Unmarshal(payload, view)
 
 	if err != nil {
@@ -46,40 +197,56 @@ func RestoreView(messageType string, payload []byte) (View, error) {
 	return view, nil
 }
 
+// TypeOf returns the name v is registered under, and whether it is.
+func TypeOf(v View) (string, bool) {
+	sc, ok := screenOf(v)
+
+	if !ok {
+		return "", false
+	}
+
+	return sc.name, true
+}
+
 func ViewType(v View) string {
-	switch v.(type) {
-	case *LoginView:
-		return "login"
-	case *LandingView:
-		return "landing"
-	case *QuickBookView:
-		return "quick-book"
-	case *BrowseView:
-		return "browse"
-	case *ReservationView:
-		return "reservations"
-	case *CalendarView:
-		return "calendar"
-	default:
-		return "unknown"
-	}
-}
-
-func RenderView(v View) slack.Block {
-	switch v := v.(type) {
-	case *LoginView:
-		return RenderLoginView(v)
-	case *LandingView:
-		return RenderLandingView(v)
-	case *QuickBookView:
-		return RenderQuickBookView(v)
-	case *BrowseView:
-		return RenderBrowseView(v)
-	case *ReservationView:
-		return RenderReservationsView(v)
-	case *CalendarView:
-		return RenderCalendarView(v)
-	default:
-		return slack.Block{}
+	if name, ok := TypeOf(v); ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+func RenderView(v View, l i18n.Locale) slack.Block {
+	if sc, ok := screenOf(v); ok {
+		return sc.render(v, l)
+	}
+
+	return slack.Block{}
+}
+
+func RenderModal(v View, l i18n.Locale) slack.Modal {
+	if sc, ok := screenOf(v); ok {
+		return sc.modal(v, l)
 	}
+
+	return slack.NewModal("Cosoft", l.T("slack.close"), ViewType(v), nil)
+}
+
+// RenderBusyModal replaces a submitted modal which couldn't be handled in
+// time, because the user's previous action is still running.
+func RenderBusyModal(l i18n.Locale) slack.Modal {
+	return slack.NewModal(l.T("slack.busy.title"), l.T("slack.close"), "busy", []slack.BlockElement{
+		slack.NewMrkDwn(l.T("slack.busy.text")),
+	})
+}
+
+// RenderLoadingModal is displayed while a submitted modal waits for Cosoft.
+func RenderLoadingModal(v View, l i18n.Locale) slack.Modal {
+	modal := RenderModal(v, l)
+	modal.Submit = nil
+	modal.Blocks = []slack.BlockElement{
+		slack.NewMrkDwn(l.T("slack.loading")),
+	}
+
+	return modal
 }
@@ -0,0 +1,73 @@
+package views
+
+import (
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/ui/slack"
+	"slices"
+	"testing"
+)
+
+type testView struct {
+	Alert
+	Count int
+}
+
+func (v *testView) Update(action Action) (View, Cmd) {
+	v.Count++
+	return v, nil
+}
+
+func renderTestView(v *testView, l i18n.Locale) slack.Block {
+	return slack.Block{Blocks: []slack.BlockElement{slack.NewMrkDwn("test")}}
+}
+
+func init() {
+	Register("test", func() *testView { return &testView{} }, renderTestView, nil)
+}
+
+func TestRegister(t *testing.T) {
+	want := []string{"browse", "calendar", "landing", "login", "quick-book", "reservations", "test"}
+	if got := Types(); !slices.Equal(got, want) {
+		t.Errorf("Types() = %v, want %v", got, want)
+	}
+
+	view, err := RestoreView("test", []byte(`{"Count": 2, "Error": "oops"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored, ok := view.(*testView)
+	if !ok || restored.Count != 2 || restored.Error == nil || *restored.Error != "oops" {
+		t.Fatalf("RestoreView() = %+v, want the stored test view", view)
+	}
+
+	if got := ViewType(restored); got != "test" {
+		t.Errorf("ViewType() = %q, want test", got)
+	}
+	if got := len(RenderView(restored, i18n.English).Blocks); got != 1 {
+		t.Errorf("RenderView() has %d blocks, want 1", got)
+	}
+	if modal := RenderModal(restored, i18n.English); modal.CallbackId != "test" || len(modal.Blocks) != 1 {
+		t.Errorf("RenderModal() = %+v, want the rendered blocks in a plain modal", modal)
+	}
+	if modal := RenderModal(restored, i18n.French); modal.Close == nil || modal.Close.Text != "Fermer" {
+		t.Errorf("RenderModal().Close = %+v, want the close button in French", modal.Close)
+	}
+	if modal := RenderModal(restored, i18n.English); modal.Close == nil || modal.Close.Text != "Close" {
+		t.Errorf("RenderModal().Close = %+v, want the close button in English", modal.Close)
+	}
+
+	if _, err := RestoreView("unknown", []byte(`{}`)); err == nil {
+		t.Error("RestoreView() of an unknown type should fail")
+	}
+	if got := ViewType(&HomeView{}); got != "unknown" {
+		t.Errorf("ViewType() of an unregistered view = %q, want unknown", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a name twice should panic")
+		}
+	}()
+	Register("test", func() *LandingView { return &LandingView{} }, RenderLandingView, nil)
+}
@@ -1,12 +1,18 @@
 package storage
 
 import (
+	"context"
 	"cosoft-cli/internal/api"
 	"cosoft-cli/shared/models"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	_ "modernc.org/sqlite"
@@ -35,6 +41,11 @@ func (s *Store) Close() error {
 	return s.db.Close()
 }
 
+// Ping checks the database can be reached.
+func (s *Store) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
 func (s *Store) SetupDatabase() error {
 
 	query := `
@@ -47,6 +58,8 @@ func (s *Store) SetupDatabase() error {
 			w_auth TEXT NOT NULL,
 			w_auth_refresh TEXT NOT NULL,
 			slack_user_id VARCHAR(50),
+			reminder_lead_time INTEGER NOT NULL DEFAULT 10,
+			locale VARCHAR(10) NOT NULL DEFAULT '',
 			created_at DATE NOT NULL
 		);
 
@@ -55,16 +68,67 @@ func (s *Store) SetupDatabase() error {
 			name VARCHAR(50) NOT NULL,
 			nb_users TINYINT NOT NULL DEFAULT 0,
 			price REAL NOT NULL DEFAULT 0,
-			created_at DATE NOT NULL
+			image TEXT NOT NULL DEFAULT '',
+			description TEXT NOT NULL DEFAULT '',
+			floor VARCHAR(20) NOT NULL DEFAULT '',
+			equipment TEXT NOT NULL DEFAULT '',
+			created_at DATE NOT NULL,
+			updated_at DATE,
+			deleted_at DATE
 		);
 
 		CREATE TABLE IF NOT EXISTS slack_messages (
 		    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
 		    slack_user_id VARCHAR(50) UNIQUE NOT NULL,
 		    payload BLOB NOT NULL,
-		    message_type TEXT CHECK (
-		        message_type IN ('landing', 'quick-book', 'browse', 'login', 'reservations', 'calendar')
-		    ) NOT NULL,
+		    message_type TEXT NOT NULL,
+		    created_at DATE NOT NULL,
+		    updated_at DATE,
+		    version INTEGER NOT NULL DEFAULT 0
+		);
+
+		CREATE TABLE IF NOT EXISTS reminders (
+		    reservation_id VARCHAR(40) PRIMARY KEY NOT NULL,
+		    slack_user_id VARCHAR(50),
+		    created_at DATE NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS announcements (
+		    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+		    slack_user_id VARCHAR(50) NOT NULL,
+		    channel_id VARCHAR(50) NOT NULL DEFAULT '',
+		    reservation_id VARCHAR(40) NOT NULL DEFAULT '',
+		    room_name VARCHAR(50) NOT NULL,
+		    room_image TEXT NOT NULL DEFAULT '',
+		    starts_at DATE NOT NULL,
+		    ends_at DATE NOT NULL,
+		    team BOOLEAN NOT NULL DEFAULT 0,
+		    response_url TEXT NOT NULL DEFAULT '',
+		    created_at DATE NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS announcement_attendees (
+		    announcement_id INTEGER NOT NULL REFERENCES announcements (id) ON DELETE CASCADE,
+		    slack_user_id VARCHAR(50) NOT NULL,
+		    created_at DATE NOT NULL,
+		    PRIMARY KEY (announcement_id, slack_user_id)
+		);
+
+		CREATE TABLE IF NOT EXISTS delegations (
+		    owner_slack_user_id VARCHAR(50) NOT NULL,
+		    delegate_slack_user_id VARCHAR(50) NOT NULL,
+		    created_at DATE NOT NULL,
+		    PRIMARY KEY (owner_slack_user_id, delegate_slack_user_id)
+		);
+
+		CREATE TABLE IF NOT EXISTS delegated_bookings (
+		    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+		    owner_slack_user_id VARCHAR(50) NOT NULL,
+		    delegate_slack_user_id VARCHAR(50) NOT NULL,
+		    room_name VARCHAR(50) NOT NULL,
+		    starts_at DATE NOT NULL,
+		    ends_at DATE NOT NULL,
+		    cost REAL NOT NULL DEFAULT 0,
 		    created_at DATE NOT NULL
 		)
 	`
@@ -75,9 +139,252 @@ func (s *Store) SetupDatabase() error {
 		return err
 	}
 
+	return s.migrate()
+}
+
+// migrate brings databases created by previous versions up to date, since
+// CREATE TABLE IF NOT EXISTS leaves existing tables untouched.
+func (s *Store) migrate() error {
+	err := s.addColumnIfMissing("users", "reminder_lead_time", "INTEGER NOT NULL DEFAULT 10")
+
+	if err != nil {
+		return err
+	}
+
+	err = s.addColumnIfMissing("users", "locale", "VARCHAR(10) NOT NULL DEFAULT ''")
+
+	if err != nil {
+		return err
+	}
+
+	err = s.addColumnIfMissing("slack_messages", "updated_at", "DATE")
+
+	if err != nil {
+		return err
+	}
+
+	err = s.addColumnIfMissing("rooms", "updated_at", "DATE")
+
+	if err != nil {
+		return err
+	}
+
+	err = s.addColumnIfMissing("rooms", "deleted_at", "DATE")
+
+	if err != nil {
+		return err
+	}
+
+	for _, column := range []string{"image", "description", "floor", "equipment"} {
+		err = s.addColumnIfMissing("rooms", column, "TEXT NOT NULL DEFAULT ''")
+
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.dropMessageTypeCheck()
+
+	if err != nil {
+		return err
+	}
+
+	err = s.addColumnIfMissing("slack_messages", "version", "INTEGER NOT NULL DEFAULT 0")
+
+	if err != nil {
+		return err
+	}
+
+	return s.scrubSlackCredentials()
+}
+
+// dropMessageTypeCheck removes the CHECK constraint previous versions put on
+// slack_messages.message_type: the views are now registered by the bot, which
+// validates their type itself. SQLite can't drop a constraint, so the table
+// is rebuilt.
+func (s *Store) dropMessageTypeCheck() error {
+	var schema string
+
+	err := s.db.QueryRow(`SELECT sql FROM sqlite_master WHERE type = 'table' AND name = 'slack_messages'`).Scan(&schema)
+
+	if err != nil {
+		return err
+	}
+
+	if !strings.Contains(schema, "CHECK") {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		CREATE TABLE slack_messages_new (
+		    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+		    slack_user_id VARCHAR(50) UNIQUE NOT NULL,
+		    payload BLOB NOT NULL,
+		    message_type TEXT NOT NULL,
+		    created_at DATE NOT NULL,
+		    updated_at DATE
+		);
+
+		INSERT INTO slack_messages_new (id, slack_user_id, payload, message_type, created_at, updated_at)
+		SELECT id, slack_user_id, payload, message_type, created_at, updated_at FROM slack_messages;
+
+		DROP TABLE slack_messages;
+
+		ALTER TABLE slack_messages_new RENAME TO slack_messages;
+	`)
+
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// credentialFields were stored along with the Slack views by previous
+// versions of the bot: the login form's values at the top level, and the
+// user's tokens wherever a user was embedded.
+var (
+	loginFields = []string{"Email", "Password"}
+	tokenFields = []string{"WAuth", "WAuthRefresh"}
+)
+
+// scrubSlackCredentials removes the credentials stored in slack_messages by
+// previous versions of the bot.
+func (s *Store) scrubSlackCredentials() error {
+	rows, err := s.db.Query(`SELECT id, payload FROM slack_messages`)
+
+	if err != nil {
+		return err
+	}
+
+	scrubbed := map[int][]byte{}
+
+	for rows.Next() {
+		var (
+			id      int
+			payload []byte
+		)
+
+		if err := rows.Scan(&id, &payload); err != nil {
+			rows.Close()
+			return err
+		}
+
+		var state map[string]any
+
+		// Not a JSON object, hence not a view holding credentials.
+		if json.Unmarshal(payload, &state) != nil {
+			continue
+		}
+
+		found := removeFields(state, loginFields, false)
+
+		if !removeFields(state, tokenFields, true) && !found {
+			continue
+		}
+
+		clean, err := json.Marshal(state)
+
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		scrubbed[id] = clean
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, payload := range scrubbed {
+		_, err := s.db.Exec(`UPDATE slack_messages SET payload = ? WHERE id = ?`, payload, id)
+
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// removeFields deletes fields from value, and from the objects it contains
+// when recursive. It reports whether anything was deleted.
+func removeFields(value any, fields []string, recursive bool) bool {
+	found := false
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, field := range fields {
+			if _, ok := v[field]; ok {
+				delete(v, field)
+				found = true
+			}
+		}
+
+		if recursive {
+			for _, child := range v {
+				found = removeFields(child, fields, true) || found
+			}
+		}
+	case []any:
+		if recursive {
+			for _, child := range v {
+				found = removeFields(child, fields, true) || found
+			}
+		}
+	}
+
+	return found
+}
+
+func (s *Store) addColumnIfMissing(table, column, definition string) error {
+	rows, err := s.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return err
+		}
+
+		if name == column {
+			return nil
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+
+	return err
+}
+
 func (s *Store) HasActiveToken(slackUserID *string) (*Cookies, error) {
 	var query string
 	// Using []interface{} to avoid duplicating the QueryRow().Scan() call in each branch
@@ -168,10 +475,10 @@ func (s *Store) GetUserData(slackUserID *string) (*User, error) {
 	var args []interface{}
 
 	if slackUserID != nil {
-		query = `SELECT id, first_name, last_name, email, w_auth, w_auth_refresh, credits, slack_user_id, created_at FROM users WHERE slack_user_id = ?;`
+		query = `SELECT id, first_name, last_name, email, w_auth, w_auth_refresh, credits, slack_user_id, reminder_lead_time, locale, created_at FROM users WHERE slack_user_id = ?;`
 		args = append(args, *slackUserID)
 	} else {
-		query = `SELECT id, first_name, last_name, email, w_auth, w_auth_refresh, credits, slack_user_id, created_at FROM users LIMIT 1;`
+		query = `SELECT id, first_name, last_name, email, w_auth, w_auth_refresh, credits, slack_user_id, reminder_lead_time, locale, created_at FROM users LIMIT 1;`
 	}
 
 	err := s.db.QueryRow(query, args...).Scan(
@@ -183,6 +490,8 @@ func (s *Store) GetUserData(slackUserID *string) (*User, error) {
 		&user.WAuthRefresh,
 		&user.Credits,
 		&user.SlackUserID,
+		&user.ReminderLeadTime,
+		&user.Locale,
 		&user.CreatedAt,
 	)
 
@@ -265,9 +574,14 @@ func (s *Store) UpdateCredits(slackUserID *string) (*float64, error) {
 	return &newCredits, nil
 }
 
+// GetRooms returns the rooms Cosoft still has, without the deleted ones.
 func (s *Store) GetRooms() ([]Room, error) {
 	var rooms []Room
-	query := `SELECT * FROM rooms;`
+	query := `
+		SELECT id, name, nb_users, price, image, description, floor, equipment, created_at, updated_at
+		FROM rooms
+		WHERE deleted_at IS NULL;
+	`
 
 	rows, err := s.db.Query(query)
 
@@ -279,39 +593,55 @@ func (s *Store) GetRooms() ([]Room, error) {
 
 	for rows.Next() {
 		var room Room
-		if err := rows.Scan(&room.Id, &room.Name, &room.MaxUsers, &room.Price, &room.CreatedAt); err != nil {
+		var equipment string
+		var updatedAt sql.NullTime
+		if err := rows.Scan(
+			&room.Id,
+			&room.Name,
+			&room.MaxUsers,
+			&room.Price,
+			&room.Image,
+			&room.Description,
+			&room.Floor,
+			&equipment,
+			&room.CreatedAt,
+			&updatedAt,
+		); err != nil {
 			return nil, err
 		}
 
+		room.Equipment = decodeEquipment(equipment)
+		room.UpdatedAt = updatedAt.Time
 		rooms = append(rooms, room)
 	}
 
 	return rooms, nil
 }
 
-func (s *Store) CreateRooms(rooms []models.Room) error {
-	query := `INSERT INTO rooms (id, name, nb_users, price, created_at) VALUES (?, ?, ?, ?, ?)`
-	for _, room := range rooms {
-		_, err := s.db.Exec(query, room.Id, room.Name, room.NbUsers, room.Price, time.Now())
-
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
+// GetRoomByName returns the room called name. Deleted rooms are found too,
+// as reservations may still be made in them, but the current ones win.
 func (s *Store) GetRoomByName(name string) (*models.Room, error) {
 	var room models.Room
 
-	query := `SELECT id, name, nb_users, price FROM rooms WHERE name = ? LIMIT 1;`
+	var equipment string
+
+	query := `
+		SELECT id, name, nb_users, price, image, description, floor, equipment
+		FROM rooms
+		WHERE name = ?
+		ORDER BY deleted_at IS NOT NULL
+		LIMIT 1;
+	`
 
 	err := s.db.QueryRow(query, name).Scan(
 		&room.Id,
 		&room.Name,
 		&room.NbUsers,
 		&room.Price,
+		&room.Image,
+		&room.Description,
+		&room.Floor,
+		&equipment,
 	)
 
 	if err != nil {
@@ -321,8 +651,21 @@ func (s *Store) GetRoomByName(name string) (*models.Room, error) {
 		return nil, err
 	}
 
+	room.Equipment = decodeEquipment(equipment)
+
 	return &room, nil
+}
+
+// decodeEquipment reads the equipment column, a JSON list, empty when the
+// room was stored before it existed.
+func decodeEquipment(column string) []string {
+	var equipment []string
 
+	if column != "" {
+		_ = json.Unmarshal([]byte(column), &equipment)
+	}
+
+	return equipment
 }
 
 func (s *Store) SetSlackState(slackUserId, messageType string, state any) error {
@@ -333,18 +676,22 @@ func (s *Store) SetSlackState(slackUserId, messageType string, state any) error
 	}
 
 	query := `
-		INSERT INTO slack_messages (id, slack_user_id, message_type, payload, created_at)
-		VALUES (NULL, ?, ?, ?, ?)
+		INSERT INTO slack_messages (id, slack_user_id, message_type, payload, created_at, updated_at, version)
+		VALUES (NULL, ?, ?, ?, ?, ?, 1)
 		ON CONFLICT (slack_user_id) DO UPDATE SET
    			payload = EXCLUDED.payload,
-   			message_type = EXCLUDED.message_type		
+   			message_type = EXCLUDED.message_type,
+   			updated_at = EXCLUDED.updated_at,
+   			version = slack_messages.version + 1
 	`
+	now := time.Now()
 	_, err = s.db.Exec(
 		query,
 		slackUserId,
 		messageType,
 		stateJson,
-		time.Now(),
+		now,
+		now,
 	)
 
 	return err
@@ -352,12 +699,16 @@ func (s *Store) SetSlackState(slackUserId, messageType string, state any) error
 
 func (s *Store) GetSlackState(slackUserId string) (*SlackState, error) {
 	var state SlackState
+	var updatedAt sql.NullTime
 
-	query := `SELECT message_type, payload FROM slack_messages WHERE slack_user_id = ?`
+	query := `SELECT message_type, payload, created_at, updated_at, version FROM slack_messages WHERE slack_user_id = ?`
 
 	err := s.db.QueryRow(query, slackUserId).Scan(
 		&state.MessageType,
 		&state.Payload,
+		&state.UpdatedAt,
+		&updatedAt,
+		&state.Version,
 	)
 
 	if err != nil {
@@ -368,6 +719,11 @@ func (s *Store) GetSlackState(slackUserId string) (*SlackState, error) {
 		return nil, err
 	}
 
+	// Views stored by previous versions were never updated since.
+	if updatedAt.Valid {
+		state.UpdatedAt = updatedAt.Time
+	}
+
 	return &state, nil
 }
 
@@ -378,3 +734,672 @@ func (s *Store) ResetUserSlackState(slackUserID string) error {
 
 	return err
 }
+
+// GetSlackUsersWithReminders returns the Slack users who haven't disabled
+// their reminders.
+func (s *Store) GetSlackUsersWithReminders() ([]User, error) {
+	return s.getSlackUsers(`reminder_lead_time > 0`)
+}
+
+// GetSlackUsers returns every user linked to a Slack account, sorted by
+// name.
+func (s *Store) GetSlackUsers() ([]User, error) {
+	return s.getSlackUsers(`1`)
+}
+
+func (s *Store) getSlackUsers(condition string) ([]User, error) {
+	var users []User
+
+	query := `
+		SELECT id, first_name, last_name, email, w_auth, w_auth_refresh, credits, slack_user_id, reminder_lead_time, locale, created_at
+		FROM users
+		WHERE slack_user_id IS NOT NULL AND ` + condition + `
+		ORDER BY first_name, last_name
+	`
+
+	rows, err := s.db.Query(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var user User
+		err := rows.Scan(
+			&user.Id,
+			&user.FirstName,
+			&user.LastName,
+			&user.Email,
+			&user.WAuth,
+			&user.WAuthRefresh,
+			&user.Credits,
+			&user.SlackUserID,
+			&user.ReminderLeadTime,
+			&user.Locale,
+			&user.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// SetReminderLeadTime sets how many minutes before a reservation starts its
+// reminder is sent. 0 disables reminders.
+func (s *Store) SetReminderLeadTime(slackUserID *string, minutes int) error {
+	var query string
+	args := []interface{}{minutes}
+
+	if slackUserID != nil {
+		query = `UPDATE users SET reminder_lead_time = ? WHERE slack_user_id = ?`
+		args = append(args, *slackUserID)
+	} else {
+		query = `UPDATE users SET reminder_lead_time = ?`
+	}
+
+	_, err := s.db.Exec(query, args...)
+
+	return err
+}
+
+// SetLocale sets the language of the messages, as an i18n.Locale. An empty
+// locale follows the environment, or the Slack user's language.
+func (s *Store) SetLocale(slackUserID *string, locale string) error {
+	var query string
+	args := []interface{}{locale}
+
+	if slackUserID != nil {
+		query = `UPDATE users SET locale = ? WHERE slack_user_id = ?`
+		args = append(args, *slackUserID)
+	} else {
+		query = `UPDATE users SET locale = ?`
+	}
+
+	_, err := s.db.Exec(query, args...)
+
+	return err
+}
+
+func (s *Store) HasSentReminder(reservationId string) (bool, error) {
+	var count int
+
+	query := `SELECT COUNT(*) FROM reminders WHERE reservation_id = ?`
+
+	err := s.db.QueryRow(query, reservationId).Scan(&count)
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (s *Store) SetReminderSent(reservationId string, slackUserID *string) error {
+	query := `
+		INSERT INTO reminders (reservation_id, slack_user_id, created_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (reservation_id) DO NOTHING
+	`
+
+	_, err := s.db.Exec(query, reservationId, slackUserID, time.Now())
+
+	return err
+}
+
+// PurgeReminders forgets the reminders sent before the given time, returning
+// how many were.
+func (s *Store) PurgeReminders(before time.Time) (int64, error) {
+	query := `DELETE FROM reminders WHERE created_at < ?`
+
+	result, err := s.db.Exec(query, before)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// CreateAnnouncement stores a, setting its id.
+func (s *Store) CreateAnnouncement(a *Announcement) error {
+	query := `
+		INSERT INTO announcements (slack_user_id, channel_id, reservation_id, room_name, room_image, starts_at, ends_at, team, response_url, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	a.CreatedAt = time.Now()
+
+	result, err := s.db.Exec(
+		query,
+		a.SlackUserId,
+		a.ChannelId,
+		a.ReservationId,
+		a.RoomName,
+		a.RoomImage,
+		a.Start,
+		a.End,
+		a.Team,
+		a.ResponseUrl,
+		a.CreatedAt,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	a.Id, err = result.LastInsertId()
+
+	return err
+}
+
+const announcementColumns = `id, slack_user_id, channel_id, reservation_id, room_name, room_image, starts_at, ends_at, team, response_url, created_at`
+
+func scanAnnouncement(row interface{ Scan(...any) error }) (*Announcement, error) {
+	var a Announcement
+
+	err := row.Scan(
+		&a.Id,
+		&a.SlackUserId,
+		&a.ChannelId,
+		&a.ReservationId,
+		&a.RoomName,
+		&a.RoomImage,
+		&a.Start,
+		&a.End,
+		&a.Team,
+		&a.ResponseUrl,
+		&a.CreatedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// GetAnnouncement returns the announcement identified by id, nil if there is
+// none.
+func (s *Store) GetAnnouncement(id int64) (*Announcement, error) {
+	query := `SELECT ` + announcementColumns + ` FROM announcements WHERE id = ?`
+
+	a, err := scanAnnouncement(s.db.QueryRow(query, id))
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return a, err
+}
+
+// GetTeamAnnouncements returns the team bookings made for reservationId.
+func (s *Store) GetTeamAnnouncements(reservationId string) ([]Announcement, error) {
+	query := `SELECT ` + announcementColumns + ` FROM announcements WHERE reservation_id = ? AND team`
+
+	rows, err := s.db.Query(query, reservationId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var announcements []Announcement
+
+	for rows.Next() {
+		a, err := scanAnnouncement(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		announcements = append(announcements, *a)
+	}
+
+	return announcements, rows.Err()
+}
+
+// SetAttendance records whether the Slack user attends the announced
+// meeting.
+func (s *Store) SetAttendance(announcementId int64, slackUserId string, attending bool) error {
+	query := `DELETE FROM announcement_attendees WHERE announcement_id = ? AND slack_user_id = ?`
+	args := []any{announcementId, slackUserId}
+
+	if attending {
+		query = `
+			INSERT INTO announcement_attendees (announcement_id, slack_user_id, created_at)
+			VALUES (?, ?, ?)
+			ON CONFLICT (announcement_id, slack_user_id) DO NOTHING
+		`
+		args = append(args, time.Now())
+	}
+
+	_, err := s.db.Exec(query, args...)
+
+	return err
+}
+
+// GetAttendees returns the Slack users attending the announced meeting, in
+// the order they joined.
+func (s *Store) GetAttendees(announcementId int64) ([]string, error) {
+	query := `SELECT slack_user_id FROM announcement_attendees WHERE announcement_id = ? ORDER BY created_at, slack_user_id`
+
+	rows, err := s.db.Query(query, announcementId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var attendees []string
+
+	for rows.Next() {
+		var slackUserId string
+
+		if err := rows.Scan(&slackUserId); err != nil {
+			return nil, err
+		}
+
+		attendees = append(attendees, slackUserId)
+	}
+
+	return attendees, rows.Err()
+}
+
+// Delegate allows the delegate to book rooms with the owner's account.
+func (s *Store) Delegate(ownerSlackUserId, delegateSlackUserId string) error {
+	query := `
+		INSERT INTO delegations (owner_slack_user_id, delegate_slack_user_id, created_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (owner_slack_user_id, delegate_slack_user_id) DO NOTHING
+	`
+
+	_, err := s.db.Exec(query, ownerSlackUserId, delegateSlackUserId, time.Now())
+
+	return err
+}
+
+// Revoke withdraws a delegation, telling whether there was one.
+func (s *Store) Revoke(ownerSlackUserId, delegateSlackUserId string) (bool, error) {
+	query := `DELETE FROM delegations WHERE owner_slack_user_id = ? AND delegate_slack_user_id = ?`
+
+	result, err := s.db.Exec(query, ownerSlackUserId, delegateSlackUserId)
+
+	if err != nil {
+		return false, err
+	}
+
+	deleted, err := result.RowsAffected()
+
+	return deleted > 0, err
+}
+
+// IsDelegate tells whether the delegate may book with the owner's account.
+func (s *Store) IsDelegate(ownerSlackUserId, delegateSlackUserId string) (bool, error) {
+	query := `SELECT COUNT(*) FROM delegations WHERE owner_slack_user_id = ? AND delegate_slack_user_id = ?`
+
+	var count int
+	err := s.db.QueryRow(query, ownerSlackUserId, delegateSlackUserId).Scan(&count)
+
+	return count > 0, err
+}
+
+// GetDelegates returns the Slack users allowed to book with the owner's
+// account, in the order they were allowed.
+func (s *Store) GetDelegates(ownerSlackUserId string) ([]string, error) {
+	query := `SELECT delegate_slack_user_id FROM delegations WHERE owner_slack_user_id = ? ORDER BY created_at, delegate_slack_user_id`
+
+	rows, err := s.db.Query(query, ownerSlackUserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var delegates []string
+
+	for rows.Next() {
+		var slackUserId string
+
+		if err := rows.Scan(&slackUserId); err != nil {
+			return nil, err
+		}
+
+		delegates = append(delegates, slackUserId)
+	}
+
+	return delegates, rows.Err()
+}
+
+// GetPrincipals returns the logged in users whose account the delegate may
+// book with, sorted by name.
+func (s *Store) GetPrincipals(delegateSlackUserId string) ([]User, error) {
+	query := `
+		SELECT u.id, u.first_name, u.last_name, u.email, u.credits, u.slack_user_id
+		FROM delegations d
+		JOIN users u ON u.slack_user_id = d.owner_slack_user_id
+		WHERE d.delegate_slack_user_id = ?
+		ORDER BY u.first_name, u.last_name
+	`
+
+	rows, err := s.db.Query(query, delegateSlackUserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var principals []User
+
+	for rows.Next() {
+		var user User
+
+		err := rows.Scan(&user.Id, &user.FirstName, &user.LastName, &user.Email, &user.Credits, &user.SlackUserID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		principals = append(principals, user)
+	}
+
+	return principals, rows.Err()
+}
+
+// RecordDelegatedBooking adds b to the audit trail of delegated bookings.
+func (s *Store) RecordDelegatedBooking(b *DelegatedBooking) error {
+	query := `
+		INSERT INTO delegated_bookings (owner_slack_user_id, delegate_slack_user_id, room_name, starts_at, ends_at, cost, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	b.CreatedAt = time.Now()
+
+	result, err := s.db.Exec(
+		query,
+		b.OwnerSlackUserId,
+		b.DelegateSlackUserId,
+		b.RoomName,
+		b.Start,
+		b.End,
+		b.Cost,
+		b.CreatedAt,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	b.Id, err = result.LastInsertId()
+
+	return err
+}
+
+// GetDelegatedBookings returns the last bookings made with or for the Slack
+// user's account on someone's behalf, newest first.
+func (s *Store) GetDelegatedBookings(slackUserId string, limit int) ([]DelegatedBooking, error) {
+	query := `
+		SELECT id, owner_slack_user_id, delegate_slack_user_id, room_name, starts_at, ends_at, cost, created_at
+		FROM delegated_bookings
+		WHERE owner_slack_user_id = ? OR delegate_slack_user_id = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`
+
+	rows, err := s.db.Query(query, slackUserId, slackUserId, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var bookings []DelegatedBooking
+
+	for rows.Next() {
+		var b DelegatedBooking
+
+		err := rows.Scan(
+			&b.Id,
+			&b.OwnerSlackUserId,
+			&b.DelegateSlackUserId,
+			&b.RoomName,
+			&b.Start,
+			&b.End,
+			&b.Cost,
+			&b.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		bookings = append(bookings, b)
+	}
+
+	return bookings, rows.Err()
+}
+
+// PurgeSlackStates deletes the views last displayed before the given time,
+// returning how many were.
+func (s *Store) PurgeSlackStates(before time.Time) (int64, error) {
+	query := `DELETE FROM slack_messages WHERE COALESCE(updated_at, created_at) < ?`
+
+	result, err := s.db.Exec(query, before)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// SyncRooms updates the stored rooms to match rooms, as fetched from Cosoft,
+// at once: new rooms are added, changed ones updated, and those Cosoft no
+// longer has are marked deleted, as reservations may still be made in them.
+// It returns what changed.
+func (s *Store) SyncRooms(rooms []models.Room) ([]RoomChange, error) {
+	if len(rooms) == 0 {
+		return nil, errors.New("no room to store, Cosoft returned none")
+	}
+
+	tx, err := s.db.Begin()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`SELECT id, name, price, deleted_at IS NOT NULL FROM rooms`)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []Room
+	deleted := map[string]bool{}
+
+	for rows.Next() {
+		var room Room
+		var isDeleted bool
+
+		if err := rows.Scan(&room.Id, &room.Name, &room.Price, &isDeleted); err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		stored = append(stored, room)
+		deleted[room.Id] = isDeleted
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var changes []RoomChange
+	fetched := map[string]bool{}
+	now := time.Now()
+
+	query := `
+		INSERT INTO rooms (id, name, nb_users, price, image, description, floor, equipment, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			nb_users = EXCLUDED.nb_users,
+			price = EXCLUDED.price,
+			image = EXCLUDED.image,
+			description = EXCLUDED.description,
+			floor = EXCLUDED.floor,
+			equipment = EXCLUDED.equipment,
+			updated_at = EXCLUDED.updated_at,
+			deleted_at = NULL
+	`
+
+	for _, room := range rooms {
+		fetched[room.Id] = true
+		i := slices.IndexFunc(stored, func(r Room) bool { return r.Id == room.Id })
+
+		switch {
+		case i < 0 || deleted[room.Id]:
+			changes = append(changes, RoomChange{Kind: RoomAdded, Id: room.Id, Name: room.Name, Price: room.Price})
+		default:
+			old := stored[i]
+
+			if old.Name != room.Name {
+				changes = append(changes, RoomChange{Kind: RoomRenamed, Id: room.Id, Name: room.Name, OldName: old.Name, Price: room.Price})
+			}
+
+			if old.Price != room.Price {
+				changes = append(changes, RoomChange{Kind: RoomRepriced, Id: room.Id, Name: room.Name, OldName: old.Name, Price: room.Price, OldPrice: old.Price})
+			}
+		}
+
+		equipment, err := json.Marshal(room.Equipment)
+
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tx.Exec(query, room.Id, room.Name, room.NbUsers, room.Price, room.Image, room.Description, room.Floor, string(equipment), now, now)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, room := range stored {
+		if fetched[room.Id] || deleted[room.Id] {
+			continue
+		}
+
+		_, err := tx.Exec(`UPDATE rooms SET deleted_at = ? WHERE id = ?`, now, room.Id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, RoomChange{Kind: RoomRemoved, Id: room.Id, Name: room.Name, OldName: room.Name, OldPrice: room.Price})
+	}
+
+	return changes, tx.Commit()
+}
+
+// Export writes a consistent copy of the database to path, which must not
+// exist.
+func (s *Store) Export(path string) error {
+	_, err := s.db.Exec(`VACUUM INTO ?`, path)
+
+	return err
+}
+
+// Import replaces the database at dbPath with the one at path, once checked
+// and migrated. Nothing else may use the database meanwhile.
+func Import(path, dbPath string) error {
+	source, err := os.Open(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer source.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dbPath), filepath.Base(dbPath)+".import-*")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, source)
+
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+
+	if err != nil {
+		return err
+	}
+
+	err = checkImport(tmp.Name())
+
+	if err != nil {
+		return fmt.Errorf("%s can't be imported: %w", path, err)
+	}
+
+	return os.Rename(tmp.Name(), dbPath)
+}
+
+// checkImport makes sure the database at path is sound and holds the bot's
+// tables, bringing them up to date.
+func checkImport(path string) error {
+	store, err := NewStore(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer store.Close()
+
+	var result string
+
+	err = store.db.QueryRow(`PRAGMA integrity_check`).Scan(&result)
+
+	if err != nil {
+		return err
+	}
+
+	if result != "ok" {
+		return errors.New(result)
+	}
+
+	var tables int
+
+	err = store.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('users', 'slack_messages')`).Scan(&tables)
+
+	if err != nil {
+		return err
+	}
+
+	if tables != 2 {
+		return errors.New("not a database of the bot")
+	}
+
+	return store.SetupDatabase()
+}
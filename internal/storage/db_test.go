@@ -0,0 +1,303 @@
+package storage
+
+import (
+	"cosoft-cli/shared/models"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScrubSlackCredentials(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "database.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	if err := store.SetupDatabase(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rows as stored by previous versions of the bot.
+	legacy := map[string]struct {
+		messageType string
+		payload     string
+	}{
+		"U1": {"login", `{"Email":"jane.doe@example.com","Password":"hunter2","Error":null}`},
+		"U2": {"landing", `{"User":{"FirstName":"Jane","WAuth":"token","WAuthRefresh":"refresh"}}`},
+		"U3": {"calendar", `{"CurrentDate":"2026-01-27T00:00:00Z"}`},
+	}
+
+	for userId, row := range legacy {
+		_, err := store.db.Exec(
+			`INSERT INTO slack_messages (slack_user_id, message_type, payload, created_at) VALUES (?, ?, ?, ?)`,
+			userId,
+			row.messageType,
+			[]byte(row.payload),
+			time.Now(),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Migrations run on every start.
+	if err := store.SetupDatabase(); err != nil {
+		t.Fatal(err)
+	}
+
+	for userId, row := range legacy {
+		state, err := store.GetSlackState(userId)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		payload := string(state.Payload)
+
+		for _, secret := range []string{"hunter2", "jane.doe@example.com", "token", "refresh"} {
+			if strings.Contains(payload, secret) {
+				t.Errorf("%s payload still contains %q: %s", row.messageType, secret, payload)
+			}
+		}
+
+		if row.messageType == "calendar" && payload != row.payload {
+			t.Errorf("calendar payload = %s, want it untouched", payload)
+		}
+	}
+}
+
+func TestDropMessageTypeCheck(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "database.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	// The table as created by previous versions of the bot.
+	_, err = store.db.Exec(`
+		CREATE TABLE slack_messages (
+		    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+		    slack_user_id VARCHAR(50) UNIQUE NOT NULL,
+		    payload BLOB NOT NULL,
+		    message_type TEXT CHECK (
+		        message_type IN ('landing', 'quick-book', 'browse', 'login', 'reservations', 'calendar')
+		    ) NOT NULL,
+		    created_at DATE NOT NULL
+		);
+
+		INSERT INTO slack_messages (slack_user_id, message_type, payload, created_at)
+		VALUES ('U1', 'landing', '{}', '2026-01-27 10:00:00');
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.SetupDatabase(); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := store.GetSlackState("U1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state == nil || state.MessageType != "landing" {
+		t.Fatalf("GetSlackState() = %+v, want the landing view to be kept", state)
+	}
+
+	if err := store.SetSlackState("U2", "new-screen", struct{}{}); err != nil {
+		t.Errorf("SetSlackState() error = %v, want any view type to be accepted", err)
+	}
+
+	// Views stored from now on are versioned.
+	for want := int64(1); want <= 2; want++ {
+		if err := store.SetSlackState("U1", "landing", struct{}{}); err != nil {
+			t.Fatal(err)
+		}
+
+		if state, err := store.GetSlackState("U1"); err != nil || state.Version != want {
+			t.Errorf("GetSlackState() = %+v, %v, want version %d", state, err, want)
+		}
+	}
+}
+
+func TestImport(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "database.db")
+
+	store, err := NewStore(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	if err := store.SetupDatabase(); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetSlackState("U1", "landing", struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+
+	exported := filepath.Join(dir, "export.db")
+	if err := store.Export(exported); err != nil {
+		t.Fatal(err)
+	}
+
+	notDatabase := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(notDatabase, []byte("not a database"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{name: "exported", path: exported},
+		{name: "not_a_database", path: notDatabase, wantErr: true},
+		{name: "missing", path: filepath.Join(dir, "missing.db"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := filepath.Join(t.TempDir(), "database.db")
+
+			err := Import(tt.path, target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Import() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			imported, err := NewStore(target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer imported.Close()
+
+			state, err := imported.GetSlackState("U1")
+			if err != nil || state == nil {
+				t.Errorf("GetSlackState() = %+v, %v, want the exported view", state, err)
+			}
+		})
+	}
+}
+
+func TestSyncRooms(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "database.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	if err := store.SetupDatabase(); err != nil {
+		t.Fatal(err)
+	}
+
+	initial := []models.Room{
+		{Id: "a", Name: "Salle Bleue", NbUsers: 4, Price: 10},
+		{Id: "b", Name: "Salle Verte", NbUsers: 2, Price: 6},
+		{Id: "c", Name: "Salle Rouge", NbUsers: 8, Price: 12},
+	}
+	if _, err := store.SyncRooms(initial); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		rooms       []models.Room
+		wantChanges []RoomChange
+		wantRooms   []string
+	}{
+		{
+			name:      "unchanged",
+			rooms:     initial,
+			wantRooms: []string{"Salle Bleue", "Salle Verte", "Salle Rouge"},
+		},
+		{
+			name: "changed",
+			rooms: []models.Room{
+				{Id: "a", Name: "Salle Azur", NbUsers: 4, Price: 12},
+				{Id: "c", Name: "Salle Rouge", NbUsers: 8, Price: 12},
+				{Id: "d", Name: "Salle Jaune", NbUsers: 6, Price: 8},
+			},
+			wantChanges: []RoomChange{
+				{Kind: RoomRenamed, Id: "a", Name: "Salle Azur", OldName: "Salle Bleue", Price: 12},
+				{Kind: RoomRepriced, Id: "a", Name: "Salle Azur", OldName: "Salle Bleue", Price: 12, OldPrice: 10},
+				{Kind: RoomAdded, Id: "d", Name: "Salle Jaune", Price: 8},
+				{Kind: RoomRemoved, Id: "b", Name: "Salle Verte", OldName: "Salle Verte", OldPrice: 6},
+			},
+			wantRooms: []string{"Salle Azur", "Salle Rouge", "Salle Jaune"},
+		},
+		{
+			name: "restored",
+			rooms: []models.Room{
+				{Id: "a", Name: "Salle Azur", NbUsers: 4, Price: 12},
+				{Id: "b", Name: "Salle Verte", NbUsers: 2, Price: 6},
+				{Id: "c", Name: "Salle Rouge", NbUsers: 8, Price: 12},
+				{Id: "d", Name: "Salle Jaune", NbUsers: 6, Price: 8},
+			},
+			wantChanges: []RoomChange{
+				{Kind: RoomAdded, Id: "b", Name: "Salle Verte", Price: 6},
+			},
+			wantRooms: []string{"Salle Azur", "Salle Verte", "Salle Rouge", "Salle Jaune"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := store.SyncRooms(tt.rooms)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(changes, tt.wantChanges) {
+				t.Errorf("SyncRooms() = %+v, want %+v", changes, tt.wantChanges)
+			}
+
+			rooms, err := store.GetRooms()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var names []string
+			for _, r := range rooms {
+				names = append(names, r.Name)
+				if r.RefreshedAt().IsZero() {
+					t.Errorf("%s was never refreshed", r.Name)
+				}
+			}
+			if !slices.Equal(names, tt.wantRooms) {
+				t.Errorf("GetRooms() = %v, want %v", names, tt.wantRooms)
+			}
+		})
+	}
+
+	// Reservations may still be made in removed rooms.
+	if _, err := store.SyncRooms(initial[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if room, err := store.GetRoomByName("Salle Rouge"); err != nil || room.Id != "c" {
+		t.Errorf("GetRoomByName() = %+v, %v, want the removed room", room, err)
+	}
+
+	// The description and equipment of the room are kept along with it.
+	described := initial[0]
+	described.Floor = "2"
+	described.Description = "Vue sur le parc"
+	described.Equipment = []string{"Écran", "Tableau blanc"}
+	if _, err := store.SyncRooms([]models.Room{described}); err != nil {
+		t.Fatal(err)
+	}
+	room, err := store.GetRoomByName("Salle Bleue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if room.Floor != "2" || room.Description != "Vue sur le parc" || !slices.Equal(room.Equipment, described.Equipment) {
+		t.Errorf("GetRoomByName() = %+v, want the room's description and equipment", room)
+	}
+
+	if _, err := store.SyncRooms(nil); err == nil {
+		t.Error("SyncRooms(nil) succeeded, want an error rather than removing every room")
+	}
+}
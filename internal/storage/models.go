@@ -7,23 +7,83 @@ import (
 )
 
 type User struct {
-	Id           uuid.UUID `db:"id"`
-	FirstName    string    `db:"first_name"`
-	LastName     string    `db:"last_name"`
-	Email        string    `db:"email"`
-	WAuth        string    `db:"w_auth"`
-	WAuthRefresh string    `db:"w_auth_refresh"`
-	Credits      float64   `db:"credits"`
-	SlackUserID  *string   `db:"slack_user_id"`
-	CreatedAt    time.Time `db:"created_at"`
+	Id        uuid.UUID `db:"id"`
+	FirstName string    `db:"first_name"`
+	LastName  string    `db:"last_name"`
+	Email     string    `db:"email"`
+	// The tokens are left out of JSON, since users are part of the Slack
+	// views stored in slack_messages.
+	WAuth        string  `db:"w_auth" json:"-"`
+	WAuthRefresh string  `db:"w_auth_refresh" json:"-"`
+	Credits      float64 `db:"credits"`
+	SlackUserID  *string `db:"slack_user_id"`
+	// ReminderLeadTime is in minutes, 0 meaning reminders are disabled.
+	ReminderLeadTime int `db:"reminder_lead_time"`
+	// Locale is the language picked by the user, empty to follow the
+	// environment or Slack.
+	Locale    string    `db:"locale"`
+	CreatedAt time.Time `db:"created_at"`
 }
 
 type Room struct {
-	Id        string    `db:"id"`
-	Name      string    `db:"name"`
-	MaxUsers  int       `db:"max_users"`
-	Price     float64   `db:"price"`
-	CreatedAt time.Time `db:"created_at"`
+	Id       string  `db:"id"`
+	Name     string  `db:"name"`
+	MaxUsers int     `db:"max_users"`
+	Price    float64 `db:"price"`
+	Image    string  `db:"image"`
+	// Description, Floor and Equipment are as Cosoft describes the room.
+	Description string    `db:"description"`
+	Floor       string    `db:"floor"`
+	Equipment   []string  `db:"equipment"`
+	CreatedAt   time.Time `db:"created_at"`
+	// UpdatedAt is when Cosoft last returned the room, zero if it was only
+	// fetched once, when created.
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// RefreshedAt is when the room was last fetched from Cosoft.
+func (r Room) RefreshedAt() time.Time {
+	if r.UpdatedAt.IsZero() {
+		return r.CreatedAt
+	}
+
+	return r.UpdatedAt
+}
+
+// RoomChangeKind tells how a room changed, see RoomChange.
+type RoomChangeKind int
+
+const (
+	RoomAdded RoomChangeKind = iota
+	RoomRemoved
+	RoomRenamed
+	RoomRepriced
+)
+
+func (k RoomChangeKind) String() string {
+	switch k {
+	case RoomAdded:
+		return "added"
+	case RoomRemoved:
+		return "removed"
+	case RoomRenamed:
+		return "renamed"
+	default:
+		return "repriced"
+	}
+}
+
+// RoomChange is a difference between the stored rooms and Cosoft's, as
+// found by Store.SyncRooms. A room both renamed and repriced has a change of
+// each kind.
+type RoomChange struct {
+	Kind RoomChangeKind
+	Id   string
+	// Name is the room's current name, or its last one if removed.
+	Name     string
+	OldName  string
+	Price    float64
+	OldPrice float64
 }
 
 type Reservation struct {
@@ -39,4 +99,41 @@ type Reservation struct {
 type SlackState struct {
 	MessageType string `db:"message_type"`
 	Payload     []byte `db:"payload"`
+	// UpdatedAt is when the view was last stored, which is when it was last
+	// displayed.
+	UpdatedAt time.Time `db:"updated_at"`
+	// Version counts the views stored for the user, starting at 1.
+	Version int64 `db:"version"`
+}
+
+// Announcement is a booking shared in a Slack channel, which the channel's
+// members can join. Team bookings are linked to the channel they were made
+// from, which is notified when they are cancelled.
+type Announcement struct {
+	Id          int64  `db:"id"`
+	SlackUserId string `db:"slack_user_id"`
+	ChannelId   string `db:"channel_id"`
+	// ReservationId is only known for team bookings.
+	ReservationId string    `db:"reservation_id"`
+	RoomName      string    `db:"room_name"`
+	RoomImage     string    `db:"room_image"`
+	Start         time.Time `db:"starts_at"`
+	End           time.Time `db:"ends_at"`
+	Team          bool      `db:"team"`
+	// ResponseUrl is where the announcement is posted.
+	ResponseUrl string    `db:"response_url"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// DelegatedBooking is the audit trail of a booking made by a delegate with
+// the owner's account.
+type DelegatedBooking struct {
+	Id                  int64     `db:"id"`
+	OwnerSlackUserId    string    `db:"owner_slack_user_id"`
+	DelegateSlackUserId string    `db:"delegate_slack_user_id"`
+	RoomName            string    `db:"room_name"`
+	Start               time.Time `db:"starts_at"`
+	End                 time.Time `db:"ends_at"`
+	Cost                float64   `db:"cost"`
+	CreatedAt           time.Time `db:"created_at"`
 }
@@ -0,0 +1,141 @@
+// Package termimage displays pictures in the terminals which support an
+// image protocol: kitty's graphics protocol, or iTerm2's inline images.
+package termimage
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Protocol is how a terminal displays images.
+type Protocol int
+
+const (
+	// None means the terminal can't display images.
+	None Protocol = iota
+	Kitty
+	ITerm2
+)
+
+// maxSize bounds the pictures Fetch downloads.
+const maxSize = 10 << 20
+
+// kittyChunk is the most base64 bytes kitty accepts per escape sequence.
+const kittyChunk = 4096
+
+// Detect guesses the protocol of the terminal from its environment.
+func Detect() Protocol {
+	return detect(os.Getenv)
+}
+
+func detect(getenv func(string) string) Protocol {
+	switch {
+	case getenv("KITTY_WINDOW_ID") != "",
+		strings.Contains(getenv("TERM"), "kitty"),
+		getenv("TERM_PROGRAM") == "ghostty":
+		return Kitty
+	case getenv("TERM_PROGRAM") == "iTerm.app",
+		getenv("TERM_PROGRAM") == "WezTerm",
+		getenv("LC_TERMINAL") == "iTerm2":
+		return ITerm2
+	}
+
+	return None
+}
+
+// Fetch downloads the picture at url.
+func Fetch(url string) ([]byte, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch the picture: %s", resp.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxSize))
+}
+
+// Encode returns the escape sequences displaying picture, width cells wide,
+// at the cursor.
+func Encode(p Protocol, picture []byte, width int) (string, error) {
+	switch p {
+	case Kitty:
+		return encodeKitty(picture, width)
+	case ITerm2:
+		return fmt.Sprintf(
+			"\x1b]1337;File=inline=1;size=%d;width=%d;preserveAspectRatio=1:%s\a",
+			len(picture),
+			width,
+			base64.StdEncoding.EncodeToString(picture),
+		), nil
+	}
+
+	return "", errors.New("the terminal can't display pictures")
+}
+
+// encodeKitty sends picture as PNG, the only compressed format kitty reads,
+// in chunks. Cells being about twice as high as wide, the picture is half
+// as many cells high as its ratio says.
+func encodeKitty(picture []byte, width int) (string, error) {
+	img, _, err := image.Decode(bytes.NewReader(picture))
+
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+
+	bounds := img.Bounds()
+	rows := max(1, width*bounds.Dy()/bounds.Dx()/2)
+	data := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	var b strings.Builder
+
+	for i := 0; i < len(data); i += kittyChunk {
+		end := min(i+kittyChunk, len(data))
+		more := 0
+
+		if end < len(data) {
+			more = 1
+		}
+
+		if i == 0 {
+			fmt.Fprintf(&b, "\x1b_Ga=T,f=100,c=%d,r=%d,m=%d;%s\x1b\\", width, rows, more, data[i:end])
+		} else {
+			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, data[i:end])
+		}
+	}
+
+	return b.String(), nil
+}
+
+// Clear returns the escape sequence removing the pictures Encode displayed,
+// which kitty keeps on screen otherwise.
+func Clear(p Protocol) string {
+	if p == Kitty {
+		return "\x1b_Ga=d\x1b\\"
+	}
+
+	return ""
+}
@@ -0,0 +1,63 @@
+package termimage
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want Protocol
+	}{
+		{name: "kitty", env: map[string]string{"TERM": "xterm-kitty"}, want: Kitty},
+		{name: "kitty_over_tmux", env: map[string]string{"TERM": "tmux-256color", "KITTY_WINDOW_ID": "1"}, want: Kitty},
+		{name: "iterm", env: map[string]string{"TERM_PROGRAM": "iTerm.app"}, want: ITerm2},
+		{name: "wezterm", env: map[string]string{"TERM_PROGRAM": "WezTerm"}, want: ITerm2},
+		{name: "xterm", env: map[string]string{"TERM": "xterm-256color"}, want: None},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detect(func(key string) string { return tt.env[key] }); got != tt.want {
+				t.Errorf("detect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	var picture bytes.Buffer
+	if err := png.Encode(&picture, image.NewRGBA(image.Rect(0, 0, 400, 200))); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		protocol   Protocol
+		wantPrefix string
+		wantErr    bool
+	}{
+		{name: "kitty", protocol: Kitty, wantPrefix: "\x1b_Ga=T,f=100,c=40,r=10,"},
+		{name: "iterm", protocol: ITerm2, wantPrefix: "\x1b]1337;File=inline=1;"},
+		{name: "none", protocol: None, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Encode(tt.protocol, picture.Bytes(), 40)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.HasPrefix(got, tt.wantPrefix) {
+				t.Errorf("Encode() = %.40q, want it to start with %q", got, tt.wantPrefix)
+			}
+		})
+	}
+
+	if _, err := Encode(Kitty, []byte("not a picture"), 40); err == nil {
+		t.Error("Encode() succeeded with something else than a picture")
+	}
+}
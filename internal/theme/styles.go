@@ -0,0 +1,112 @@
+package theme
+
+import (
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Fg returns a style writing in color, plain without colors.
+func (t *Theme) Fg(color string) lipgloss.Style {
+	if t.NoColor || color == "" {
+		return lipgloss.NewStyle()
+	}
+
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+}
+
+// Bg returns a style highlighting with color, or fallback without colors.
+func (t *Theme) Bg(color string, fallback lipgloss.Style) lipgloss.Style {
+	if t.NoColor || color == "" {
+		return fallback
+	}
+
+	return lipgloss.NewStyle().Background(lipgloss.Color(color))
+}
+
+func (t *Theme) Spinner() lipgloss.Style { return t.Fg(t.Colors.Primary) }
+func (t *Theme) Title() lipgloss.Style   { return t.Fg(t.Colors.Primary).Bold(true) }
+func (t *Theme) Muted() lipgloss.Style   { return t.Fg(t.Colors.Muted) }
+func (t *Theme) Success() lipgloss.Style { return t.Fg(t.Colors.Success) }
+func (t *Theme) Warning() lipgloss.Style { return t.Fg(t.Colors.Warning) }
+func (t *Theme) Danger() lipgloss.Style  { return t.Fg(t.Colors.Danger) }
+
+// Cursor highlights the item under the cursor.
+func (t *Theme) Cursor() lipgloss.Style {
+	return t.Bg(t.Colors.Primary, lipgloss.NewStyle().Reverse(true)).Foreground(t.Color(t.Colors.Text))
+}
+
+// CursorSubtle highlights the secondary lines of the item under the cursor.
+func (t *Theme) CursorSubtle() lipgloss.Style {
+	return t.Bg(t.Colors.Primary, lipgloss.NewStyle().Reverse(true)).Foreground(t.Color(t.Colors.Subtle))
+}
+
+// Selected highlights the selected calendar slots, apart from the cursor.
+func (t *Theme) Selected() lipgloss.Style {
+	return t.Bg(t.Colors.Success, lipgloss.NewStyle().Underline(true))
+}
+
+// CurrentSlot highlights the calendar's slot of the current time.
+func (t *Theme) CurrentSlot() lipgloss.Style {
+	return t.Bg(t.Colors.Now, lipgloss.NewStyle().Reverse(true))
+}
+
+// Busy renders the symbol of a slot booked by someone else.
+func (t *Theme) Busy() string { return t.Fg(t.Colors.Busy).Render("░") }
+
+// Own renders the symbol of a slot booked by the user.
+func (t *Theme) Own() string { return t.Fg(t.Colors.Own).Render("█") }
+
+// Unknown renders the symbol of a slot whose occupancy couldn't be fetched.
+func (t *Theme) Unknown() string { return t.Fg(t.Colors.Warning).Render("?") }
+
+// Color returns c as a lipgloss color, none without colors.
+func (t *Theme) Color(c string) lipgloss.TerminalColor {
+	if t.NoColor || c == "" {
+		return lipgloss.NoColor{}
+	}
+
+	return lipgloss.Color(c)
+}
+
+// Huh returns the theme of huh forms, based on huh's default one.
+func (t *Theme) Huh() *huh.Theme {
+	if t.NoColor {
+		return huh.ThemeBase()
+	}
+
+	h := huh.ThemeCharm()
+
+	primary := t.Color(t.Colors.Primary)
+	muted := t.Color(t.Colors.Muted)
+
+	h.Focused.Base = h.Focused.Base.BorderForeground(t.Color(t.Colors.Accent))
+	h.Focused.Card = h.Focused.Base
+	h.Focused.Title = h.Focused.Title.Foreground(primary)
+	h.Focused.NoteTitle = h.Focused.NoteTitle.Foreground(primary)
+	h.Focused.Description = h.Focused.Description.Foreground(muted)
+	h.Focused.ErrorIndicator = h.Focused.ErrorIndicator.Foreground(t.Color(t.Colors.Danger))
+	h.Focused.ErrorMessage = h.Focused.ErrorMessage.Foreground(t.Color(t.Colors.Danger))
+	h.Focused.SelectSelector = h.Focused.SelectSelector.Foreground(primary)
+	h.Focused.NextIndicator = h.Focused.NextIndicator.Foreground(primary)
+	h.Focused.PrevIndicator = h.Focused.PrevIndicator.Foreground(primary)
+	h.Focused.MultiSelectSelector = h.Focused.MultiSelectSelector.Foreground(primary)
+	h.Focused.SelectedOption = h.Focused.SelectedOption.Foreground(t.Color(t.Colors.Success))
+	h.Focused.SelectedPrefix = h.Focused.SelectedPrefix.Foreground(t.Color(t.Colors.Success))
+	h.Focused.UnselectedPrefix = h.Focused.UnselectedPrefix.Foreground(muted)
+	h.Focused.FocusedButton = h.Focused.FocusedButton.Foreground(t.Color(t.Colors.Text)).Background(primary)
+	h.Focused.Next = h.Focused.FocusedButton
+	h.Focused.TextInput.Cursor = h.Focused.TextInput.Cursor.Foreground(primary)
+	h.Focused.TextInput.Placeholder = h.Focused.TextInput.Placeholder.Foreground(muted)
+	h.Focused.TextInput.Prompt = h.Focused.TextInput.Prompt.Foreground(primary)
+
+	h.Blurred = h.Focused
+	h.Blurred.Base = h.Focused.Base.BorderStyle(lipgloss.HiddenBorder())
+	h.Blurred.Card = h.Blurred.Base
+	h.Blurred.NextIndicator = lipgloss.NewStyle()
+	h.Blurred.PrevIndicator = lipgloss.NewStyle()
+
+	h.Group.Title = h.Focused.Title
+	h.Group.Description = h.Focused.Description
+
+	return h
+}
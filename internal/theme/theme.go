@@ -0,0 +1,199 @@
+// Package theme holds the colors of the TUI and of the CLI's tables, picked
+// from a preset and the user's overrides.
+package theme
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Palette holds a theme's colors, as lipgloss colors: ANSI codes ("63") or
+// hex codes ("#fd4b4b"). Empty colors are left to the terminal.
+type Palette struct {
+	// Primary colors spinners, titles and the cursor.
+	Primary string `json:"primary,omitempty"`
+	// Accent colors borders and table headers.
+	Accent string `json:"accent,omitempty"`
+	// Header is the background of the header.
+	Header string `json:"header,omitempty"`
+	// Text and Subtle are the colors of text over Primary and Header.
+	Text   string `json:"text,omitempty"`
+	Subtle string `json:"subtle,omitempty"`
+	// Muted colors help, footers and secondary text.
+	Muted string `json:"muted,omitempty"`
+
+	Success string `json:"success,omitempty"`
+	Warning string `json:"warning,omitempty"`
+	Danger  string `json:"danger,omitempty"`
+
+	// Busy and Own color the calendar's slots booked by someone else (░)
+	// and by the user (█), Now the background of the current slot.
+	Busy string `json:"busy,omitempty"`
+	Own  string `json:"own,omitempty"`
+	Now  string `json:"now,omitempty"`
+}
+
+// Config is the theme section of the user's configuration.
+type Config struct {
+	// Preset is one of Presets, Dark when empty.
+	Preset string `json:"preset,omitempty"`
+	// Colorblind swaps the colors telling states apart for ones which
+	// don't rely on telling red from green.
+	Colorblind bool `json:"colorblind,omitempty"`
+	// Colors override the preset's.
+	Colors Palette `json:"colors,omitempty"`
+}
+
+const (
+	Dark         = "dark"
+	Light        = "light"
+	HighContrast = "high-contrast"
+)
+
+var presets = map[string]Palette{
+	Dark: {
+		Primary: "63",
+		Accent:  "#fd4b4b",
+		Header:  "#f45656",
+		Text:    "#FAFAFA",
+		Muted:   "245",
+		Subtle:  "7",
+		Success: "42",
+		Warning: "214",
+		Danger:  "9",
+		Now:     "#f45656",
+	},
+	Light: {
+		Primary: "#5A4FCF",
+		Accent:  "#C62828",
+		Header:  "#C62828",
+		Text:    "#FFFFFF",
+		Muted:   "#6B6B6B",
+		Subtle:  "#E0E0E0",
+		Success: "#2E7D32",
+		Warning: "#B26A00",
+		Danger:  "#C62828",
+		Now:     "#EF9A9A",
+	},
+	HighContrast: {
+		Primary: "14",
+		Accent:  "15",
+		Header:  "15",
+		Text:    "0",
+		Muted:   "15",
+		Subtle:  "0",
+		Success: "10",
+		Warning: "11",
+		Danger:  "9",
+		Busy:    "15",
+		Own:     "11",
+		Now:     "9",
+	},
+}
+
+// colorblind is the Okabe-Ito palette: blue and orange stay apart with
+// every kind of color blindness.
+var colorblind = Palette{
+	Success: "#0072B2",
+	Warning: "#E69F00",
+	Danger:  "#D55E00",
+	Busy:    "#E69F00",
+	Own:     "#0072B2",
+	Now:     "#CC79A7",
+}
+
+// Presets lists the presets' names.
+func Presets() []string {
+	names := make([]string, 0, len(presets))
+
+	for name := range presets {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Theme is the palette in use, along with the styles made of it.
+type Theme struct {
+	Colors Palette
+	// NoColor drops every color, highlights using reverse video instead.
+	NoColor bool
+}
+
+// New builds the theme cfg describes. noColor is usually NoColorEnv().
+func New(cfg Config, noColor bool) (*Theme, error) {
+	preset := cfg.Preset
+	if preset == "" {
+		preset = Dark
+	}
+
+	palette, ok := presets[preset]
+	if !ok {
+		return nil, fmt.Errorf("unknown theme %q, pick one of %v", cfg.Preset, Presets())
+	}
+
+	if cfg.Colorblind {
+		palette = palette.merge(colorblind)
+	}
+
+	return &Theme{Colors: palette.merge(cfg.Colors), NoColor: noColor}, nil
+}
+
+// NoColorEnv tells whether NO_COLOR asks for no colors, see no-color.org.
+func NoColorEnv() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// merge returns p with the colors set in o.
+func (p Palette) merge(o Palette) Palette {
+	for _, c := range []struct{ dst, src *string }{
+		{&p.Primary, &o.Primary},
+		{&p.Accent, &o.Accent},
+		{&p.Header, &o.Header},
+		{&p.Text, &o.Text},
+		{&p.Subtle, &o.Subtle},
+		{&p.Muted, &o.Muted},
+		{&p.Success, &o.Success},
+		{&p.Warning, &o.Warning},
+		{&p.Danger, &o.Danger},
+		{&p.Busy, &o.Busy},
+		{&p.Own, &o.Own},
+		{&p.Now, &o.Now},
+	} {
+		if *c.src != "" {
+			*c.dst = *c.src
+		}
+	}
+
+	return p
+}
+
+var (
+	mu      sync.RWMutex
+	current = &Theme{Colors: presets[Dark]}
+)
+
+// Set makes t the theme of every style built afterwards.
+func Set(t *Theme) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = t
+
+	if t.NoColor {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
+
+// Current returns the theme in use, Dark until Set is called.
+func Current() *Theme {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
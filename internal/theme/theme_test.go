@@ -0,0 +1,94 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		want    Palette
+		wantErr bool
+	}{
+		{name: "default", cfg: Config{}, want: presets[Dark]},
+		{name: "preset", cfg: Config{Preset: Light}, want: presets[Light]},
+		{name: "unknown", cfg: Config{Preset: "solarized"}, wantErr: true},
+		{
+			name: "overrides",
+			cfg:  Config{Preset: HighContrast, Colors: Palette{Primary: "#123456", Own: "12"}},
+			want: func() Palette {
+				p := presets[HighContrast]
+				p.Primary, p.Own = "#123456", "12"
+				return p
+			}(),
+		},
+		{
+			name: "colorblind",
+			cfg:  Config{Colorblind: true},
+			want: presets[Dark].merge(colorblind),
+		},
+		{
+			name: "overrides_win_over_colorblind",
+			cfg:  Config{Colorblind: true, Colors: Palette{Busy: "3"}},
+			want: func() Palette {
+				p := presets[Dark].merge(colorblind)
+				p.Busy = "3"
+				return p
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.cfg, false)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Colors != tt.want {
+				t.Errorf("New() = %+v, want %+v", got.Colors, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorblindTellsSlotsApart(t *testing.T) {
+	th, _ := New(Config{Colorblind: true}, false)
+
+	if th.Colors.Busy == "" || th.Colors.Busy == th.Colors.Own {
+		t.Errorf("busy %q and own %q slots should have distinct colors", th.Colors.Busy, th.Colors.Own)
+	}
+}
+
+func TestNoColor(t *testing.T) {
+	th, _ := New(Config{}, true)
+
+	if _, ok := th.Success().GetForeground().(lipgloss.NoColor); !ok {
+		t.Errorf("Success() should have no color, got %v", th.Success().GetForeground())
+	}
+	if _, ok := th.Color(th.Colors.Primary).(lipgloss.NoColor); !ok {
+		t.Errorf("Color() should have no color")
+	}
+	if !th.Cursor().GetReverse() {
+		t.Errorf("Cursor() should fall back to reverse video")
+	}
+	if !th.Selected().GetUnderline() {
+		t.Errorf("Selected() should fall back to an underline")
+	}
+	if !th.CurrentSlot().GetReverse() {
+		t.Errorf("CurrentSlot() should fall back to reverse video")
+	}
+}
+
+func TestNoColorEnv(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	if NoColorEnv() {
+		t.Errorf("NoColorEnv() = true with an empty NO_COLOR")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if !NoColorEnv() {
+		t.Errorf("NoColorEnv() = false with NO_COLOR=1")
+	}
+}
@@ -17,6 +17,7 @@ type AppModel struct {
 	browseModel          *BrowseModel
 	reservationListModel *ReservationListModel
 	settingsModel        *SettingsModel
+	calendarModel        *CalendarModel
 	// Add others
 }
 
@@ -28,6 +29,7 @@ const (
 	PageBrowse       PageType = "browse"
 	PageReservations PageType = "reservations"
 	PageSettings     PageType = "settings"
+	PageCalendar     PageType = "calendar"
 )
 
 func NewAppModel(startPage string, allowBackNav bool) *AppModel {
@@ -39,6 +41,7 @@ func NewAppModel(startPage string, allowBackNav bool) *AppModel {
 		browseModel:          NewBrowseModel(),
 		reservationListModel: NewReservationListModel(),
 		settingsModel:        NewSettingsModel(),
+		calendarModel:        NewCalendarModel(),
 		// Add others
 	}
 }
@@ -55,6 +58,8 @@ func (m *AppModel) Init() tea.Cmd {
 		return m.reservationListModel.Init()
 	case "settings":
 		return m.settingsModel.Init()
+	case "calendar":
+		return m.calendarModel.Init()
 	default:
 		return m.landingModel.Init()
 	}
@@ -83,6 +88,9 @@ func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "settings":
 			m.settingsModel = NewSettingsModel()
 			return m, m.settingsModel.Init()
+		case "calendar":
+			m.calendarModel = NewCalendarModel()
+			return m, m.calendarModel.Init()
 		default:
 			return m, nil
 		}
@@ -132,6 +140,10 @@ func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		newModel, cmd := m.settingsModel.Update(msg)
 		m.settingsModel = newModel.(*SettingsModel)
 		return m, cmd
+	case PageCalendar:
+		newModel, cmd := m.calendarModel.Update(msg)
+		m.calendarModel = newModel.(*CalendarModel)
+		return m, cmd
 		// Add other pages here as you create them
 	}
 
@@ -155,6 +167,8 @@ func (m *AppModel) View() string {
 		return m.reservationListModel.View()
 	case PageSettings:
 		return m.settingsModel.View()
+	case PageCalendar:
+		return m.calendarModel.View()
 	// Others
 	default:
 		return "unknown page"
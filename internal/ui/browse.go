@@ -3,10 +3,14 @@ package ui
 import (
 	"cosoft-cli/internal/api"
 	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/i18n"
 	"cosoft-cli/internal/services"
+	"cosoft-cli/internal/termimage"
+	"cosoft-cli/internal/theme"
 	"cosoft-cli/internal/ui/components"
 	"cosoft-cli/shared/models"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
@@ -25,7 +29,22 @@ type BrowseModel struct {
 	bookForm      *huh.Form
 	browsePayload *api.BrowsePayload
 	bookPayload   *api.CosoftBookingPayload
-	err           error
+	// features are the equipment the rooms must have.
+	features *[]models.Feature
+	// roomList is the list of bookForm, when it lists rooms.
+	roomList *components.ListField[string]
+	// photos is how the terminal displays the rooms' photos, if it can.
+	photos   termimage.Protocol
+	photoErr error
+	// suggestions are offered instead of rooms when none is free at the
+	// requested time, suggestion being the picked one.
+	suggestions []models.SlotSuggestion
+	suggestion  int
+	err         error
+}
+
+type suggestionsFetchedMsg struct {
+	suggestions []models.SlotSuggestion
 }
 
 func NewBrowseModel() *BrowseModel {
@@ -34,20 +53,21 @@ func NewBrowseModel() *BrowseModel {
 		StartHour: roundHourToQuarter(time.Now()).Format(timeOnlyFormat),
 	}
 	bookPayload := &api.CosoftBookingPayload{}
+	features := &[]models.Feature{}
 
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("63"))
+	s.Style = theme.Current().Spinner()
 
 	peoples := []components.Item[int]{
 		{
-			Label:    "1 person",
-			Subtitle: "The research will include callboxes",
+			Label:    i18n.T("tui.people.one"),
+			Subtitle: i18n.T("tui.people.one_hint"),
 			Value:    1,
 		},
 		{
-			Label:    "2 persons or more",
-			Subtitle: "The research will default to classic meeting rooms",
+			Label:    i18n.T("tui.people.two"),
+			Subtitle: i18n.T("tui.people.two_hint"),
 			Value:    2,
 		},
 	}
@@ -55,28 +75,33 @@ func NewBrowseModel() *BrowseModel {
 	form := huh.NewForm(
 		huh.NewGroup(
 			huh.NewInput().
-				Title("Reservation date").
-				Description("Pick a date in the future, format yyyy-mm-dd").
+				Title(i18n.T("tui.browse.date")).
+				Description(i18n.T("tui.browse.date_hint")).
 				Validate(validateDateIsFuture).
 				Value(&browsePayload.StartDate),
 			huh.NewInput().
-				Title("Reservation hour").
-				Description("The hour needs to be rounded to the quarter (ex: 9:15, 10:30, etc)").
+				Title(i18n.T("tui.browse.hour")).
+				Description(i18n.T("tui.browse.hour_hint")).
 				Validate(validateHour).
 				Value(&browsePayload.StartHour),
 			huh.NewSelect[int]().
-				Title("Reservation duration").
+				Title(i18n.T("tui.browse.duration")).
 				Options(
-					huh.NewOption("30mn", 30),
-					huh.NewOption("1 hour", 60),
-					huh.NewOption("1 hour 30 minutes", 90),
-					huh.NewOption("2 hours", 120),
+					huh.NewOption(i18n.T("tui.browse.duration_30"), 30),
+					huh.NewOption(i18n.T("tui.browse.duration_60"), 60),
+					huh.NewOption(i18n.T("tui.browse.duration_90"), 90),
+					huh.NewOption(i18n.T("tui.browse.duration_120"), 120),
 				).
 				Value(&browsePayload.Duration),
-			components.NewListField(peoples, "For how many people?").
+			components.NewListField(peoples, i18n.T("tui.people.how_many")).
 				Value(&browsePayload.NbPeople),
+			huh.NewMultiSelect[models.Feature]().
+				Title(i18n.T("tui.browse.features")).
+				Description(i18n.T("tui.browse.features_hint")).
+				Options(featureOptions()...).
+				Value(features),
 		),
-	)
+	).WithTheme(theme.Current().Huh())
 
 	return &BrowseModel{
 		phase:         0,
@@ -85,9 +110,22 @@ func NewBrowseModel() *BrowseModel {
 		roomId:        "",
 		browsePayload: browsePayload,
 		bookPayload:   bookPayload,
+		features:      features,
+		photos:        termimage.Detect(),
 	}
 }
 
+// featureOptions offers every feature rooms can be filtered by.
+func featureOptions() []huh.Option[models.Feature] {
+	options := make([]huh.Option[models.Feature], len(models.Features))
+
+	for i, f := range models.Features {
+		options[i] = huh.NewOption(i18n.T("tui.feature."+string(f)), f)
+	}
+
+	return options
+}
+
 func (b *BrowseModel) Init() tea.Cmd {
 	b.browsePayload.StartDate = time.Now().Format(time.DateOnly)
 	b.browsePayload.StartHour = roundHourToQuarter(time.Now()).Format(timeOnlyFormat)
@@ -103,10 +141,25 @@ func (b *BrowseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		b.bookForm = b.buildBookForm(b.rooms)
 		b.phase = 2
 		return b, b.bookForm.Init()
+	case suggestionsFetchedMsg:
+		b.suggestions = msg.suggestions
+		b.bookForm = b.buildSuggestionForm()
+		b.phase = 2
+		return b, b.bookForm.Init()
 	case bookingCompleteMsg:
 		b.bookedRoom = &msg.room
 		b.phase = 4
 		return b, nil
+	case photoShownMsg:
+		b.photoErr = msg.err
+		return b, nil
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+o" && b.phase == 2 {
+			if room := b.hoveredRoom(); room != nil && b.canShowPhoto(*room) {
+				b.photoErr = nil
+				return b, showPhoto(*room, b.photos)
+			}
+		}
 
 	case spinner.TickMsg:
 		var cmd tea.Cmd
@@ -135,6 +188,11 @@ func (b *BrowseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		if b.bookForm.State == huh.StateCompleted {
+			// Suggestions are on the same day, only the hour changes.
+			if len(b.suggestions) > 0 {
+				b.browsePayload.StartHour = b.suggestions[b.suggestion].Start.Format(timeOnlyFormat)
+			}
+
 			b.phase = 3
 			return b, tea.Batch(b.spinner.Tick, b.bookRoom())
 		}
@@ -150,32 +208,36 @@ func (b *BrowseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (b *BrowseModel) View() string {
 
 	if b.err != nil {
-		return b.err.Error()
+		return errorText(b.err)
 	}
 
 	switch b.phase {
 	case 0:
 		return b.searchForm.View()
 	case 1:
-		return b.spinner.View() + " Looking for available rooms... \n\n"
+		return b.spinner.View() + " " + i18n.T("tui.booking.looking") + " \n\n"
 	case 2:
+		if b.photoErr != nil {
+			return b.bookForm.View() + "\n" + theme.Current().Danger().Render(b.photoErr.Error())
+		}
+
 		return b.bookForm.View()
 	case 3:
-		return b.spinner.View() + " Booking selected room... \n\n"
+		return b.spinner.View() + " " + i18n.T("tui.browse.booking") + " \n\n"
 	case 4:
 		if b.err != nil {
-			return lipgloss.NewStyle().Foreground(lipgloss.Color("5")).Render(b.err.Error())
+			return theme.Current().Danger().Render(errorText(b.err))
 		}
 
-		header := lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Render("✓ Booking complete!") + "\n\n"
-		tooltip := "You can now press \"ESC\" to go back to the main menu."
+		header := theme.Current().Success().Render(i18n.T("tui.booking.complete")) + "\n\n"
+		tooltip := i18n.T("tui.esc_to_menu")
 		t := b.generateTable()
 
 		return header + tooltip + t
 
 	}
 
-	return "Browse."
+	return i18n.T("tui.browse.title")
 }
 
 func (b *BrowseModel) getRoomsAvailability() tea.Cmd {
@@ -209,11 +271,22 @@ func (b *BrowseModel) getRoomsAvailability() tea.Cmd {
 			return bookingFailedMsg{err: err}
 		}
 
-		if len(rooms) == 0 {
-			return bookingFailedMsg{err: fmt.Errorf("no room available for the selected time")}
+		rooms = models.FilterRooms(rooms, *b.features)
+
+		if len(rooms) > 0 {
+			return roomFetchedMsg{availableRooms: rooms}
+		}
+
+		suggestions, err := services.SuggestSlots(*user, payload.NbPeople, payload.Duration, dt, *b.features)
+		if err != nil {
+			return bookingFailedMsg{err: err}
 		}
 
-		return roomFetchedMsg{availableRooms: rooms}
+		if len(suggestions) == 0 {
+			return bookingFailedMsg{err: localized("tui.browse.no_room_day")}
+		}
+
+		return suggestionsFetchedMsg{suggestions: suggestions}
 	}
 }
 
@@ -228,19 +301,129 @@ func (b *BrowseModel) buildBookForm(rooms []models.Room) *huh.Form {
 		list[i] = components.Item[string]{
 			Value:    room.Id,
 			Label:    room.Name,
-			Subtitle: fmt.Sprintf("%.02f credits", room.Price),
+			Subtitle: i18n.T("tui.credits_amount", room.Price),
 		}
 	}
 
+	b.roomList = components.NewListField(list, i18n.T("tui.browse.pick_room")).
+		Value(&b.roomId).
+		Detail(b.roomDetail)
+
 	form := huh.NewForm(
-		huh.NewGroup(
-			components.NewListField(list, "Pick a meeting room").
-				Value(&b.roomId),
-		))
+		huh.NewGroup(b.roomList),
+	).WithTheme(theme.Current().Huh())
 
 	return form
 }
 
+// hoveredRoom returns the room under the list's cursor, if any.
+func (b *BrowseModel) hoveredRoom() *models.Room {
+	if b.roomList == nil || len(b.suggestions) > 0 {
+		return nil
+	}
+
+	item := b.roomList.SelectedItem()
+
+	if item == nil {
+		return nil
+	}
+
+	for i, room := range b.rooms {
+		if room.Id == item.Value {
+			return &b.rooms[i]
+		}
+	}
+
+	return nil
+}
+
+// canShowPhoto tells whether the room has a photo the terminal can display.
+func (b *BrowseModel) canShowPhoto(room models.Room) bool {
+	return room.Image != "" && b.photos != termimage.None
+}
+
+// detailWidth wraps the rooms' descriptions in the detail pane.
+const detailWidth = 40
+
+// roomDetail describes the room under the list's cursor.
+func (b *BrowseModel) roomDetail(id string) string {
+	for _, room := range b.rooms {
+		if room.Id != id {
+			continue
+		}
+
+		lines := []string{
+			lipgloss.NewStyle().Bold(true).Render(room.Name),
+			"",
+			i18n.T("tui.browse.capacity", room.NbUsers),
+			i18n.T("tui.browse.price", room.Price),
+			i18n.T(
+				"tui.browse.total",
+				room.Price*float64(b.browsePayload.Duration)/60,
+				b.browsePayload.Duration,
+			),
+		}
+
+		if room.Floor != "" {
+			lines = append(lines, i18n.T("tui.browse.floor", room.Floor))
+		}
+
+		if len(room.Equipment) > 0 {
+			lines = append(lines, "", i18n.T("tui.browse.equipment", strings.Join(room.Equipment, ", ")))
+		}
+
+		if room.Description != "" {
+			lines = append(lines, "", lipgloss.NewStyle().Width(detailWidth).Render(room.Description))
+		}
+
+		if b.canShowPhoto(room) {
+			lines = append(lines, "", theme.Current().Muted().Render(i18n.T("tui.browse.photo")))
+		}
+
+		return strings.Join(lines, "\n")
+	}
+
+	return ""
+}
+
+// buildSuggestionForm offers the free slots closest to the requested time.
+func (b *BrowseModel) buildSuggestionForm() *huh.Form {
+	requested := b.getStartTime(b.browsePayload.StartDate, b.browsePayload.StartHour)
+	length := time.Duration(b.browsePayload.Duration) * time.Minute
+	list := make([]components.Item[int], len(b.suggestions))
+	l := i18n.Default()
+
+	for i, suggestion := range b.suggestions {
+		when := "tui.browse.later"
+		gap := suggestion.Start.Sub(requested)
+
+		if gap < 0 {
+			when, gap = "tui.browse.earlier", -gap
+		}
+
+		list[i] = components.Item[int]{
+			Value: i,
+			Label: fmt.Sprintf(
+				"%s · %s → %s",
+				suggestion.Room.Name,
+				l.Time(suggestion.Start),
+				l.Time(suggestion.Start.Add(length)),
+			),
+			Subtitle: l.T(
+				when,
+				int(gap.Minutes()),
+				suggestion.Room.Price*float64(b.browsePayload.Duration)/60,
+			),
+		}
+	}
+
+	return huh.NewForm(
+		huh.NewGroup(
+			components.NewListField(list, l.T("tui.browse.suggestions")).
+				Value(&b.suggestion),
+		)).WithTheme(theme.Current().Huh())
+}
+
 func (b *BrowseModel) bookRoom() tea.Cmd {
 	return func() tea.Msg {
 		authService, err := services.NewService()
@@ -264,12 +447,16 @@ func (b *BrowseModel) bookRoom() tea.Cmd {
 			}
 		}
 
+		if len(b.suggestions) > 0 {
+			pickedRoom = &b.suggestions[b.suggestion].Room
+		}
+
 		if pickedRoom == nil {
-			return bookingFailedMsg{err: fmt.Errorf("no room suiting user's selection, aborting")}
+			return bookingFailedMsg{err: localized("tui.booking.no_suiting_room")}
 		}
 
 		if user.Credits < pickedRoom.Price {
-			return bookingFailedMsg{err: fmt.Errorf("not enough credits to perform the booking, aborting")}
+			return bookingFailedMsg{err: localized("tui.booking.not_enough_credits")}
 		}
 
 		dt := b.getStartTime(b.browsePayload.StartDate, b.browsePayload.StartHour)
@@ -299,17 +486,17 @@ func (b *BrowseModel) bookRoom() tea.Cmd {
 func (b *BrowseModel) generateTable() string {
 	dt := b.getStartTime(b.browsePayload.StartDate, b.browsePayload.StartHour)
 	endTime := dt.Add(time.Duration(b.browsePayload.Duration) * time.Minute)
-	dateFormat := "02/01/2006 15:04"
 
 	paidPrice := b.bookedRoom.Price * (float64(b.browsePayload.Duration) / 60)
 
-	headers := []string{"ROOM", "DURATION", "COST"}
+	l := i18n.Default()
+	headers := []string{l.T("tui.table.room"), l.T("tui.table.duration"), l.T("tui.table.cost")}
 
 	rows := [][]string{
 		{
 			b.bookedRoom.Name,
-			fmt.Sprintf("%s → %s", dt.Format(dateFormat), endTime.Format(dateFormat)),
-			fmt.Sprintf("%.2f credits", paidPrice),
+			fmt.Sprintf("%s → %s", l.DateTime(dt), l.DateTime(endTime)),
+			l.T("tui.credits_amount", paidPrice),
 		},
 	}
 
@@ -0,0 +1,671 @@
+package ui
+
+import (
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/services"
+	"cosoft-cli/internal/storage"
+	"cosoft-cli/internal/theme"
+	"cosoft-cli/shared/models"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	calendarStartHour = common.OpeningHour
+	calendarHours     = common.ClosingHour - common.OpeningHour
+	calendarCells     = calendarHours * 4
+	// maxBookingCells is the longest booking Cosoft allows.
+	maxBookingCells = common.MaxBookingMinutes / 15
+)
+
+type cellState int
+
+const (
+	cellFree cellState = iota
+	cellBusy
+	cellOwn
+	cellPast
+	// cellUnknown is a slot of a room whose busy times couldn't be fetched.
+	cellUnknown
+)
+
+// calendarGrid holds the state of every 15 minutes cell of a day, from 8:00
+// to midnight, one row per room.
+type calendarGrid struct {
+	date  time.Time
+	rooms []storage.Room
+	cells [][]cellState
+	// reservations holds the user's reservation covering each of their cells.
+	reservations [][]*api.Reservation
+}
+
+func newCalendarGrid(
+	date, now time.Time,
+	rooms []storage.Room,
+	usages []models.RoomUsage,
+	bookings []api.Reservation,
+) calendarGrid {
+	location := date.Location()
+	g := calendarGrid{
+		date:         date,
+		rooms:        rooms,
+		cells:        make([][]cellState, len(rooms)),
+		reservations: make([][]*api.Reservation, len(rooms)),
+	}
+
+	overlaps := func(col int, start, end string) bool {
+		s, errStart := time.ParseInLocation("2006-01-02T15:04:05", start, location)
+		e, errEnd := time.ParseInLocation("2006-01-02T15:04:05", end, location)
+
+		if errStart != nil || errEnd != nil {
+			return false
+		}
+
+		cellStart := g.cellTime(col)
+
+		return cellStart.Before(e) && cellStart.Add(15*time.Minute).After(s)
+	}
+
+	for row, room := range rooms {
+		g.cells[row] = make([]cellState, calendarCells)
+		g.reservations[row] = make([]*api.Reservation, calendarCells)
+
+		var usage models.RoomUsage
+
+		for _, u := range usages {
+			if u.Id == room.Id {
+				usage = u
+				break
+			}
+		}
+
+		for col := range calendarCells {
+			if g.cellTime(col).Before(now) {
+				g.cells[row][col] = cellPast
+			} else if usage.Unknown {
+				g.cells[row][col] = cellUnknown
+			}
+
+			for _, slot := range usage.UsedSlots {
+				if overlaps(col, slot.Start, slot.End) {
+					g.cells[row][col] = cellBusy
+					break
+				}
+			}
+
+			for i, b := range bookings {
+				if b.ItemName == room.Name && overlaps(col, b.Start, b.End) {
+					g.cells[row][col] = cellOwn
+					g.reservations[row][col] = &bookings[i]
+					break
+				}
+			}
+		}
+	}
+
+	return g
+}
+
+// cellTime returns when the cell at col starts, on the wall clock, so that
+// days when the clocks change keep their hours.
+func (g calendarGrid) cellTime(col int) time.Time {
+	year, month, day := g.date.Date()
+
+	return time.Date(year, month, day, calendarStartHour, col*15, 0, 0, g.date.Location())
+}
+
+// checkRange returns why the cells from..to of row can't be booked, if so.
+func (g calendarGrid) checkRange(row, from, to int) error {
+	if from > to {
+		from, to = to, from
+	}
+
+	if to-from+1 > maxBookingCells {
+		return errors.New(i18n.T("tui.calendar.too_long"))
+	}
+
+	for col := from; col <= to; col++ {
+		switch g.cells[row][col] {
+		case cellPast:
+			return errors.New(i18n.T("tui.calendar.passed"))
+		case cellBusy, cellOwn:
+			return errors.New(i18n.T("tui.calendar.booked_at", g.rooms[row].Name, i18n.Default().Time(g.cellTime(col))))
+		case cellUnknown:
+			return errors.New(i18n.T("tui.calendar.slot_unknown"))
+		}
+	}
+
+	return nil
+}
+
+type calendarDataMsg struct {
+	rooms    []storage.Room
+	usages   []models.RoomUsage
+	bookings []api.Reservation
+	err      error
+}
+
+type calendarActionMsg struct {
+	message string
+	err     error
+}
+
+// CalendarModel displays a day's occupancy of every room, through which a
+// cursor is moved to book free cells, or act on the user's reservations.
+type CalendarModel struct {
+	phase    int
+	date     time.Time
+	grid     calendarGrid
+	row, col int
+	// anchor is the first cell of the range being selected, -1 if none.
+	anchor    int
+	form      *huh.Form
+	confirmed bool
+	action    int
+	picked    api.Reservation
+	// booking describes the range to book, once selected.
+	booking services.BookingRequest
+	notice  string
+	failed  bool
+	spinner spinner.Model
+	err     error
+}
+
+func NewCalendarModel() *CalendarModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = theme.Current().Spinner()
+
+	location, _ := common.LoadLocalTime()
+	now := time.Now().In(location)
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+
+	// Start on the current quarter hour.
+	col := (now.Hour()-calendarStartHour)*4 + now.Minute()/15
+
+	return &CalendarModel{
+		phase:   1,
+		date:    date,
+		col:     max(0, min(col, calendarCells-1)),
+		anchor:  -1,
+		spinner: s,
+	}
+}
+
+func (m *CalendarModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.fetchDay())
+}
+
+func (m *CalendarModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case calendarDataMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+
+		if len(msg.rooms) == 0 {
+			m.err = localized("tui.calendar.no_rooms")
+			return m, nil
+		}
+
+		m.grid = newCalendarGrid(m.date, time.Now(), msg.rooms, msg.usages, msg.bookings)
+		m.row = min(m.row, len(msg.rooms)-1)
+		m.phase = 2
+		return m, nil
+
+	case calendarActionMsg:
+		m.notice, m.failed = msg.message, false
+
+		if msg.err != nil {
+			m.notice, m.failed = errorText(msg.err), true
+		}
+
+		m.phase = 1
+		return m, tea.Batch(m.spinner.Tick, m.fetchDay())
+
+	case tea.KeyMsg:
+		if m.phase == 2 {
+			return m, m.handleKey(msg.String())
+		}
+	}
+
+	if m.phase != 3 || m.form == nil {
+		return m, nil
+	}
+
+	form, cmd := m.form.Update(msg)
+
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+	}
+
+	if m.form.State == huh.StateCompleted {
+		m.anchor = -1
+
+		if !m.confirmed {
+			m.phase = 2
+			return m, nil
+		}
+
+		m.phase = 4
+
+		if m.booking.Name != "" {
+			return m, tea.Batch(m.spinner.Tick, m.book())
+		}
+
+		return m, tea.Batch(m.spinner.Tick, m.updateReservation())
+	}
+
+	return m, cmd
+}
+
+// handleKey moves the cursor, moving to the previous or next day when going
+// past either end of the grid.
+func (m *CalendarModel) handleKey(key string) tea.Cmd {
+	m.notice = ""
+
+	switch key {
+	case "up", "k":
+		if m.row > 0 {
+			m.row--
+			m.anchor = -1
+		}
+	case "down", "j":
+		if m.row < len(m.grid.rooms)-1 {
+			m.row++
+			m.anchor = -1
+		}
+	case "left", "h":
+		if m.col > 0 {
+			m.col--
+			return nil
+		}
+
+		m.col = calendarCells - 1
+		return m.changeDay(-1)
+	case "right", "l":
+		if m.col < calendarCells-1 {
+			m.col++
+			return nil
+		}
+
+		m.col = 0
+		return m.changeDay(1)
+	case "pgup", "[":
+		return m.changeDay(-1)
+	case "pgdown", "]":
+		return m.changeDay(1)
+	case "x", "backspace":
+		m.anchor = -1
+	case "enter", " ":
+		return m.selectCell()
+	}
+
+	return nil
+}
+
+func (m *CalendarModel) changeDay(days int) tea.Cmd {
+	location := m.date.Location()
+	now := time.Now().In(location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	date := m.date.AddDate(0, 0, days)
+
+	if date.Before(today) {
+		m.col = 0
+		return nil
+	}
+
+	m.date = date
+	m.anchor = -1
+	m.phase = 1
+
+	return tea.Batch(m.spinner.Tick, m.fetchDay())
+}
+
+// selectCell opens the actions of the user's reservation under the cursor,
+// or starts selecting a range of free cells, then asks to book it.
+func (m *CalendarModel) selectCell() tea.Cmd {
+	switch m.grid.cells[m.row][m.col] {
+	case cellOwn:
+		m.picked = *m.grid.reservations[m.row][m.col]
+		m.booking = services.BookingRequest{}
+		m.buildActionForm()
+	case cellFree:
+		if m.anchor == -1 {
+			m.anchor = m.col
+			return nil
+		}
+
+		if err := m.grid.checkRange(m.row, m.anchor, m.col); err != nil {
+			m.notice, m.failed = err.Error(), true
+			return nil
+		}
+
+		from, to := min(m.anchor, m.col), max(m.anchor, m.col)
+		room := m.grid.rooms[m.row]
+
+		m.booking = services.BookingRequest{
+			Capacity: 1,
+			Duration: (to - from + 1) * 15,
+			Name:     room.Name,
+			DateTime: m.grid.cellTime(from),
+		}
+		m.buildBookingForm(room)
+	case cellBusy:
+		m.notice, m.failed = i18n.T("tui.calendar.slot_booked"), true
+		return nil
+	case cellUnknown:
+		m.notice, m.failed = i18n.T("tui.calendar.slot_unknown"), true
+		return nil
+	default:
+		m.notice, m.failed = i18n.T("tui.calendar.slot_passed"), true
+		return nil
+	}
+
+	m.confirmed = false
+	m.phase = 3
+
+	return m.form.Init()
+}
+
+func (m *CalendarModel) buildBookingForm(room storage.Room) {
+	end := m.booking.DateTime.Add(time.Duration(m.booking.Duration) * time.Minute)
+	cost := room.Price * float64(m.booking.Duration) / 60
+	l := i18n.Default()
+
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(l.T("tui.calendar.book", room.Name)).
+				Description(l.T(
+					"tui.calendar.book_summary",
+					l.Day(m.booking.DateTime),
+					l.Time(m.booking.DateTime),
+					l.Time(end),
+					cost,
+				)).
+				Negative(l.T("tui.no")).
+				Affirmative(l.T("tui.yes")).
+				Value(&m.confirmed),
+		),
+	).WithTheme(theme.Current().Huh())
+}
+
+func (m *CalendarModel) buildActionForm() {
+	m.action = 0
+
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[int]().
+				Title(i18n.T("tui.calendar.what", m.picked.ItemName)).
+				Options(reservationActions(m.picked)...).
+				Value(&m.action).
+				Validate(func(action int) error {
+					return validateReservationAction(m.picked, action)
+				}),
+			huh.NewConfirm().
+				Title(i18n.T("tui.confirm")).
+				Negative(i18n.T("tui.no")).
+				Affirmative(i18n.T("tui.yes")).
+				Value(&m.confirmed),
+		),
+	).WithTheme(theme.Current().Huh())
+}
+
+func (m *CalendarModel) View() string {
+	if m.err != nil {
+		return errorText(m.err)
+	}
+
+	switch m.phase {
+	case 1:
+		return m.spinner.View() + " " + i18n.T("tui.calendar.loading", i18n.Default().Day(m.date))
+	case 3:
+		return m.form.View()
+	case 4:
+		return m.spinner.View() + " " + i18n.T("tui.calendar.updating")
+	}
+
+	title := lipgloss.NewStyle().Bold(true).Render("‹ " + i18n.Default().Day(m.date) + " ›")
+
+	notice := m.describeCell()
+	if m.notice != "" {
+		style := theme.Current().Success()
+		if m.failed {
+			style = theme.Current().Danger()
+		}
+
+		notice = style.Render(m.notice)
+	}
+
+	help := theme.Current().Muted().Render(
+		i18n.T("tui.calendar.help"),
+	)
+
+	return title + "\n\n" + m.renderGrid() + "\n\n" + notice + "\n\n" + help
+}
+
+// describeCell tells what the cell under the cursor, or the selected range,
+// holds.
+func (m *CalendarModel) describeCell() string {
+	room := m.grid.rooms[m.row]
+	start := m.grid.cellTime(m.col)
+	l := i18n.Default()
+
+	if m.anchor != -1 {
+		from, to := min(m.anchor, m.col), max(m.anchor, m.col)
+		minutes := (to - from + 1) * 15
+
+		return l.T(
+			"tui.calendar.selection",
+			room.Name,
+			l.Time(m.grid.cellTime(from)),
+			l.Time(m.grid.cellTime(to+1)),
+			room.Price*float64(minutes)/60,
+		)
+	}
+
+	state := l.T("tui.calendar.free")
+
+	switch m.grid.cells[m.row][m.col] {
+	case cellBusy:
+		state = l.T("tui.calendar.booked")
+	case cellUnknown:
+		state = l.T("tui.calendar.unknown")
+	case cellPast:
+		state = l.T("tui.calendar.past")
+	case cellOwn:
+		r := m.grid.reservations[m.row][m.col]
+		rStart, _ := time.ParseInLocation("2006-01-02T15:04:05", r.Start, m.date.Location())
+		rEnd, _ := time.ParseInLocation("2006-01-02T15:04:05", r.End, m.date.Location())
+		state = l.T("tui.calendar.yours", l.Time(rStart), l.Time(rEnd))
+	}
+
+	return l.T(
+		"tui.calendar.cell",
+		room.Name,
+		room.MaxUsers,
+		room.Price,
+		l.Time(start),
+		l.Time(start.Add(15*time.Minute)),
+		state,
+	)
+}
+
+func (m *CalendarModel) renderGrid() string {
+	labelLength := 0
+
+	for _, room := range m.grid.rooms {
+		labelLength = max(labelLength, len(room.Name)+1)
+	}
+
+	var b strings.Builder
+
+	b.WriteString(strings.Repeat(" ", labelLength+1))
+
+	for h := range calendarHours {
+		b.WriteString(fmt.Sprintf("%02dh  ", calendarStartHour+h))
+	}
+
+	t := theme.Current()
+	cursor := t.Cursor()
+	selected := t.Selected()
+	past := t.Muted()
+
+	for row, room := range m.grid.rooms {
+		label := room.Name + strings.Repeat(" ", labelLength-len(room.Name))
+		if row == m.row {
+			label = lipgloss.NewStyle().Bold(true).Render(label)
+		}
+
+		b.WriteString("\n" + label + "│")
+
+		for col, state := range m.grid.cells[row] {
+			symbol := " "
+
+			switch state {
+			case cellBusy:
+				symbol = t.Busy()
+			case cellOwn:
+				symbol = t.Own()
+			case cellUnknown:
+				symbol = t.Unknown()
+			case cellPast:
+				symbol = past.Render("·")
+			}
+
+			inRange := row == m.row && m.anchor != -1 &&
+				col >= min(m.anchor, m.col) && col <= max(m.anchor, m.col)
+
+			if row == m.row && col == m.col {
+				symbol = cursor.Render(symbol)
+			} else if inRange {
+				symbol = selected.Render(symbol)
+			}
+
+			b.WriteString(symbol)
+
+			if col%4 == 3 {
+				b.WriteString("│")
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func (m *CalendarModel) fetchDay() tea.Cmd {
+	date := m.date
+
+	return func() tea.Msg {
+		s, err := services.NewService()
+		if err != nil {
+			return calendarDataMsg{err: err}
+		}
+
+		if err := s.EnsureRoomsStored(); err != nil {
+			return calendarDataMsg{err: err}
+		}
+
+		rooms, err := s.GetRooms()
+		if err != nil {
+			return calendarDataMsg{err: err}
+		}
+
+		usages, err := s.GetRoomUsages(date)
+		if err != nil {
+			return calendarDataMsg{err: err}
+		}
+
+		user, err := s.GetAuthData()
+		if err != nil {
+			return calendarDataMsg{err: err}
+		}
+
+		apiClient := api.NewApi()
+		bookings, err := apiClient.GetFutureBookings(user.WAuth, user.WAuthRefresh)
+		if err != nil {
+			return calendarDataMsg{err: err}
+		}
+
+		return calendarDataMsg{rooms: rooms, usages: usages, bookings: bookings.Data}
+	}
+}
+
+func (m *CalendarModel) book() tea.Cmd {
+	request := m.booking
+
+	return func() tea.Msg {
+		s, err := services.NewService()
+		if err != nil {
+			return calendarActionMsg{err: err}
+		}
+
+		user, err := s.GetAuthData()
+		if err != nil {
+			return calendarActionMsg{err: err}
+		}
+
+		room, err := services.BookFirstAvailable(*user, request, nil)
+		if err != nil {
+			return calendarActionMsg{err: err}
+		}
+
+		l := i18n.Default()
+
+		return calendarActionMsg{message: l.T(
+			"tui.calendar.booked_from",
+			room.Name,
+			l.Time(request.DateTime),
+			l.Time(request.DateTime.Add(time.Duration(request.Duration)*time.Minute)),
+			request.Cost(*room),
+		)}
+	}
+}
+
+func (m *CalendarModel) updateReservation() tea.Cmd {
+	reservation, action := m.picked, m.action
+
+	return func() tea.Msg {
+		s, err := services.NewService()
+		if err != nil {
+			return calendarActionMsg{err: err}
+		}
+
+		if action == 0 {
+			_, credits, err := s.CancelReservation(reservation.OrderResourceRentId, false)
+			if err != nil {
+				return calendarActionMsg{err: err}
+			}
+
+			return calendarActionMsg{message: i18n.T("tui.calendar.cancelled", credits)}
+		}
+
+		_, credits, err := s.ResizeReservation(reservation.OrderResourceRentId, action, false)
+		if err != nil {
+			return calendarActionMsg{err: err}
+		}
+
+		if action > 0 {
+			return calendarActionMsg{message: i18n.T("tui.reservations.extended", credits)}
+		}
+
+		return calendarActionMsg{message: i18n.T("tui.reservations.shortened", math.Abs(credits))}
+	}
+}
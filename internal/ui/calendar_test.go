@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/storage"
+	"cosoft-cli/shared/models"
+	"testing"
+	"time"
+)
+
+func TestCalendarGrid(t *testing.T) {
+	date := time.Date(2026, 1, 27, 0, 0, 0, 0, time.Local)
+	rooms := []storage.Room{
+		{Id: "blue", Name: "Salle Bleue", Price: 10},
+		{Id: "green", Name: "Salle Verte", Price: 6},
+		{Id: "red", Name: "Salle Rouge", Price: 12},
+	}
+	usages := []models.RoomUsage{
+		{Id: "green", Name: "Salle Verte", UsedSlots: []models.UnavailableSlot{
+			{Start: "2026-01-27T10:00:00", End: "2026-01-27T11:00:00"},
+		}},
+		{Id: "blue", Name: "Salle Bleue", UsedSlots: []models.UnavailableSlot{
+			{Start: "2026-01-27T14:00:00", End: "2026-01-27T14:30:00"},
+		}},
+		{Id: "red", Name: "Salle Rouge", Unknown: true},
+	}
+	bookings := []api.Reservation{
+		{OrderResourceRentId: "own", ItemName: "Salle Bleue", Start: "2026-01-27T14:00:00", End: "2026-01-27T14:30:00"},
+	}
+
+	g := newCalendarGrid(date, date.Add(9*time.Hour), rooms, usages, bookings)
+
+	// Columns are quarter hours since 8:00.
+	cell := func(hour, minute int) int { return (hour-calendarStartHour)*4 + minute/15 }
+
+	tests := []struct {
+		name string
+		row  int
+		col  int
+		want cellState
+	}{
+		{name: "past", row: 0, col: cell(8, 45), want: cellPast},
+		{name: "free", row: 0, col: cell(9, 0), want: cellFree},
+		{name: "busy", row: 1, col: cell(10, 45), want: cellBusy},
+		{name: "busy_end_excluded", row: 1, col: cell(11, 0), want: cellFree},
+		{name: "own_over_busy", row: 0, col: cell(14, 15), want: cellOwn},
+		{name: "unknown", row: 2, col: cell(9, 0), want: cellUnknown},
+		{name: "unknown_past", row: 2, col: cell(8, 45), want: cellPast},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := g.cells[tt.row][tt.col]; got != tt.want {
+				t.Errorf("cells[%d][%d] = %v, want %v", tt.row, tt.col, got, tt.want)
+			}
+		})
+	}
+
+	if r := g.reservations[0][cell(14, 0)]; r == nil || r.OrderResourceRentId != "own" {
+		t.Errorf("reservations[0][14:00] = %v, want the own reservation", r)
+	}
+
+	ranges := []struct {
+		name     string
+		row      int
+		from, to int
+		wantErr  bool
+	}{
+		{name: "free", row: 1, from: cell(11, 0), to: cell(12, 45)},
+		{name: "reversed", row: 1, from: cell(12, 0), to: cell(11, 0)},
+		{name: "too_long", row: 1, from: cell(11, 0), to: cell(13, 0), wantErr: true},
+		{name: "over_busy", row: 1, from: cell(9, 30), to: cell(10, 0), wantErr: true},
+		{name: "over_past", row: 0, from: cell(8, 45), to: cell(9, 15), wantErr: true},
+		{name: "unknown", row: 2, from: cell(9, 0), to: cell(9, 30), wantErr: true},
+	}
+	for _, tt := range ranges {
+		t.Run("range_"+tt.name, func(t *testing.T) {
+			if err := g.checkRange(tt.row, tt.from, tt.to); (err != nil) != tt.wantErr {
+				t.Errorf("checkRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCalendarGridClocksChange(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	// The clocks go forward from 2:00 to 3:00 that night.
+	date := time.Date(2026, 3, 29, 0, 0, 0, 0, location)
+	rooms := []storage.Room{{Id: "green", Name: "Salle Verte", Price: 6}}
+	usages := []models.RoomUsage{
+		{Id: "green", Name: "Salle Verte", UsedSlots: []models.UnavailableSlot{
+			{Start: "2026-03-29T10:00:00", End: "2026-03-29T11:00:00"},
+		}},
+	}
+
+	g := newCalendarGrid(date, date, rooms, usages, nil)
+	cell := func(hour, minute int) int { return (hour-calendarStartHour)*4 + minute/15 }
+
+	if got := g.cellTime(cell(10, 0)); got.Hour() != 10 || got.Minute() != 0 {
+		t.Errorf("cellTime(10:00) = %v, want 10:00", got)
+	}
+
+	for col, want := range map[int]cellState{cell(9, 45): cellFree, cell(10, 0): cellBusy, cell(10, 45): cellBusy, cell(11, 0): cellFree} {
+		if got := g.cells[0][col]; got != want {
+			t.Errorf("cells[0][%d] = %v, want %v", col, got, want)
+		}
+	}
+}
@@ -1,9 +1,14 @@
 package components
 
 import (
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/theme"
+	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
+	"unicode"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -19,7 +24,8 @@ type Item[T comparable] struct {
 }
 
 // ListField is a huh-compatible field that displays a list of items
-// with labels and subtitles.
+// with labels and subtitles. Typing filters the items with a fuzzy search
+// over their label and subtitle, and the list scrolls to fit its height.
 type ListField[T comparable] struct {
 	value    *T
 	items    []Item[T]
@@ -28,6 +34,12 @@ type ListField[T comparable] struct {
 	focused  bool
 	selected bool
 
+	// filtering: the indexes of the items matching query, in display order.
+	// cursor and offset are positions in filtered.
+	query    string
+	filtered []int
+	offset   int
+
 	// huh configuration
 	key        string
 	width      int
@@ -40,18 +52,29 @@ type ListField[T comparable] struct {
 	// validation
 	validate func(T) error
 	err      error
+
+	// multiple selection, see Multiple.
+	values  *[]T
+	checked map[int]bool
+	group   func(T) string
+
+	// detail pane, see Detail.
+	detail func(T) string
 }
 
 // NewListField creates a new ListField with the given items and title.
 func NewListField[T comparable](items []Item[T], title string) *ListField[T] {
-	return &ListField[T]{
+	f := &ListField[T]{
 		items:    items,
 		title:    title,
 		cursor:   0,
 		width:    80,
-		height:   10,
+		height:   24,
 		validate: func(T) error { return nil },
 	}
+	f.filter()
+
+	return f
 }
 
 // Value sets the pointer to store the selected value.
@@ -72,12 +95,163 @@ func (f *ListField[T]) Title(title string) *ListField[T] {
 	return f
 }
 
-// Validate sets the validation function.
+// Validate sets the validation function. With multiple selection, it is
+// called for every selected item.
 func (f *ListField[T]) Validate(validate func(T) error) *ListField[T] {
 	f.validate = validate
 	return f
 }
 
+// Multiple allows selecting several items, stored in values in the list's
+// order: space toggles the item under the cursor, shift+up/down extend the
+// selection, and ctrl+a selects all or none of the listed items. Enter
+// without any selection picks the item under the cursor.
+func (f *ListField[T]) Multiple(values *[]T) *ListField[T] {
+	f.values = values
+	f.checked = map[int]bool{}
+	return f
+}
+
+// Group shows a header above each group of items, e.g. the day of
+// reservations. group returns the header, and items of a group are
+// expected to be next to each other. With Multiple, ctrl+g selects every
+// item in the same group as the one under the cursor.
+func (f *ListField[T]) Group(group func(T) string) *ListField[T] {
+	f.group = group
+	return f
+}
+
+// Detail shows, next to the list, what detail returns for the item under
+// the cursor.
+func (f *ListField[T]) Detail(detail func(T) string) *ListField[T] {
+	f.detail = detail
+	return f
+}
+
+// current returns the index of the item under the cursor, false when no
+// item matches the filter.
+func (f *ListField[T]) current() (int, bool) {
+	if f.cursor < 0 || f.cursor >= len(f.filtered) {
+		return 0, false
+	}
+
+	return f.filtered[f.cursor], true
+}
+
+// filter lists the items matching the query, moving the cursor to the first
+// one. Without groups, the best matches come first. Once the query is
+// cleared, the cursor stays on the item it was on.
+func (f *ListField[T]) filter() {
+	previous, hadCurrent := f.current()
+	scores := make(map[int]int, len(f.items))
+	f.filtered = f.filtered[:0]
+
+	for i, item := range f.items {
+		score, ok := fuzzyScore(f.query, item.Label+" "+item.Subtitle)
+		if !ok {
+			continue
+		}
+
+		scores[i] = score
+		f.filtered = append(f.filtered, i)
+	}
+
+	if f.group == nil && f.query != "" {
+		sort.SliceStable(f.filtered, func(a, b int) bool {
+			return scores[f.filtered[a]] > scores[f.filtered[b]]
+		})
+	}
+
+	f.cursor, f.offset = 0, 0
+
+	for pos, i := range f.filtered {
+		if f.query == "" && hadCurrent && i == previous {
+			f.cursor = pos
+		}
+	}
+}
+
+// fuzzyScore tells whether every character of query appears in text, in
+// order and ignoring case and spaces. Consecutive characters and ones
+// starting a word score higher.
+func fuzzyScore(query, text string) (int, bool) {
+	runes := []rune(strings.ToLower(text))
+	score, pos := 0, 0
+	previous := -2
+
+	for _, q := range strings.ToLower(query) {
+		if unicode.IsSpace(q) {
+			continue
+		}
+
+		for pos < len(runes) && runes[pos] != q {
+			pos++
+		}
+
+		if pos == len(runes) {
+			return 0, false
+		}
+
+		score++
+		if pos == previous+1 {
+			score += 2
+		}
+		if pos == 0 || !unicode.IsLetter(runes[pos-1]) && !unicode.IsDigit(runes[pos-1]) {
+			score += 3
+		}
+
+		previous = pos
+		pos++
+	}
+
+	return score, true
+}
+
+// selection returns the selected items' indexes, in the list's order.
+func (f *ListField[T]) selection() []int {
+	var indexes []int
+
+	for i := range f.items {
+		if f.checked[i] {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
+
+// submit stores the selection, validating every item.
+func (f *ListField[T]) submit() {
+	f.selected = true
+	f.err = nil
+
+	current, _ := f.current()
+
+	if f.values == nil {
+		if f.value != nil {
+			*f.value = f.items[current].Value
+		}
+		f.err = f.validate(f.items[current].Value)
+		return
+	}
+
+	if len(f.selection()) == 0 {
+		f.checked[current] = true
+	}
+
+	selected := make([]T, 0, len(f.checked))
+
+	for _, i := range f.selection() {
+		selected = append(selected, f.items[i].Value)
+
+		if err := f.validate(f.items[i].Value); err != nil && f.err == nil {
+			f.err = fmt.Errorf("%s: %w", f.items[i].Label, err)
+		}
+	}
+
+	*f.values = selected
+}
+
 // --- huh.Field interface implementation ---
 
 func (f *ListField[T]) Init() tea.Cmd {
@@ -88,90 +262,266 @@ func (f *ListField[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "up", "k":
+		case "up":
 			if f.cursor > 0 {
 				f.cursor--
 			}
-		case "down", "j":
-			if f.cursor < len(f.items)-1 {
+		case "down":
+			if f.cursor < len(f.filtered)-1 {
 				f.cursor++
 			}
 		case "enter":
-			if len(f.items) > 0 {
-				f.selected = true
-				if f.value != nil {
-					*f.value = f.items[f.cursor].Value
-				}
-				f.err = f.validate(f.items[f.cursor].Value)
+			if len(f.filtered) > 0 {
+				f.submit()
 				return f, func() tea.Msg { return huh.NextField() }
 			}
+		case "backspace":
+			if f.query != "" {
+				query := []rune(f.query)
+				f.query = string(query[:len(query)-1])
+				f.filter()
+			}
+		}
+
+		if msg.Type == tea.KeyRunes && !msg.Alt || msg.Type == tea.KeySpace && f.values == nil {
+			f.query += string(msg.Runes)
+			f.filter()
+		}
+
+		if f.values != nil && len(f.filtered) > 0 {
+			f.updateSelection(msg.String())
 		}
 	}
 	return f, nil
 }
 
+// updateSelection handles the multiple selection keys.
+func (f *ListField[T]) updateSelection(key string) {
+	current, _ := f.current()
+
+	switch key {
+	case " ":
+		f.checked[current] = !f.checked[current]
+	case "shift+up", "shift+down":
+		f.checked[current] = true
+
+		if key == "shift+up" && f.cursor > 0 {
+			f.cursor--
+		} else if key == "shift+down" && f.cursor < len(f.filtered)-1 {
+			f.cursor++
+		}
+
+		current, _ = f.current()
+		f.checked[current] = true
+	case "ctrl+a":
+		all := false
+
+		for _, i := range f.filtered {
+			all = all || !f.checked[i]
+		}
+
+		for _, i := range f.filtered {
+			f.checked[i] = all
+		}
+	case "ctrl+g":
+		if f.group == nil {
+			return
+		}
+
+		group := f.group(f.items[current].Value)
+
+		for _, i := range f.filtered {
+			if f.group(f.items[i].Value) == group {
+				f.checked[i] = true
+			}
+		}
+	}
+}
+
+// header returns the group header shown above the item at pos, if any: the
+// first item displayed always has one.
+func (f *ListField[T]) header(pos int) (string, bool) {
+	if f.group == nil {
+		return "", false
+	}
+
+	group := f.group(f.items[f.filtered[pos]].Value)
+
+	if pos > f.offset && f.group(f.items[f.filtered[pos-1]].Value) == group {
+		return "", false
+	}
+
+	return group, true
+}
+
+// lines returns how many lines the item at pos takes, with its header.
+func (f *ListField[T]) lines(pos int) int {
+	n := 2
+	if f.items[f.filtered[pos]].Subtitle != "" {
+		n++
+	}
+
+	if _, ok := f.header(pos); ok {
+		n++
+	}
+
+	return n
+}
+
+// scroll moves the first displayed item so that the cursor fits in budget
+// lines.
+func (f *ListField[T]) scroll(budget int) {
+	if f.cursor < f.offset {
+		f.offset = f.cursor
+	}
+
+	for f.offset < f.cursor {
+		used := 0
+		for pos := f.offset; pos <= f.cursor; pos++ {
+			used += f.lines(pos)
+		}
+
+		if used <= budget {
+			break
+		}
+
+		f.offset++
+	}
+}
+
 func (f *ListField[T]) View() string {
 	if len(f.items) == 0 {
-		return "No items available"
+		return i18n.T("tui.list.empty")
 	}
 
 	var b strings.Builder
 
 	// Styles
-	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("62")).
-		Bold(true)
+	t := theme.Current()
+	titleStyle := t.Title()
 
 	if f.theme != nil {
 		titleStyle = f.theme.Focused.Title
 	}
 
+	mutedStyle := t.Muted()
+
 	// Not focused: show condensed view (title + selected value)
 	if !f.focused {
-		if f.selected && f.title != "" {
-			selectedItem := f.items[f.cursor]
+		if current, ok := f.current(); ok && f.selected && f.title != "" {
+			label := f.items[current].Label
+			if count := len(f.selection()); f.values != nil && count > 1 {
+				label = i18n.T("tui.list.items", count)
+			}
+
 			b.WriteString(titleStyle.Render(f.title))
 			b.WriteString(" ")
-			b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render(selectedItem.Label))
+			b.WriteString(mutedStyle.Render(label))
 			b.WriteString("\n")
 		}
 		return b.String()
 	}
 
 	// Focused: show full list
+	budget := f.height
 	if f.title != "" {
 		b.WriteString(titleStyle.Render(f.title))
 		b.WriteString("\n\n")
+		budget -= 2
 	}
 
-	for i, item := range f.items {
-		isCursor := i == f.cursor
+	if f.query != "" {
+		b.WriteString(i18n.T("tui.list.filter", f.query))
+		b.WriteString(mutedStyle.Render(fmt.Sprintf(" (%d/%d)", len(f.filtered), len(f.items))))
+		b.WriteString("\n\n")
+		budget -= 2
+	}
+
+	if len(f.filtered) == 0 {
+		b.WriteString(mutedStyle.Render(i18n.T("tui.list.no_match")))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	// Keep a line for each scroll indicator.
+	f.scroll(max(budget-2, 1))
+
+	var list strings.Builder
+	used := 0
+
+	if f.offset > 0 {
+		list.WriteString(mutedStyle.Render(i18n.T("tui.list.more_above", f.offset)))
+		list.WriteString("\n")
+	}
+
+	pos := f.offset
+	for ; pos < len(f.filtered); pos++ {
+		if pos > f.cursor && used+f.lines(pos) > budget-2 {
+			break
+		}
+		used += f.lines(pos)
+
+		if header, ok := f.header(pos); ok {
+			list.WriteString(titleStyle.Render(header))
+			list.WriteString("\n")
+		}
+
+		i := f.filtered[pos]
+		item := f.items[i]
+		isCursor := pos == f.cursor
 
 		labelStyle := lipgloss.NewStyle().Padding(0, 2).Width(f.width / 2)
-		subtitleStyle := lipgloss.NewStyle().
+		subtitleStyle := t.Muted().
 			Padding(0, 2).
-			Width(f.width / 2).
-			Foreground(lipgloss.Color("8"))
+			Width(f.width / 2)
 
 		if isCursor {
-			labelStyle = labelStyle.
-				Background(lipgloss.Color("62")).
-				Foreground(lipgloss.Color("15")).
+			labelStyle = t.Cursor().
+				Inherit(labelStyle).
 				Bold(true)
-			subtitleStyle = subtitleStyle.
-				Background(lipgloss.Color("62")).
-				Foreground(lipgloss.Color("7"))
+			subtitleStyle = t.CursorSubtle().
+				Inherit(subtitleStyle)
 		}
 
-		b.WriteString(labelStyle.Render(item.Label))
-		b.WriteString("\n")
+		label := item.Label
+		if f.values != nil {
+			box := "[ ] "
+			if f.checked[i] {
+				box = "[x] "
+			}
+			label = box + label
+		}
+
+		list.WriteString(labelStyle.Render(label))
+		list.WriteString("\n")
 		if item.Subtitle != "" {
-			b.WriteString(subtitleStyle.Render(item.Subtitle))
-			b.WriteString("\n")
+			list.WriteString(subtitleStyle.Render(item.Subtitle))
+			list.WriteString("\n")
 		}
-		b.WriteString("\n")
+		list.WriteString("\n")
 	}
 
+	if rest := len(f.filtered) - pos; rest > 0 {
+		list.WriteString(mutedStyle.Render(i18n.T("tui.list.more_below", rest)))
+		list.WriteString("\n")
+	}
+
+	current, _ := f.current()
+	if f.detail == nil {
+		b.WriteString(list.String())
+		return b.String()
+	}
+
+	detail := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Color(t.Colors.Primary)).
+		Padding(0, 1).
+		Width(max(f.width/2-4, 10)).
+		Render(f.detail(f.items[current].Value))
+
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, list.String(), "  ", detail))
+	b.WriteString("\n")
+
 	return b.String()
 }
 
@@ -205,23 +555,22 @@ func (f *ListField[T]) RunAccessible(w io.Writer, r io.Reader) error {
 	}
 
 	var selection int
-	fmt.Fprint(w, "Enter selection number: ")
+	fmt.Fprint(w, i18n.T("tui.list.prompt"))
 	_, err := fmt.Fscanln(r, &selection)
 	if err != nil {
 		return err
 	}
 
 	if selection < 1 || selection > len(f.items) {
-		return fmt.Errorf("invalid selection: %d", selection)
+		return errors.New(i18n.T("tui.list.invalid", selection))
 	}
 
+	f.query = ""
+	f.filter()
 	f.cursor = selection - 1
-	f.selected = true
-	if f.value != nil {
-		*f.value = f.items[f.cursor].Value
-	}
+	f.submit()
 
-	return nil
+	return f.err
 }
 
 func (f *ListField[T]) Skip() bool {
@@ -233,11 +582,27 @@ func (f *ListField[T]) Zoom() bool {
 }
 
 func (f *ListField[T]) KeyBinds() []key.Binding {
-	return []key.Binding{
-		key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("up/k", "move up")),
-		key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("down/j", "move down")),
-		key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),
+	binds := []key.Binding{
+		key.NewBinding(key.WithKeys("up"), key.WithHelp("↑", i18n.T("tui.list.up"))),
+		key.NewBinding(key.WithKeys("down"), key.WithHelp("↓", i18n.T("tui.list.down"))),
+		key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", i18n.T("tui.list.select"))),
+		key.NewBinding(key.WithKeys("backspace"), key.WithHelp(i18n.T("tui.list.type"), i18n.T("tui.list.filter_help"))),
 	}
+
+	if f.values != nil {
+		binds = append(
+			binds,
+			key.NewBinding(key.WithKeys(" "), key.WithHelp("space", i18n.T("tui.list.toggle"))),
+			key.NewBinding(key.WithKeys("shift+up", "shift+down"), key.WithHelp("shift+↑/↓", i18n.T("tui.list.extend"))),
+			key.NewBinding(key.WithKeys("ctrl+a"), key.WithHelp("ctrl+a", i18n.T("tui.list.all"))),
+		)
+
+		if f.group != nil {
+			binds = append(binds, key.NewBinding(key.WithKeys("ctrl+g"), key.WithHelp("ctrl+g", i18n.T("tui.list.group"))))
+		}
+	}
+
+	return binds
 }
 
 func (f *ListField[T]) WithTheme(theme *huh.Theme) huh.Field {
@@ -275,11 +640,14 @@ func (f *ListField[T]) GetKey() string {
 }
 
 func (f *ListField[T]) GetValue() any {
+	if f.values != nil {
+		return *f.values
+	}
 	if f.value != nil {
 		return *f.value
 	}
-	if len(f.items) > 0 {
-		return f.items[f.cursor].Value
+	if current, ok := f.current(); ok {
+		return f.items[current].Value
 	}
 	return nil
 }
@@ -291,8 +659,9 @@ func (f *ListField[T]) IsSelected() bool {
 
 // SelectedItem returns the currently selected item.
 func (f *ListField[T]) SelectedItem() *Item[T] {
-	if len(f.items) == 0 {
+	current, ok := f.current()
+	if !ok {
 		return nil
 	}
-	return &f.items[f.cursor]
+	return &f.items[current]
 }
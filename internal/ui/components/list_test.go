@@ -0,0 +1,143 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestListFieldMultiple(t *testing.T) {
+	items := []Item[string]{
+		{Label: "a", Value: "mon-a"},
+		{Label: "b", Value: "mon-b"},
+		{Label: "c", Value: "tue-c"},
+		{Label: "d", Value: "tue-d"},
+	}
+
+	keys := map[string]tea.KeyMsg{
+		"down":       {Type: tea.KeyDown},
+		"up":         {Type: tea.KeyUp},
+		"space":      {Type: tea.KeySpace, Runes: []rune{' '}},
+		"shift+down": {Type: tea.KeyShiftDown},
+		"ctrl+a":     {Type: tea.KeyCtrlA},
+		"ctrl+g":     {Type: tea.KeyCtrlG},
+		"enter":      {Type: tea.KeyEnter},
+	}
+
+	tests := []struct {
+		name string
+		keys []string
+		want []string
+	}{
+		{name: "cursor_when_nothing_selected", keys: []string{"down", "enter"}, want: []string{"mon-b"}},
+		{name: "toggle", keys: []string{"space", "down", "down", "space", "enter"}, want: []string{"mon-a", "tue-c"}},
+		{name: "toggle_twice", keys: []string{"space", "space", "down", "space", "enter"}, want: []string{"mon-b"}},
+		{name: "range", keys: []string{"down", "shift+down", "shift+down", "enter"}, want: []string{"mon-b", "tue-c", "tue-d"}},
+		{name: "all_then_none", keys: []string{"ctrl+a", "ctrl+a", "enter"}, want: []string{"mon-a"}},
+		{name: "group", keys: []string{"down", "down", "ctrl+g", "enter"}, want: []string{"tue-c", "tue-d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+
+			f := NewListField(items, "Pick").
+				Multiple(&got).
+				Group(func(v string) string { day, _, _ := strings.Cut(v, "-"); return day })
+
+			for _, k := range tt.keys {
+				f.Update(keys[k])
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("values = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListFieldFilter(t *testing.T) {
+	items := []Item[string]{
+		{Label: "Salle Bleue", Subtitle: "10.00 credits", Value: "blue"},
+		{Label: "Salle Verte", Subtitle: "6.00 credits", Value: "green"},
+		{Label: "Salle Rouge", Subtitle: "12.00 credits", Value: "red"},
+		{Label: "Box", Subtitle: "2 people", Value: "box"},
+	}
+
+	keys := map[string]tea.KeyMsg{
+		"down":      {Type: tea.KeyDown},
+		"backspace": {Type: tea.KeyBackspace},
+		"enter":     {Type: tea.KeyEnter},
+	}
+
+	tests := []struct {
+		name string
+		keys []string
+		want string
+	}{
+		{name: "no_filter", keys: []string{"down", "enter"}, want: "green"},
+		{name: "subsequence", keys: []string{"r", "g", "enter"}, want: "red"},
+		{name: "case_insensitive", keys: []string{"V", "E", "enter"}, want: "green"},
+		{name: "subtitle", keys: []string{"p", "e", "o", "enter"}, want: "box"},
+		{name: "best_match_first", keys: []string{"r", "e", "enter"}, want: "red"},
+		{name: "move_in_results", keys: []string{"s", "a", "l", "down", "enter"}, want: "green"},
+		{name: "backspace_keeps_cursor", keys: []string{"r", "g", "backspace", "backspace", "down", "enter"}, want: "box"},
+		{name: "no_match", keys: []string{"z", "enter", "backspace", "enter"}, want: "blue"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+
+			f := NewListField(items, "Pick").Value(&got)
+
+			for _, k := range tt.keys {
+				msg, ok := keys[k]
+				if !ok {
+					msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+				}
+				f.Update(msg)
+			}
+
+			if got != tt.want {
+				t.Errorf("value = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListFieldView(t *testing.T) {
+	var items []Item[int]
+	for i := range 20 {
+		items = append(items, Item[int]{Label: fmt.Sprintf("item %02d", i), Subtitle: "subtitle", Value: i})
+	}
+
+	f := NewListField(items, "Pick").
+		Group(func(v int) string { return fmt.Sprintf("group %d", v/5) }).
+		Detail(func(v int) string { return fmt.Sprintf("detail %02d", v) })
+	f.WithHeight(20)
+	f.Focus()
+
+	for range 12 {
+		f.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+
+	view := f.View()
+
+	for _, want := range []string{"item 12", "detail 12", "group 2", "↑", "↓"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() doesn't contain %q:\n%s", want, view)
+		}
+	}
+
+	for _, unwanted := range []string{"item 00", "item 19"} {
+		if strings.Contains(view, unwanted) {
+			t.Errorf("View() contains %q, which is scrolled out:\n%s", unwanted, view)
+		}
+	}
+
+	if lines := strings.Count(view, "\n"); lines > 20 {
+		t.Errorf("View() is %d lines high, want at most 20", lines)
+	}
+}
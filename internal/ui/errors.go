@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/services"
+	"errors"
+)
+
+// localizedError is an error already worded in the user's language.
+type localizedError struct {
+	message string
+}
+
+func (e localizedError) Error() string {
+	return e.message
+}
+
+// localized returns the error worded by the catalog's key.
+func localized(key string, args ...any) error {
+	return localizedError{message: i18n.T(key, args...)}
+}
+
+// reasons words the errors of the booking services.
+var reasons = []struct {
+	err error
+	key string
+}{
+	{services.ErrDateInPast, "tui.reason.date_in_past"},
+	{services.ErrNotOnQuarter, "tui.reason.not_on_quarter"},
+	{services.ErrDurationNotQuarter, "tui.reason.duration_not_quarter"},
+	{services.ErrTooLong, "tui.reason.too_long"},
+	{services.ErrAfterClosing, "tui.reason.after_closing"},
+	{services.ErrNoRoomAvailable, "tui.reason.no_room"},
+	{services.ErrNoRoomWithFeatures, "tui.reason.no_room_with_features"},
+	{services.ErrRoomNotAvailable, "tui.reason.room_not_available"},
+	{services.ErrNotEnoughCredits, "tui.reason.not_enough_credits"},
+	{services.ErrResizeNotQuarter, "tui.reason.resize_not_quarter"},
+	{services.ErrSlotTaken, "tui.reason.slot_taken"},
+	{services.ErrAlreadyStarted, "tui.reason.already_started"},
+	{services.ErrEndBeforeStart, "tui.reason.end_before_start"},
+	{services.ErrEndInPast, "tui.reason.end_in_past"},
+	{services.ErrReservationNotFound, "tui.reason.not_found"},
+}
+
+// errorText words err in the user's language. Errors the services don't
+// document, from Cosoft or the network, are shown as unexpected along with
+// their details.
+func errorText(err error) string {
+	var l localizedError
+
+	if errors.As(err, &l) {
+		return err.Error()
+	}
+
+	for _, r := range reasons {
+		if errors.Is(err, r.err) {
+			return i18n.T(r.key)
+		}
+	}
+
+	return i18n.T("tui.reason.unexpected", err.Error())
+}
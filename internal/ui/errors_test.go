@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/services"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorText(t *testing.T) {
+	previous := i18n.Default()
+	i18n.SetDefault(i18n.French)
+	t.Cleanup(func() { i18n.SetDefault(previous) })
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "sentinel", err: services.ErrNotEnoughCredits, want: "pas assez de crédits"},
+		{
+			name: "wrapped_sentinel",
+			err:  fmt.Errorf("%w: Salle Bleue after 10:00", services.ErrSlotTaken),
+			want: "la salle est déjà réservée juste après",
+		},
+		{name: "localized", err: localized("tui.calendar.no_rooms"), want: i18n.French.T("tui.calendar.no_rooms")},
+		{name: "unexpected", err: errors.New("cosoft error 500"), want: "erreur inattendue : cosoft error 500"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorText(tt.err); got != tt.want {
+				t.Errorf("errorText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
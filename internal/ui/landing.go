@@ -2,7 +2,11 @@ package ui
 
 import (
 	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/i18n"
 	"cosoft-cli/internal/services"
+	"cosoft-cli/internal/settings"
+	"cosoft-cli/internal/storage"
+	"cosoft-cli/internal/theme"
 	"time"
 
 	"cosoft-cli/shared/models"
@@ -11,7 +15,6 @@ import (
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
-	"github.com/charmbracelet/lipgloss"
 )
 
 type LandingModel struct {
@@ -24,7 +27,9 @@ type LandingModel struct {
 	loading         bool
 	loadingCalendar bool
 	roomsReady      bool
-	err             error
+	// notice tells about the new prices of the favorite rooms, if any.
+	notice string
+	err    error
 }
 
 type futureBookingMsg struct {
@@ -42,7 +47,9 @@ type calendarMsg struct {
 }
 
 type roomsReadyMsg struct {
-	err error
+	// repriced are the favorite rooms whose price changed.
+	repriced []storage.RoomChange
+	err      error
 }
 
 type startFetchingMsg struct{}
@@ -52,11 +59,11 @@ func NewLandingModel() *LandingModel {
 
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("63"))
+	s.Style = theme.Current().Spinner()
 
 	cs := spinner.New()
 	cs.Spinner = spinner.Dot
-	cs.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("63"))
+	cs.Style = theme.Current().Spinner()
 
 	m := &LandingModel{
 		selection:       selection,
@@ -73,26 +80,27 @@ func NewLandingModel() *LandingModel {
 }
 
 func (m *LandingModel) buildForm() {
-	resaLabel := "My reservations"
+	resaLabel := i18n.T("tui.landing.reservations")
 	if m.futureBookings != nil {
-		resaLabel = fmt.Sprintf("My reservations (%d)", m.futureBookings.Total)
+		resaLabel = i18n.T("tui.landing.reservations_count", m.futureBookings.Total)
 	}
 
 	m.form = huh.NewForm(
 		huh.NewGroup(
 			huh.NewSelect[string]().
-				Title("What's the plan?").
+				Title(i18n.T("tui.landing.plan")).
 				Options(
-					huh.NewOption("Quick book", "quick-book"),
-					huh.NewOption("Browse & book", "browse"),
+					huh.NewOption(i18n.T("tui.landing.quick_book"), "quick-book"),
+					huh.NewOption(i18n.T("tui.landing.browse"), "browse"),
+					huh.NewOption(i18n.T("tui.landing.calendar"), "calendar"),
 					huh.NewOption(resaLabel, "reservations"),
-					huh.NewOption("Previous reservations", "history"),
-					huh.NewOption("Settings", "settings"),
-					huh.NewOption("Quit", "quit"),
+					huh.NewOption(i18n.T("tui.landing.history"), "history"),
+					huh.NewOption(i18n.T("tui.landing.settings"), "settings"),
+					huh.NewOption(i18n.T("tui.landing.quit"), "quit"),
 				).
 				Value(&m.selection.Choice),
 		),
-	)
+	).WithTheme(theme.Current().Huh())
 }
 
 func (m *LandingModel) Init() tea.Cmd {
@@ -154,9 +162,20 @@ func (m *LandingModel) ensureRoomsStored() tea.Cmd {
 			return roomsReadyMsg{err: err}
 		}
 
-		err = authService.EnsureRoomsStored()
+		changes, err := authService.RefreshStaleRooms()
+
+		if err != nil {
+			return roomsReadyMsg{err: err}
+		}
+
+		// The favorites only add a notice, the rooms are ready regardless.
+		config, err := settings.LoadConfig()
 
-		return roomsReadyMsg{err: err}
+		if err != nil {
+			return roomsReadyMsg{}
+		}
+
+		return roomsReadyMsg{repriced: services.FavoritePriceChanges(changes, config.FavoriteRooms)}
 	}
 }
 
@@ -188,6 +207,11 @@ func (m *LandingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.roomsReady = true
+
+		for _, c := range msg.repriced {
+			m.notice += theme.Current().Warning().Render(services.DescribeFavoritePriceChange(c, i18n.Default())) + "\n"
+		}
+
 		if m.futureBookings != nil {
 			return m, m.getCalendarView()
 		}
@@ -204,7 +228,7 @@ func (m *LandingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.calendar = msg.calendar
 
 	case updatedCreditsMsg:
-		credits := fmt.Sprintf("Credits: %.02f", msg.credits)
+		credits := i18n.T("tui.credits", msg.credits)
 		return m, func() tea.Msg {
 			return UpdateHeaderMsg{Credits: &credits}
 		}
@@ -242,22 +266,26 @@ func (m *LandingModel) View() string {
 	// var loadingMenu string
 
 	if m.loadingCalendar {
-		loadingCalendar = fmt.Sprintf("%s Loading calendar informations...\n\n", m.calendarSpinner.View())
+		loadingCalendar = fmt.Sprintf("%s %s\n\n", m.calendarSpinner.View(), i18n.T("tui.landing.loading_calendar"))
 	}
 
 	if m.calendar != "" {
 		calendar = m.calendar + "\n\n"
 	}
 
+	if m.notice != "" {
+		calendar = m.notice + "\n" + calendar
+	}
+
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s\nPress q to quit", m.err.Error())
+		return i18n.T("tui.error_quit", errorText(m.err))
 	}
 
 	if m.loading {
-		return loadingCalendar + fmt.Sprintf("\n %s Loading...\n", m.spinner.View())
+		return loadingCalendar + fmt.Sprintf("\n %s %s\n", m.spinner.View(), i18n.T("tui.loading"))
 	}
 	if m.form == nil {
-		return "Error: form is nil"
+		return i18n.T("tui.landing.form_nil")
 	}
 	return loadingCalendar + calendar + m.form.View()
 }
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"cosoft-cli/internal/theme"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -34,15 +35,18 @@ type LayoutConfig struct {
 	FooterColor string
 }
 
-// DefaultLayoutConfig returns a sensible default configuration
+// DefaultLayoutConfig returns a sensible default configuration, colored
+// after the current theme
 func DefaultLayoutConfig() LayoutConfig {
+	colors := theme.Current().Colors
+
 	return LayoutConfig{
 		ShowHeader:  true,
 		ShowFooter:  true,
 		ShowBorder:  true,
-		BorderColor: "#fd4b4b",
-		HeaderColor: "#f45656",
-		FooterColor: "#888888",
+		BorderColor: colors.Accent,
+		HeaderColor: colors.Header,
+		FooterColor: colors.Muted,
 	}
 }
 
@@ -78,16 +82,18 @@ func NewLayoutWithDefaults(content tea.Model, headerLeft, footer string) *Layout
 }
 
 func (l *Layout) updateStyles() {
-	// Header style - no Width set, we control width via content string length
-	l.headerStyle = lipgloss.NewStyle().
+	t := theme.Current()
+
+	// Header style - no Width set, we control width via content string length.
+	// Without colors, reverse video keeps it apart from the content
+	l.headerStyle = t.Bg(l.config.HeaderColor, lipgloss.NewStyle().Reverse(true)).
 		Bold(true).
-		Foreground(lipgloss.Color("#FAFAFA")).
-		Background(lipgloss.Color(l.config.HeaderColor)).
+		Foreground(t.Color(t.Colors.Text)).
 		Padding(0, 1)
 
 	// Footer style
 	l.footerStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color(l.config.FooterColor)).
+		Foreground(t.Color(l.config.FooterColor)).
 		Padding(0, 1).
 		Width(l.width)
 
@@ -99,7 +105,7 @@ func (l *Layout) updateStyles() {
 		}
 		l.containerStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color(l.config.BorderColor)).
+			BorderForeground(t.Color(l.config.BorderColor)).
 			Padding(1, 2).
 			Width(contentWidth)
 	} else if contentWidth > 0 {
@@ -114,7 +120,7 @@ func (l *Layout) updateStyles() {
 		if l.config.ShowBorder {
 			l.containerStyle = lipgloss.NewStyle().
 				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color(l.config.BorderColor)).
+				BorderForeground(t.Color(l.config.BorderColor)).
 				Padding(1, 2)
 		} else {
 			l.containerStyle = lipgloss.NewStyle().
@@ -2,6 +2,8 @@ package ui
 
 import (
 	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/theme"
 	"errors"
 	"fmt"
 
@@ -35,7 +37,7 @@ func (m *LoginModel) Init() tea.Cmd {
 
 func required(s string) error {
 	if s == "" {
-		return errors.New("field is required")
+		return errors.New(i18n.T("tui.login.required"))
 	}
 
 	return nil
@@ -62,19 +64,19 @@ func NewLoginModel() *LoginModel {
 	form := huh.NewForm(
 		huh.NewGroup(
 			huh.NewNote().
-				Title("Login").
-				Description("Please insert your credentials to authenticate"),
+				Title(i18n.T("tui.login.title")).
+				Description(i18n.T("tui.login.description")),
 			huh.NewInput().
 				Validate(required).
-				Title("Email address").
+				Title(i18n.T("tui.login.email")).
 				Value(&creds.Email),
 			huh.NewInput().
 				EchoMode(huh.EchoModePassword).
 				Validate(required).
-				Title("Password").
+				Title(i18n.T("tui.login.password")).
 				Value(&creds.Password),
 		),
-	)
+	).WithTheme(theme.Current().Huh())
 
 	s := spinner.New()
 	s.Spinner = spinner.Dot
@@ -109,19 +111,19 @@ func (m *LoginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.form = huh.NewForm(
 			huh.NewGroup(
 				huh.NewNote().
-					Title("Login").
-					Description("Please insert your credentials to authenticate"),
+					Title(i18n.T("tui.login.title")).
+					Description(i18n.T("tui.login.description")),
 				huh.NewInput().
 					Validate(required).
-					Title("Email address").
+					Title(i18n.T("tui.login.email")).
 					Value(&m.credentials.Email),
 				huh.NewInput().
 					EchoMode(huh.EchoModePassword).
 					Validate(required).
-					Title("Password").
+					Title(i18n.T("tui.login.password")).
 					Value(&m.credentials.Password),
 			),
-		)
+		).WithTheme(theme.Current().Huh())
 		return m, m.form.Init()
 	case spinner.TickMsg:
 		if m.loading {
@@ -159,11 +161,11 @@ func (m *LoginModel) View() string {
 	}
 
 	if m.loading {
-		return fmt.Sprintf("\n%s Logging in...", m.spinner.View())
+		return fmt.Sprintf("\n%s %s", m.spinner.View(), i18n.T("tui.login.logging_in"))
 	}
 
 	if m.err != nil {
-		return m.form.View() + fmt.Sprintf("\n\n❌ Error: %v\n", m.err)
+		return m.form.View() + "\n\n" + i18n.T("tui.login.error", m.err) + "\n"
 	}
 
 	return m.form.View()
@@ -185,8 +187,8 @@ func (ui *UI) LoginForm() (*LoginModel, error) {
 	// Wrap in layout
 	layout := NewLayoutWithDefaults(
 		loginModel,
-		"COSOFT CLI - Authentication",
-		"Press Ctrl+C to cancel",
+		i18n.T("tui.login.header"),
+		i18n.T("tui.login.footer"),
 	)
 
 	p := tea.NewProgram(layout)
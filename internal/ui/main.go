@@ -1,10 +1,12 @@
 package ui
 
 import (
+	"cosoft-cli/internal/api/sandbox"
 	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/i18n"
 	"cosoft-cli/internal/services"
+	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -23,8 +25,11 @@ func (ui *UI) StartApp(startPage string, allowBackNav bool) error {
 
 	config := DefaultLayoutConfig()
 	config.Header.Left = "COSOFT CLI"
-	config.Header.Center = strings.ToUpper(startPage[:1]) + startPage[1:] // Initial location
-	config.Footer = "Press Ctrl + C to cancel"
+	if sandbox.Enabled() {
+		config.Header.Left = "COSOFT CLI (SANDBOX)"
+	}
+	config.Header.Center = i18n.T("tui.page." + startPage) // Initial location
+	config.Footer = i18n.T("tui.footer")
 
 	// Try to get user info
 	authService, err := services.NewService()
@@ -34,7 +39,7 @@ func (ui *UI) StartApp(startPage string, allowBackNav bool) error {
 
 	if user, err := authService.GetAuthData(); err == nil {
 		config.Header.Right = fmt.Sprintf("%s %s (%s)", user.FirstName, user.LastName, user.Email)
-		config.Header.Credits = fmt.Sprintf("Credits: %.02f", user.Credits)
+		config.Header.Credits = i18n.T("tui.credits", user.Credits)
 	}
 
 	layout := NewLayout(appModel, config)
@@ -48,7 +53,7 @@ func (ui *UI) StartApp(startPage string, allowBackNav bool) error {
 
 func validateDateIsFuture(s string) error {
 	if s == "" {
-		return fmt.Errorf("date is required")
+		return errors.New(i18n.T("tui.validate.date_required"))
 	}
 
 	location, err := common.LoadLocalTime()
@@ -58,14 +63,14 @@ func validateDateIsFuture(s string) error {
 
 	date, err := time.ParseInLocation(time.DateOnly, s, location)
 	if err != nil {
-		return fmt.Errorf("date could not be parsed")
+		return errors.New(i18n.T("tui.validate.date_invalid"))
 	}
 
 	// Compute the today's date and compare it with the input.
 	now := time.Now()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 	if date.Before(today) {
-		return fmt.Errorf("date is not in the future")
+		return errors.New(i18n.T("tui.validate.date_past"))
 	}
 
 	return nil
@@ -73,24 +78,24 @@ func validateDateIsFuture(s string) error {
 
 func validateHour(s string) error {
 	if s == "" {
-		return fmt.Errorf("hour is required")
+		return errors.New(i18n.T("tui.validate.hour_required"))
 	}
 
 	h, err := time.Parse(timeOnlyFormat, s)
 	if err != nil {
-		return fmt.Errorf("could not parse time")
+		return errors.New(i18n.T("tui.validate.hour_invalid"))
 	}
 
 	hours := h.Hour()
 
 	if hours < 8 || hours > 20 {
-		return fmt.Errorf("hours outside opening hours")
+		return errors.New(i18n.T("tui.validate.opening_hours"))
 	}
 
 	minutes := h.Minute()
 
 	if minutes%15 != 0 {
-		return fmt.Errorf("minutes not rounded to quarters")
+		return errors.New(i18n.T("tui.validate.quarter"))
 	}
 
 	return nil
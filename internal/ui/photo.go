@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"bufio"
+	"cosoft-cli/internal/i18n"
+	"cosoft-cli/internal/termimage"
+	"cosoft-cli/internal/theme"
+	"cosoft-cli/shared/models"
+	"fmt"
+	"io"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// photoWidth is how many cells wide photos are displayed.
+const photoWidth = 60
+
+type photoShownMsg struct {
+	err error
+}
+
+// photoCommand shows a room's photo until enter is pressed, with the TUI
+// suspended: bubbletea repaints and truncates its lines as it sees fit,
+// which would break a picture drawn within a frame.
+type photoCommand struct {
+	room     models.Room
+	protocol termimage.Protocol
+	stdin    io.Reader
+	stdout   io.Writer
+}
+
+// showPhoto suspends the TUI to show the room's photo.
+func showPhoto(room models.Room, protocol termimage.Protocol) tea.Cmd {
+	command := &photoCommand{room: room, protocol: protocol, stdin: os.Stdin, stdout: os.Stdout}
+
+	return tea.Exec(command, func(err error) tea.Msg {
+		return photoShownMsg{err: err}
+	})
+}
+
+func (c *photoCommand) SetStdin(r io.Reader)  { c.stdin = r }
+func (c *photoCommand) SetStdout(w io.Writer) { c.stdout = w }
+func (c *photoCommand) SetStderr(io.Writer)   {}
+
+func (c *photoCommand) Run() error {
+	picture, err := termimage.Fetch(c.room.Image)
+
+	if err != nil {
+		return err
+	}
+
+	encoded, err := termimage.Encode(c.protocol, picture, photoWidth)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(
+		c.stdout,
+		"\x1b[2J\x1b[H%s\n\n%s\n\n%s",
+		theme.Current().Title().Render(c.room.Name),
+		encoded,
+		theme.Current().Muted().Render(i18n.T("tui.photo.back")),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = bufio.NewReader(c.stdin).ReadString('\n')
+	fmt.Fprint(c.stdout, termimage.Clear(c.protocol))
+
+	return err
+}
@@ -3,7 +3,9 @@ package ui
 import (
 	"cosoft-cli/internal/api"
 	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/i18n"
 	"cosoft-cli/internal/services"
+	"cosoft-cli/internal/theme"
 	"cosoft-cli/internal/ui/components"
 	"cosoft-cli/shared/models"
 	"fmt"
@@ -13,7 +15,6 @@ import (
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
-	"github.com/charmbracelet/lipgloss"
 )
 
 type QuickBookModel struct {
@@ -44,7 +45,7 @@ func NewQuickBookModel() *QuickBookModel {
 	selection := &api.CosoftAvailabilityPayload{}
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("63"))
+	s.Style = theme.Current().Spinner()
 
 	p := progress.New(
 		progress.WithDefaultGradient(),
@@ -132,11 +133,11 @@ func (qb *QuickBookModel) View() string {
 
 		switch qb.bookPhase {
 		case 1:
-			header = qb.spinner.View() + " Looking for available rooms... \n\n"
+			header = qb.spinner.View() + " " + i18n.T("tui.booking.looking") + " \n\n"
 		case 2:
-			header = qb.spinner.View() + " Found a meeting room. Booking now... \n\n"
+			header = qb.spinner.View() + " " + i18n.T("tui.quick_book.booking") + " \n\n"
 		case 3:
-			header = lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Render("✓ Booking complete!") + "\n\n"
+			header = theme.Current().Success().Render(i18n.T("tui.booking.complete")) + "\n\n"
 		}
 
 		p := qb.progress.View()
@@ -145,58 +146,59 @@ func (qb *QuickBookModel) View() string {
 		var toolTip string
 		if qb.bookPhase == 3 && qb.bookedRoom != nil {
 			t = qb.generateTable()
-			toolTip = "You can now press \"ESC\" to go back to the main menu."
+			toolTip = i18n.T("tui.esc_to_menu")
 		}
 
 		errMsg := ""
 		if qb.err != nil {
-			errMsg = "\n\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("5")).Render(qb.err.Error())
+			errMsg = "\n\n" + theme.Current().Danger().Render(errorText(qb.err))
 		}
 
 		return header + p + t + toolTip + errMsg
 
 	default:
-		return "Booking"
+		return i18n.T("tui.quick_book.title")
 	}
 }
 
 func (qb *QuickBookModel) buildForm() {
 	t := common.GetClosestQuarterHour()
+	l := i18n.Default()
 
 	durations := []components.Item[int]{
 		{
-			Label:    "30 minutes",
-			Subtitle: fmt.Sprintf("From %s to %s", t.Format("15:04"), t.Add(30*time.Minute).Format("15:04")),
+			Label:    l.T("tui.quick_book.duration_30"),
+			Subtitle: l.T("tui.quick_book.from_to", l.Time(t), l.Time(t.Add(30*time.Minute))),
 			Value:    30,
 		},
 		{
-			Label:    "1 hour",
-			Subtitle: fmt.Sprintf("From %s to %s", t.Format("15:04"), t.Add(60*time.Minute).Format("15:04")),
+			Label:    l.T("tui.quick_book.duration_60"),
+			Subtitle: l.T("tui.quick_book.from_to", l.Time(t), l.Time(t.Add(60*time.Minute))),
 			Value:    60,
 		},
 	}
 
 	peoples := []components.Item[int]{
 		{
-			Label:    "1 person",
-			Subtitle: "The research will include callboxes",
+			Label:    l.T("tui.people.one"),
+			Subtitle: l.T("tui.people.one_hint"),
 			Value:    1,
 		},
 		{
-			Label:    "2 persons or more",
-			Subtitle: "The research will default to classic meeting rooms",
+			Label:    l.T("tui.people.two"),
+			Subtitle: l.T("tui.people.two_hint"),
 			Value:    2,
 		},
 	}
 
 	qb.form = huh.NewForm(
 		huh.NewGroup(
-			components.NewListField(durations, "For how long?").
+			components.NewListField(durations, l.T("tui.quick_book.how_long")).
 				Value(&qb.payload.Duration),
-			components.NewListField(peoples, "For how many people?").
+			components.NewListField(peoples, l.T("tui.people.how_many")).
 				Value(&qb.payload.NbPeople),
 		),
-	).WithLayout(huh.LayoutStack)
+	).WithTheme(theme.Current().Huh()).WithLayout(huh.LayoutStack)
 }
 
 func (qb *QuickBookModel) getRoomsAvailability() tea.Cmd {
@@ -229,7 +231,7 @@ func (qb *QuickBookModel) getRoomsAvailability() tea.Cmd {
 		}
 
 		if len(rooms) == 0 {
-			return bookingFailedMsg{err: fmt.Errorf("no room available for the selected time")}
+			return bookingFailedMsg{err: localized("tui.quick_book.no_room")}
 		}
 
 		return roomFetchedMsg{availableRooms: rooms}
@@ -260,11 +262,11 @@ func (qb *QuickBookModel) bookRoom() tea.Cmd {
 		}
 
 		if pickedRoom == nil {
-			return bookingFailedMsg{err: fmt.Errorf("no room suiting user's selection, aborting")}
+			return bookingFailedMsg{err: localized("tui.booking.no_suiting_room")}
 		}
 
 		if user.Credits < pickedRoom.Price {
-			return bookingFailedMsg{err: fmt.Errorf("not enough credits to perfor, the booking, aborting")}
+			return bookingFailedMsg{err: localized("tui.booking.not_enough_credits")}
 		}
 
 		payload := api.CosoftBookingPayload{
@@ -292,17 +294,17 @@ func (qb *QuickBookModel) bookRoom() tea.Cmd {
 func (qb *QuickBookModel) generateTable() string {
 	startTime := qb.payload.DateTime
 	endTime := startTime.Add(time.Duration(qb.payload.Duration) * time.Minute)
-	dateFormat := "02/01/2006 15:04"
 
 	paidPrice := qb.bookedRoom.Price * (float64(qb.payload.Duration) / 60)
 
-	headers := []string{"ROOM", "DURATION", "COST"}
+	l := i18n.Default()
+	headers := []string{l.T("tui.table.room"), l.T("tui.table.duration"), l.T("tui.table.cost")}
 
 	rows := [][]string{
 		{
 			qb.bookedRoom.Name,
-			fmt.Sprintf("%s → %s", startTime.Format(dateFormat), endTime.Format(dateFormat)),
-			fmt.Sprintf("%.2f credits", paidPrice),
+			fmt.Sprintf("%s → %s", l.DateTime(startTime), l.DateTime(endTime)),
+			l.T("tui.credits_amount", paidPrice),
 		},
 	}
 
@@ -3,10 +3,13 @@ package ui
 import (
 	"cosoft-cli/internal/api"
 	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/i18n"
 	"cosoft-cli/internal/services"
+	"cosoft-cli/internal/theme"
 	"cosoft-cli/internal/ui/components"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
@@ -16,22 +19,33 @@ import (
 )
 
 type ReservationListModel struct {
-	phase             int
-	confirmed         bool
-	reservations      api.FutureBookingsResponse
-	pickedReservation api.Reservation
-	form              *huh.Form
-	spinner           spinner.Model
-	err               error
-	location          *time.Location
+	phase              int
+	confirmed          bool
+	reservations       api.FutureBookingsResponse
+	pickedReservations []api.Reservation
+	// action is how many minutes to move the reservation's end by, 0
+	// meaning it is cancelled. Several reservations can only be cancelled.
+	action   int
+	credits  float64
+	results  []services.CancellationResult
+	form     *huh.Form
+	spinner  spinner.Model
+	err      error
+	location *time.Location
 }
 
-type cancelComplete struct{}
+type cancelComplete struct {
+	results []services.CancellationResult
+}
+
+type resizeComplete struct {
+	credits float64
+}
 
 func NewReservationListModel() *ReservationListModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("63"))
+	s.Style = theme.Current().Spinner()
 
 	return &ReservationListModel{
 		phase:     1,
@@ -74,6 +88,12 @@ func (rl *ReservationListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return rl, rl.form.Init()
 
 	case cancelComplete:
+		rl.results = msg.results
+		rl.phase = 4
+		return rl, nil
+
+	case resizeComplete:
+		rl.credits = msg.credits
 		rl.phase = 4
 		return rl, nil
 	}
@@ -89,8 +109,17 @@ func (rl *ReservationListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	if rl.form.State == huh.StateCompleted {
+		if !rl.confirmed {
+			return rl, func() tea.Msg { return BackToMenuMsg{} }
+		}
+
 		rl.phase = 3
-		return rl, tea.Batch(rl.spinner.Tick, rl.cancelReservation())
+
+		if rl.action == 0 {
+			return rl, tea.Batch(rl.spinner.Tick, rl.cancelReservation())
+		}
+
+		return rl, tea.Batch(rl.spinner.Tick, rl.resizeReservation())
 	}
 
 	return rl, cmd
@@ -98,29 +127,43 @@ func (rl *ReservationListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (rl *ReservationListModel) View() string {
 	if rl.err != nil {
-		return rl.err.Error()
+		return errorText(rl.err)
 	}
 
 	switch rl.phase {
 	case 1:
-		return rl.spinner.View() + " Loading reservations..."
+		return rl.spinner.View() + " " + i18n.T("tui.reservations.loading")
 	case 2:
 		if len(rl.reservations.Data) == 0 {
-			return "No reservations found \n\n Press \"ESC\" to go back to the main menu."
+			return i18n.T("tui.reservations.none")
 		}
 		return rl.form.View()
 	case 3:
-		return rl.spinner.View() + " Cancelling reservations..."
+		if rl.action != 0 {
+			return rl.spinner.View() + " " + i18n.T("tui.reservations.updating")
+		}
+		return rl.spinner.View() + " " + i18n.T("tui.reservations.cancelling")
 	case 4:
-		success := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("42")).
-			Render("✓ Cancellation complete!")
+		message := i18n.T("tui.reservations.cancelled")
 
-		tooltip := "You can now press \"ESC\" to go back to the main menu."
+		if len(rl.results) > 1 {
+			return rl.cancellationSummary()
+		}
+
+		if rl.action > 0 {
+			message = i18n.T("tui.reservations.extended", rl.credits)
+		} else if rl.action < 0 {
+			message = i18n.T("tui.reservations.shortened", -rl.credits)
+		}
+
+		success := theme.Current().Success().
+			Render(message)
+
+		tooltip := i18n.T("tui.esc_to_menu")
 
 		return success + "\n\n" + tooltip
 	default:
-		return "Reservations"
+		return i18n.T("tui.reservations.title")
 
 	}
 }
@@ -132,7 +175,7 @@ func (rl *ReservationListModel) buildForm() error {
 	}
 	rl.location = location
 
-	dateFormat := "02/01/2006 15:04"
+	l := i18n.Default()
 	var list []components.Item[api.Reservation]
 
 	for _, r := range rl.reservations.Data {
@@ -152,10 +195,10 @@ func (rl *ReservationListModel) buildForm() error {
 		list = append(list, components.Item[api.Reservation]{
 			Label: r.ItemName,
 			Value: r,
-			Subtitle: fmt.Sprintf(
-				"%s → %s · %.02f credits",
-				parsedStart.Format(dateFormat),
-				parsedEnd.Format(dateFormat),
+			Subtitle: l.T(
+				"tui.reservations.subtitle",
+				l.DateTime(parsedStart),
+				l.DateTime(parsedEnd),
 				paidPrice,
 			),
 		})
@@ -163,39 +206,174 @@ func (rl *ReservationListModel) buildForm() error {
 
 	rl.form = huh.NewForm(
 		huh.NewGroup(
-			components.NewListField(list, "Pick a reservation to cancel it").
-				Value(&rl.pickedReservation).
-				Validate(validateReservation),
+			components.NewListField(list, l.T("tui.reservations.pick")).
+				Multiple(&rl.pickedReservations).
+				Group(reservationDay).
+				Detail(rl.reservationDetail),
+			huh.NewSelect[int]().
+				Title(l.T("tui.reservations.what")).
+				OptionsFunc(rl.actionOptions, &rl.pickedReservations).
+				Value(&rl.action).
+				Validate(rl.validateAction),
 			huh.NewConfirm().
-				Title("Confirm cancellation?").
-				Negative("No").
-				Affirmative("Yes").
+				Title(l.T("tui.confirm")).
+				Negative(l.T("tui.no")).
+				Affirmative(l.T("tui.yes")).
 				Value(&rl.confirmed),
 		),
-	)
+	).WithTheme(theme.Current().Huh())
 
 	return nil
 }
 
-func (rl *ReservationListModel) cancelReservation() tea.Cmd {
+// actionOptions only offers to cancel several reservations at once.
+func (rl *ReservationListModel) actionOptions() []huh.Option[int] {
+	if len(rl.pickedReservations) == 1 {
+		return reservationActions(rl.pickedReservations[0])
+	}
+
+	return []huh.Option[int]{
+		huh.NewOption(i18n.T("tui.reservations.cancel_several", len(rl.pickedReservations)), 0),
+	}
+}
+
+func (rl *ReservationListModel) validateAction(action int) error {
+	for _, r := range rl.pickedReservations {
+		if err := validateReservationAction(r, action); err != nil {
+			return fmt.Errorf("%s: %w", r.ItemName, err)
+		}
+	}
+
+	return nil
+}
+
+// reservationDay groups reservations by the day they start.
+func reservationDay(r api.Reservation) string {
+	day, _, _ := strings.Cut(r.Start, "T")
+
+	parsed, err := time.Parse("2006-01-02", day)
+	if err != nil {
+		return day
+	}
+
+	return i18n.Default().Day(parsed)
+}
+
+// reservationDetail describes the reservation under the list's cursor.
+func (rl *ReservationListModel) reservationDetail(r api.Reservation) string {
+	start, errStart := rl.parseDate(r.Start)
+	end, errEnd := rl.parseDate(r.End)
+	if errStart != nil || errEnd != nil {
+		return r.ItemName
+	}
+
+	l := i18n.Default()
+	minutes := end.Sub(start).Minutes()
+	status := l.T("tui.reservations.upcoming")
+	if start.Before(time.Now()) {
+		status = l.T("tui.reservations.in_progress")
+	}
+
+	return strings.Join([]string{
+		lipgloss.NewStyle().Bold(true).Render(r.ItemName),
+		"",
+		l.T("tui.reservations.date", l.Date(start)),
+		l.T("tui.reservations.time", l.Time(start), l.Time(end)),
+		l.T("tui.reservations.duration", int(minutes)),
+		l.T("tui.reservations.cost", r.Credits*minutes/60),
+		l.T("tui.reservations.status", status),
+	}, "\n")
+}
+
+// cancellationSummary tells which reservations were cancelled, and why the
+// others couldn't be.
+func (rl *ReservationListModel) cancellationSummary() string {
+	success := theme.Current().Success()
+	failure := theme.Current().Danger()
+
+	var b strings.Builder
+	var refunded float64
+	l := i18n.Default()
+
+	for _, result := range rl.results {
+		start, _ := rl.parseDate(result.Reservation.Start)
+		line := fmt.Sprintf("%s · %s", result.Reservation.ItemName, l.DateTime(start))
+
+		if result.Err != nil {
+			b.WriteString(failure.Render(fmt.Sprintf("✗ %s: %s", line, result.Err.Error())) + "\n")
+			continue
+		}
+
+		refunded += result.Credits
+		b.WriteString(success.Render("✓ "+line) + "\n")
+	}
+
+	b.WriteString("\n" + l.T("tui.reservations.refunded", refunded) + "\n\n")
+	b.WriteString(l.T("tui.esc_to_menu"))
+
+	return b.String()
+}
+
+// reservationActions lists what can be done with a reservation, along with
+// the credits it costs or gives back. The values are how many minutes to move
+// its end by, 0 meaning it is cancelled.
+func reservationActions(r api.Reservation) []huh.Option[int] {
+	price := r.Credits
+	l := i18n.Default()
+
+	return []huh.Option[int]{
+		huh.NewOption(l.T("tui.reservations.cancel"), 0),
+		huh.NewOption(l.T("tui.reservations.extend", 15, price/4), 15),
+		huh.NewOption(l.T("tui.reservations.extend", 30, price/2), 30),
+		huh.NewOption(l.T("tui.reservations.shorten", 15, price/4), -15),
+		huh.NewOption(l.T("tui.reservations.shorten", 30, price/2), -30),
+	}
+}
+
+// validateReservationAction prevents cancelling a reservation which has
+// already started. Changing its end is still possible.
+func validateReservationAction(r api.Reservation, action int) error {
+	if action != 0 {
+		return nil
+	}
+
+	return validateReservation(r)
+}
+
+func (rl *ReservationListModel) resizeReservation() tea.Cmd {
 	return func() tea.Msg {
-		authService, err := services.NewService()
+		s, err := services.NewService()
 		if err != nil {
 			return futureBookingMsg{err: err}
 		}
 
-		user, err := authService.GetAuthData()
+		_, credits, err := s.ResizeReservation(rl.pickedReservations[0].OrderResourceRentId, rl.action, false)
 		if err != nil {
 			return futureBookingMsg{err: err}
 		}
 
-		apiClient := api.NewApi()
-		err = apiClient.CancelBooking(user.WAuth, user.WAuthRefresh, rl.pickedReservation.OrderResourceRentId)
+		return resizeComplete{credits: credits}
+	}
+}
+
+func (rl *ReservationListModel) cancelReservation() tea.Cmd {
+	return func() tea.Msg {
+		authService, err := services.NewService()
 		if err != nil {
 			return futureBookingMsg{err: err}
 		}
 
-		return cancelComplete{}
+		results, err := authService.CancelReservations(rl.pickedReservations)
+		if err != nil {
+			return futureBookingMsg{err: err}
+		}
+
+		// A single failure is reported like any other error.
+		if len(results) == 1 && results[0].Err != nil {
+			return futureBookingMsg{err: results[0].Err}
+		}
+
+		return cancelComplete{results: results}
 	}
 }
 
@@ -237,7 +415,7 @@ func validateReservation(r api.Reservation) error {
 	}
 
 	if parsedStart.Before(time.Now()) {
-		return errors.New("this reservation has already started and cannot be cancelled")
+		return errors.New(i18n.T("tui.reservations.already_started"))
 	}
 
 	return nil
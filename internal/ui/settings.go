@@ -1,12 +1,13 @@
 package ui
 
 import (
+	"cosoft-cli/internal/i18n"
 	"cosoft-cli/internal/services"
+	"cosoft-cli/internal/theme"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
-	"github.com/charmbracelet/lipgloss"
 )
 
 type SettingsModel struct {
@@ -14,8 +15,12 @@ type SettingsModel struct {
 	spinner     spinner.Model
 	choiceForm  *huh.Form
 	confirmForm *huh.Form
+	leadForm    *huh.Form
+	langForm    *huh.Form
 	confirmed   bool
 	choice      string
+	leadTime    int
+	locale      i18n.Locale
 	loading     bool
 	err         error
 }
@@ -24,16 +29,25 @@ type clearingDone struct {
 	err error
 }
 
+type leadTimeSaved struct {
+	err error
+}
+
+type localeSaved struct {
+	err error
+}
+
 func NewSettingsModel() *SettingsModel {
 
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("63"))
+	s.Style = theme.Current().Spinner()
 
 	settings := &SettingsModel{
 		phase:     1,
 		choice:    "",
 		confirmed: false,
+		leadTime:  10,
 		spinner:   s,
 		loading:   false,
 	}
@@ -41,26 +55,61 @@ func NewSettingsModel() *SettingsModel {
 	choice := huh.NewForm(
 		huh.NewGroup(
 			huh.NewSelect[string]().
-				Title("Choose a setting").
+				Title(i18n.T("tui.settings.choose")).
 				Options(
-					huh.NewOption("Delete the local settings", "clean"),
+					huh.NewOption(i18n.T("tui.settings.reminder"), "reminder"),
+					huh.NewOption(i18n.T("tui.settings.language"), "language"),
+					huh.NewOption(i18n.T("tui.settings.clean"), "clean"),
 				).
 				Value(&settings.choice),
 		),
-	)
+	).WithTheme(theme.Current().Huh())
 
 	confirm := huh.NewForm(
 		huh.NewGroup(
 			huh.NewConfirm().
-				Title("Confirm?").
-				Negative("No").
-				Affirmative("Yes").
+				Title(i18n.T("tui.confirm")).
+				Negative(i18n.T("tui.no")).
+				Affirmative(i18n.T("tui.yes")).
 				Value(&settings.confirmed),
 		),
-	)
+	).WithTheme(theme.Current().Huh())
+
+	lead := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[int]().
+				Title(i18n.T("tui.settings.lead_title")).
+				Options(
+					huh.NewOption(i18n.T("tui.minutes", 5), 5),
+					huh.NewOption(i18n.T("tui.minutes", 10), 10),
+					huh.NewOption(i18n.T("tui.minutes", 15), 15),
+					huh.NewOption(i18n.T("tui.minutes", 30), 30),
+					huh.NewOption(i18n.T("tui.settings.never"), 0),
+				).
+				Value(&settings.leadTime),
+		),
+	).WithTheme(theme.Current().Huh())
+
+	// An empty locale follows LANG.
+	languages := []huh.Option[i18n.Locale]{huh.NewOption(i18n.T("tui.settings.automatic"), i18n.Locale(""))}
+
+	for _, l := range i18n.Locales {
+		languages = append(languages, huh.NewOption(l.Name(), l))
+	}
+
+	lang := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[i18n.Locale]().
+				Title(i18n.T("tui.settings.language_title")).
+				Options(languages...).
+				Value(&settings.locale),
+		),
+	).WithTheme(theme.Current().Huh())
 
 	settings.choiceForm = choice
 	settings.confirmForm = confirm
+	settings.leadForm = lead
+	settings.langForm = lang
 
 	return settings
 }
@@ -85,6 +134,22 @@ func (s *SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		s.phase = 4
 		return s, tea.Printf("")
+	case leadTimeSaved:
+		if msg.err != nil {
+			s.err = msg.err
+			return s, nil
+		}
+
+		s.phase = 4
+		return s, nil
+	case localeSaved:
+		if msg.err != nil {
+			s.err = msg.err
+			return s, nil
+		}
+
+		s.phase = 4
+		return s, nil
 	}
 
 	switch s.phase {
@@ -95,10 +160,42 @@ func (s *SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if s.choiceForm.State == huh.StateCompleted {
 			s.phase = 2
+			switch s.choice {
+			case "reminder":
+				return s, s.leadForm.Init()
+			case "language":
+				return s, s.langForm.Init()
+			}
 			return s, s.confirmForm.Init()
 		}
 		return s, cmd
 	case 2:
+		if s.choice == "reminder" {
+			form, cmd := s.leadForm.Update(msg)
+			if f, ok := form.(*huh.Form); ok {
+				s.leadForm = f
+			}
+
+			if s.leadForm.State == huh.StateCompleted {
+				s.phase = 3
+				return s, s.saveLeadTime()
+			}
+			return s, cmd
+		}
+
+		if s.choice == "language" {
+			form, cmd := s.langForm.Update(msg)
+			if f, ok := form.(*huh.Form); ok {
+				s.langForm = f
+			}
+
+			if s.langForm.State == huh.StateCompleted {
+				s.phase = 3
+				return s, s.saveLocale()
+			}
+			return s, cmd
+		}
+
 		form, cmd := s.confirmForm.Update(msg)
 		if f, ok := form.(*huh.Form); ok {
 			s.confirmForm = f
@@ -119,43 +216,66 @@ func (s *SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (s *SettingsModel) View() string {
 	if s.err != nil {
-		return s.err.Error()
+		return errorText(s.err)
 	}
 
 	switch s.phase {
 	case 1:
 		return s.choiceForm.View()
 	case 2:
+		switch s.choice {
+		case "reminder":
+			return s.leadForm.View()
+		case "language":
+			return s.langForm.View()
+		}
+
 		var w string
 		if s.choice == "clean" {
-			w = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("9")).
+			w = theme.Current().Danger().
 				Bold(true).
-				Render("⚠️  Warning") +
+				Render(i18n.T("tui.settings.warning")) +
 				"\n\n" +
-				"You are about to delete the local settings file, which will also log you out. \n" +
-				"This cannot be undone." +
+				i18n.T("tui.settings.clean_warning") +
 				"\n\n"
 		}
 
 		return w + s.confirmForm.View()
 	case 3:
-		return s.spinner.View() + " Clearing personal data..."
+		if s.choice == "clean" {
+			return s.spinner.View() + " " + i18n.T("tui.settings.clearing")
+		}
+		return s.spinner.View() + " " + i18n.T("tui.settings.saving")
 	case 4:
-		success := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("42")).
-			Render("✓ Cleared Personal Data!")
+		if s.choice == "reminder" {
+			success := theme.Current().Success().
+				Render(i18n.T("tui.settings.lead_saved"))
+
+			tooltip := i18n.T("tui.settings.lead_tooltip") + "\n" + i18n.T("tui.esc_to_menu")
+
+			return success + "\n\n" + tooltip
+		}
 
-		tooltip := "You can now press \"ESC\" to quit the program."
+		if s.choice == "language" {
+			success := theme.Current().Success().
+				Render(i18n.T("tui.settings.language_saved", i18n.Default().Name()))
+
+			return success + "\n\n" + i18n.T("tui.esc_to_menu")
+		}
+
+		success := theme.Current().Success().
+			Render(i18n.T("tui.settings.cleared"))
+
+		tooltip := i18n.T("tui.esc_to_quit")
 
 		return success + "\n\n" + tooltip
 	default:
-		return "Settings"
+		return i18n.T("tui.settings.title")
 	}
 }
 
 func (s *SettingsModel) ShouldQuitOnEsc() bool {
-	return s.phase == 4
+	return s.phase == 4 && s.choice == "clean"
 }
 
 func (s *SettingsModel) clearInformation() tea.Cmd {
@@ -170,3 +290,36 @@ func (s *SettingsModel) clearInformation() tea.Cmd {
 		return clearingDone{err: err}
 	}
 }
+
+func (s *SettingsModel) saveLeadTime() tea.Cmd {
+	return func() tea.Msg {
+		settingsService, err := services.NewService()
+
+		if err != nil {
+			return leadTimeSaved{err: err}
+		}
+
+		err = settingsService.SetReminderLeadTime(s.leadTime)
+		return leadTimeSaved{err: err}
+	}
+}
+
+// saveLocale stores the picked language, which applies right away.
+func (s *SettingsModel) saveLocale() tea.Cmd {
+	return func() tea.Msg {
+		settingsService, err := services.NewService()
+
+		if err != nil {
+			return localeSaved{err: err}
+		}
+
+		err = settingsService.SetLocale(s.locale)
+
+		if err != nil {
+			return localeSaved{err: err}
+		}
+
+		i18n.SetDefault(settingsService.Locale())
+		return localeSaved{}
+	}
+}
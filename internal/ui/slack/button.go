@@ -5,6 +5,7 @@ type ButtonPayload struct {
 	Text     BlockPayload `json:"text"`
 	Value    string       `json:"value"`
 	ActionId string       `json:"action_id"`
+	Confirm  *Confirm     `json:"confirm,omitempty"`
 }
 
 type Button struct {
@@ -0,0 +1,58 @@
+package slack
+
+import "fmt"
+
+type Checkboxes struct {
+	Type      string              `json:"type"`
+	Text      OptionContent       `json:"text"`
+	BlockId   string              `json:"block_id"`
+	Accessory CheckboxesAccessory `json:"accessory"`
+}
+
+type CheckboxesAccessory struct {
+	Type           string          `json:"type"`
+	Options        []SelectOptions `json:"options"`
+	InitialOptions []SelectOptions `json:"initial_options,omitempty"`
+	ActionID       string          `json:"action_id"`
+}
+
+func (Checkboxes) blockElement() {}
+
+// NewCheckboxes lists choices as checkboxes, those whose value is in checked
+// being ticked. Slack allows up to 10 of them.
+func NewCheckboxes(label, name string, choices []ChoicePayload, checked []string) Checkboxes {
+	options := make([]SelectOptions, len(choices))
+	var initial []SelectOptions
+
+	for i, choice := range choices {
+		options[i] = SelectOptions{
+			Value: choice.Value,
+			OptionContent: BlockPayload{
+				Type: "mrkdwn",
+				Text: choice.Text,
+			},
+		}
+
+		for _, value := range checked {
+			if value == choice.Value {
+				initial = append(initial, options[i])
+				break
+			}
+		}
+	}
+
+	return Checkboxes{
+		Type:    "section",
+		BlockId: name,
+		Text: OptionContent{
+			Type: "mrkdwn",
+			Text: fmt.Sprintf("*%s*", label),
+		},
+		Accessory: CheckboxesAccessory{
+			Type:           "checkboxes",
+			Options:        options,
+			InitialOptions: initial,
+			ActionID:       name,
+		},
+	}
+}
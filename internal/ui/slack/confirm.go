@@ -0,0 +1,27 @@
+package slack
+
+// Confirm is a dialog Slack displays before sending a button's action.
+type Confirm struct {
+	Title   BlockPayload `json:"title"`
+	Text    BlockPayload `json:"text"`
+	Confirm BlockPayload `json:"confirm"`
+	Deny    BlockPayload `json:"deny"`
+	Style   string       `json:"style,omitempty"`
+}
+
+func NewConfirm(title, text, confirm, deny string) *Confirm {
+	return &Confirm{
+		Title:   BlockPayload{Type: "plain_text", Text: title},
+		Text:    BlockPayload{Type: "mrkdwn", Text: text},
+		Confirm: BlockPayload{Type: "plain_text", Text: confirm},
+		Deny:    BlockPayload{Type: "plain_text", Text: deny},
+		Style:   "danger",
+	}
+}
+
+// WithConfirm asks the user for a confirmation before sending the action
+// of the item's button.
+func (m MenuItem) WithConfirm(confirm *Confirm) MenuItem {
+	m.Accessory.Confirm = confirm
+	return m
+}
@@ -0,0 +1,15 @@
+package slack
+
+// Home is the content of the bot's App Home tab, published through
+// views.publish.
+type Home struct {
+	Type   string         `json:"type"`
+	Blocks []BlockElement `json:"blocks"`
+}
+
+func NewHome(blocks []BlockElement) Home {
+	return Home{
+		Type:   "home",
+		Blocks: blocks,
+	}
+}
@@ -0,0 +1,46 @@
+package slack
+
+// InputElement is an input block wrapping any interactive element (select,
+// date picker...). Unlike section accessories, input blocks can receive
+// inline errors when a modal is submitted.
+type InputElement struct {
+	Type     string       `json:"type"`
+	BlockId  string       `json:"block_id"`
+	Label    BlockPayload `json:"label"`
+	Element  any          `json:"element"`
+	Optional bool         `json:"optional"`
+}
+
+func (InputElement) blockElement() {}
+
+func NewSelectInput(
+	label, placeholder, name string,
+	choices []ChoicePayload,
+) InputElement {
+	return newInputElement(label, name, NewSelect(label, placeholder, name, choices).Accessory)
+}
+
+func NewDatePickerInput(label, name, placeholder string) InputElement {
+	return newInputElement(label, name, NewDatePicker(label, name, placeholder).Accessory)
+}
+
+func NewTimePickerInput(label, name, placeholder string) InputElement {
+	return newInputElement(label, name, NewTimePicker(label, name, placeholder).Accessory)
+}
+
+func NewCheckboxesInput(label, name string, choices []ChoicePayload, checked []string) InputElement {
+	return newInputElement(label, name, NewCheckboxes(label, name, choices, checked).Accessory)
+}
+
+func newInputElement(label, name string, element any) InputElement {
+	return InputElement{
+		Type:    "input",
+		BlockId: name,
+		Label: BlockPayload{
+			Type:  "plain_text",
+			Text:  label,
+			Emoji: true,
+		},
+		Element: element,
+	}
+}
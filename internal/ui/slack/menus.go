@@ -1,122 +1,173 @@
 package slack
 
 import (
+	"cosoft-cli/internal/i18n"
 	"cosoft-cli/internal/storage"
-	"fmt"
+	"cosoft-cli/shared/models"
 )
 
-var (
-	durationChoices = []ChoicePayload{
+func durationChoices(l i18n.Locale) []ChoicePayload {
+	return []ChoicePayload{
 		{
-			"30 minutes",
+			l.T("slack.menu.duration_30"),
 			"30",
 		},
 		{
-			"1 heure",
+			l.T("slack.menu.duration_60"),
 			"60",
 		},
 		{
-			"1 heure 30",
+			l.T("slack.menu.duration_90"),
 			"90",
 		},
 		{
-			"2 heures",
+			l.T("slack.menu.duration_120"),
 			"120",
 		},
 	}
+}
+
+// featureChoices offers every feature rooms can be filtered by.
+func featureChoices(l i18n.Locale) []ChoicePayload {
+	choices := make([]ChoicePayload, len(models.Features))
+
+	for i, f := range models.Features {
+		choices[i] = ChoicePayload{l.T("slack.feature." + string(f)), string(f)}
+	}
 
-	nbPeopleChoices = []ChoicePayload{
+	return choices
+}
+
+func nbPeopleChoices(l i18n.Locale) []ChoicePayload {
+	return []ChoicePayload{
 		{
-			"Une personne",
+			l.T("slack.menu.one_person"),
 			"1",
 		},
 		{
-			"Deux personnes ou plus",
+			l.T("slack.menu.two_people"),
 			"2",
 		},
 	}
-)
-
-func MainMenu(user storage.User) Block {
-	welcomeMessage := fmt.Sprintf(
-		"Vous êtes connecté(e) en tant que *%s %s* (%s)",
-		user.FirstName,
-		user.LastName,
-		user.Email,
-	)
+}
 
-	creditsMessage := fmt.Sprintf("Il vous reste *%.2f* credits", user.Credits)
+func MainMenu(user storage.User, l i18n.Locale) Block {
+	welcomeMessage := l.T("slack.logged_in_as", user.FirstName, user.LastName, user.Email)
+	creditsMessage := l.T("slack.credits_left", user.Credits)
 
 	return Block{
 		Blocks: []BlockElement{
 			NewMrkDwn(welcomeMessage),
 			NewMrkDwn(creditsMessage),
 			NewDivider(),
-			NewHeader("Menu principal"),
+			NewHeader(l.T("slack.menu.title")),
 			NewMenuItem(
-				"*Calendrier*\nVoir l'occupation des salles de la journée et des suivantes",
-				"Accéder",
+				l.T("slack.menu.calendar"),
+				l.T("slack.menu.open"),
 				"calendar",
 			),
 			NewMenuItem(
-				"*Réservation rapide*\nRéserver immédiatement une salle de réunion",
-				"Accéder",
+				l.T("slack.menu.quick_book"),
+				l.T("slack.menu.open"),
 				"quick-book",
 			),
 			NewMenuItem(
-				"*Parcourir les salles*\nRéserver une salle pour une date ultérieure",
-				"Accéder",
+				l.T("slack.menu.browse_rooms"),
+				l.T("slack.menu.open"),
 				"browse",
 			),
 			NewMenuItem(
-				"*Mes réservations*\nVoir et annuler vos futures réservations",
-				"Accéder",
+				l.T("slack.menu.reservations"),
+				l.T("slack.menu.open"),
 				"reservations",
 			),
 		},
 	}
 }
 
-func QuickBookMenu() Block {
+func QuickBookMenu(l i18n.Locale) Block {
 	return Block{
 		Blocks: []BlockElement{
-			NewHeader("Réservation rapide"),
+			NewHeader(l.T("slack.quick_book.title")),
 			NewSelect(
-				"Durée de la réservation",
-				"Sélectionner",
+				l.T("slack.menu.duration"),
+				l.T("slack.select"),
 				"duration",
-				durationChoices,
+				durationChoices(l),
 			),
 			NewSelect(
-				"Capacité",
-				"Sélectionner",
+				l.T("slack.menu.capacity"),
+				l.T("slack.select"),
 				"nbPeople",
-				nbPeopleChoices,
+				nbPeopleChoices(l),
 			),
-			NewButtons([]ChoicePayload{{"Annuler", "cancel"}, {"Réserver", "quick-book"}}),
+			NewButtons([]ChoicePayload{{l.T("slack.cancel"), "cancel"}, {l.T("slack.book"), "quick-book"}}),
 		},
 	}
 }
 
-func BrowseMenu() Block {
+func BrowseMenu(l i18n.Locale) Block {
 	return Block{
 		Blocks: []BlockElement{
-			NewHeader("Réserver une salle"),
-			NewDatePicker("Date", "date", "Date"),
-			NewTimePicker("*Heure*\nFormat autorisé : 15h00, 15:15", "time", "Heure"),
+			NewHeader(l.T("slack.browse.title")),
+			NewDatePicker(l.T("slack.menu.date"), "date", l.T("slack.menu.date")),
+			NewTimePicker(l.T("slack.menu.time_hint"), "time", l.T("slack.menu.time")),
 			NewSelect(
-				"Durée de la réservation",
-				"Sélectionner",
+				l.T("slack.menu.duration"),
+				l.T("slack.select"),
 				"duration",
-				durationChoices,
+				durationChoices(l),
 			),
 			NewSelect(
-				"Capacité",
-				"Sélectionner",
+				l.T("slack.menu.capacity"),
+				l.T("slack.select"),
 				"nbPeople",
-				nbPeopleChoices,
+				nbPeopleChoices(l),
 			),
-			NewButtons([]ChoicePayload{{"Annuler", "cancel"}, {"Voir les salles disponibles", "browse"}}),
+			NewCheckboxes(l.T("slack.menu.features"), "features", featureChoices(l), nil),
+			NewButtons([]ChoicePayload{{l.T("slack.cancel"), "cancel"}, {l.T("slack.menu.see_rooms"), "browse"}}),
 		},
 	}
 }
+
+// QuickBookForm holds the quick book fields as input blocks, for modals.
+func QuickBookForm(l i18n.Locale) []BlockElement {
+	return []BlockElement{
+		NewSelectInput(
+			l.T("slack.menu.duration"),
+			l.T("slack.select"),
+			"duration",
+			durationChoices(l),
+		),
+		NewSelectInput(
+			l.T("slack.menu.capacity"),
+			l.T("slack.select"),
+			"nbPeople",
+			nbPeopleChoices(l),
+		),
+	}
+}
+
+// BrowseForm holds the browse fields as input blocks, for modals.
+func BrowseForm(l i18n.Locale) []BlockElement {
+	features := NewCheckboxesInput(l.T("slack.menu.features"), "features", featureChoices(l), nil)
+	features.Optional = true
+
+	return []BlockElement{
+		NewDatePickerInput(l.T("slack.menu.date"), "date", l.T("slack.menu.date")),
+		NewTimePickerInput(l.T("slack.menu.time"), "time", l.T("slack.menu.time")),
+		NewSelectInput(
+			l.T("slack.menu.duration"),
+			l.T("slack.select"),
+			"duration",
+			durationChoices(l),
+		),
+		NewSelectInput(
+			l.T("slack.menu.capacity"),
+			l.T("slack.select"),
+			"nbPeople",
+			nbPeopleChoices(l),
+		),
+		features,
+	}
+}
@@ -0,0 +1,53 @@
+package slack
+
+// Modal is a Slack view surface opened through views.open and kept in sync
+// through views.update.
+type Modal struct {
+	Type            string         `json:"type"`
+	CallbackId      string         `json:"callback_id"`
+	Title           BlockPayload   `json:"title"`
+	Submit          *BlockPayload  `json:"submit,omitempty"`
+	Close           *BlockPayload  `json:"close,omitempty"`
+	NotifyOnClose   bool           `json:"notify_on_close"`
+	PrivateMetadata string         `json:"private_metadata,omitempty"`
+	Blocks          []BlockElement `json:"blocks"`
+}
+
+// ModalResponse is the synchronous answer to a view_submission payload.
+type ModalResponse struct {
+	ResponseAction string            `json:"response_action"`
+	Errors         map[string]string `json:"errors,omitempty"`
+	View           *Modal            `json:"view,omitempty"`
+}
+
+// NewModal returns a modal whose close button reads closeText.
+func NewModal(title, closeText, callbackId string, blocks []BlockElement) Modal {
+	return Modal{
+		Type:       "modal",
+		CallbackId: callbackId,
+		Title: BlockPayload{
+			Type:  "plain_text",
+			Text:  title,
+			Emoji: true,
+		},
+		Close: &BlockPayload{
+			Type:  "plain_text",
+			Text:  closeText,
+			Emoji: true,
+		},
+		NotifyOnClose: true,
+		Blocks:        blocks,
+	}
+}
+
+// WithSubmit adds a submit button to the modal, which triggers a
+// view_submission payload when clicked.
+func (m Modal) WithSubmit(text string) Modal {
+	m.Submit = &BlockPayload{
+		Type:  "plain_text",
+		Text:  text,
+		Emoji: true,
+	}
+
+	return m
+}
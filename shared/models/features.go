@@ -0,0 +1,107 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Feature is an equipment rooms can be filtered by.
+type Feature string
+
+const (
+	FeatureScreen          Feature = "screen"
+	FeatureWhiteboard      Feature = "whiteboard"
+	FeatureVideoconference Feature = "videoconference"
+)
+
+// Features lists every feature, in the order they are offered.
+var Features = []Feature{FeatureScreen, FeatureWhiteboard, FeatureVideoconference}
+
+// featureKeywords are the words, in French and English, by which Cosoft's
+// equipment and descriptions mention a feature. They match whole words,
+// whatever their accents and plural, see words.
+var featureKeywords = map[Feature][]string{
+	FeatureScreen:          {"écran", "screen", "moniteur", "monitor", "télévision", "television", "tv", "projecteur", "projector"},
+	FeatureWhiteboard:      {"tableau blanc", "whiteboard", "paperboard", "velleda"},
+	FeatureVideoconference: {"visio", "visioconférence", "vidéoconférence", "vidéo conférence", "videoconference", "video conference", "webcam", "caméra", "camera"},
+}
+
+var unaccented = strings.NewReplacer(
+	"à", "a", "â", "a", "ç", "c", "é", "e", "è", "e", "ê", "e", "ë", "e",
+	"î", "i", "ï", "i", "ô", "o", "ù", "u", "û", "u", "ü", "u",
+)
+
+// words splits text into lowercase words, without accents nor plural s.
+func words(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i, field := range fields {
+		fields[i] = strings.TrimSuffix(unaccented.Replace(field), "s")
+	}
+
+	return fields
+}
+
+// containsWords tells whether sequence appears in text, word for word.
+func containsWords(text, sequence []string) bool {
+	for i := 0; i+len(sequence) <= len(text); i++ {
+		if slices.Equal(text[i:i+len(sequence)], sequence) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseFeature returns the feature called name, as in Features.
+func ParseFeature(name string) (Feature, bool) {
+	f := Feature(strings.ToLower(strings.TrimSpace(name)))
+
+	return f, slices.Contains(Features, f)
+}
+
+// Has tells whether the room's equipment or description mentions f.
+func (r Room) Has(f Feature) bool {
+	for _, text := range append([]string{r.Description}, r.Equipment...) {
+		mentioned := slices.ContainsFunc(featureKeywords[f], func(keyword string) bool {
+			return containsWords(words(text), words(keyword))
+		})
+
+		if mentioned {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasAll tells whether the room has every one of features.
+func (r Room) HasAll(features []Feature) bool {
+	for _, f := range features {
+		if !r.Has(f) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FilterRooms keeps the rooms having every one of features.
+func FilterRooms(rooms []Room, features []Feature) []Room {
+	if len(features) == 0 {
+		return rooms
+	}
+
+	var kept []Room
+
+	for _, room := range rooms {
+		if room.HasAll(features) {
+			kept = append(kept, room)
+		}
+	}
+
+	return kept
+}
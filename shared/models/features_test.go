@@ -0,0 +1,30 @@
+package models
+
+import "testing"
+
+func TestRoomHas(t *testing.T) {
+	tests := []struct {
+		name    string
+		room    Room
+		feature Feature
+		want    bool
+	}{
+		{name: "equipment", room: Room{Equipment: []string{"Écran"}}, feature: FeatureScreen, want: true},
+		{name: "without_accent", room: Room{Equipment: []string{"ecran 55 pouces"}}, feature: FeatureScreen, want: true},
+		{name: "plural", room: Room{Description: "Deux écrans et une webcam."}, feature: FeatureScreen, want: true},
+		{name: "several_words", room: Room{Equipment: []string{"Tableau blanc"}}, feature: FeatureWhiteboard, want: true},
+		{name: "hyphenated", room: Room{Description: "Équipée pour la vidéo-conférence."}, feature: FeatureVideoconference, want: true},
+		{name: "tv", room: Room{Equipment: []string{"TV"}}, feature: FeatureScreen, want: true},
+		{name: "word_containing_tv", room: Room{Description: "Ancienne salle de réunion de Netvibes."}, feature: FeatureScreen},
+		{name: "word_containing_visio", room: Room{Description: "Vue sur la ville, idéale pour la prévision."}, feature: FeatureVideoconference},
+		{name: "words_apart", room: Room{Equipment: []string{"Tableau", "Rideau blanc"}}, feature: FeatureWhiteboard},
+		{name: "none", room: Room{Description: "Salle calme."}, feature: FeatureScreen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.room.Has(tt.feature); got != tt.want {
+				t.Errorf("Has(%s) = %v, want %v", tt.feature, got, tt.want)
+			}
+		})
+	}
+}
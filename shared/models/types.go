@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"time"
 )
 
 type Selection struct {
@@ -14,6 +15,11 @@ type Room struct {
 	NbUsers int
 	Price   float64
 	Image   string
+	// Description, Floor and Equipment are as Cosoft describes the room,
+	// empty when it doesn't.
+	Description string
+	Floor       string
+	Equipment   []string
 }
 
 type UnavailableSlot struct {
@@ -26,6 +32,16 @@ type RoomUsage struct {
 	Name      string
 	Id        string
 	UsedSlots []UnavailableSlot
+	// Unknown tells that the busy slots couldn't be fetched, so the room
+	// may not be free.
+	Unknown bool
+}
+
+// SlotSuggestion is a free slot in Room, starting at Start, offered when
+// nothing matches the requested time.
+type SlotSuggestion struct {
+	Room  Room
+	Start time.Time
 }
 
 type Request struct {
@@ -34,17 +50,49 @@ type Request struct {
 	Text        string
 	ResponseUrl string
 	TriggerId   string
+	// ChannelId is the channel the command was typed in.
+	ChannelId string
 }
 
 type InteractionDiscovery struct {
-	User struct {
+	Type      string `json:"type"`
+	TriggerID string `json:"trigger_id"`
+	User      struct {
 		ID string `json:"id"`
 	} `json:"user"`
 	State struct {
 		Values json.RawMessage `json:"values"`
 	} `json:"state"`
+	// View is only filled when the interaction comes from a modal.
+	View struct {
+		ID         string `json:"id"`
+		Type       string `json:"type"`
+		CallbackID string `json:"callback_id"`
+		// Hash changes every time the modal is updated.
+		Hash string `json:"hash"`
+		// PrivateMetadata is set by the bot to the version of the stored
+		// view the modal displays.
+		PrivateMetadata string `json:"private_metadata"`
+		State           struct {
+			Values json.RawMessage `json:"values"`
+		} `json:"state"`
+	} `json:"view"`
 	ResponseURL string `json:"response_url"`
 	Actions     []struct {
 		ActionID string `json:"action_id"`
+		// ActionTs is when the user acted, as seconds since the epoch.
+		ActionTs string `json:"action_ts"`
 	}
 }
+
+// EventCallback is the envelope of the payloads sent by Slack's Events API.
+type EventCallback struct {
+	Type      string `json:"type"`
+	EventID   string `json:"event_id"`
+	Challenge string `json:"challenge"`
+	Event     struct {
+		Type string `json:"type"`
+		User string `json:"user"`
+		Tab  string `json:"tab"`
+	} `json:"event"`
+}
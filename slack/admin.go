@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"cosoft-cli/internal/common"
+	"cosoft-cli/internal/i18n"
+	cliservices "cosoft-cli/internal/services"
+	"cosoft-cli/internal/slackbot/services"
+	"cosoft-cli/internal/storage"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// adminCmd gathers the tools of the bot's operators. They work on the
+// database directly, the bot can keep running, except for import.
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Manages the bot's users and database",
+}
+
+// withService runs f with a service over the bot's database.
+func withService(f func(s *services.SlackService) error) error {
+	store, err := openStore()
+
+	if err != nil {
+		return err
+	}
+
+	defer store.Close()
+
+	return f(services.NewSlackService(store))
+}
+
+var adminUsersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "Lists the users linked to Slack, and whether their Cosoft token works",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withService(func(s *services.SlackService) error {
+			users, err := s.LinkedUsers()
+
+			if err != nil {
+				return err
+			}
+
+			if len(users) == 0 {
+				fmt.Println(i18n.T("cli.admin.no_user"))
+				return nil
+			}
+
+			l := i18n.Default()
+			headers := []string{
+				l.T("cli.admin.slack_id"),
+				l.T("cli.table.name"),
+				l.T("cli.admin.email"),
+				l.T("cli.admin.credits"),
+				l.T("cli.admin.last_seen"),
+				l.T("cli.admin.token"),
+			}
+			rows := make([][]string, len(users))
+
+			for i, u := range users {
+				lastSeen := l.T("cli.admin.never")
+
+				if !u.LastSeen.IsZero() {
+					lastSeen = l.DateTime(u.LastSeen)
+				}
+
+				token := l.T("cli.admin.token_ok")
+
+				if u.TokenError != "" {
+					token = u.TokenError
+				}
+
+				rows[i] = []string{u.SlackUserId, u.Name, u.Email, fmt.Sprintf("%.2f", u.Credits), lastSeen, token}
+			}
+
+			fmt.Println(common.CreateTable(headers, rows))
+
+			return nil
+		})
+	},
+}
+
+var adminLogoutCmd = &cobra.Command{
+	Use:   "logout <slack user id>",
+	Short: "Logs a user out, forgetting their Cosoft token and Slack views",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withService(func(s *services.SlackService) error {
+			loggedOut, err := s.ForceLogout(args[0])
+
+			if err != nil {
+				return err
+			}
+
+			if loggedOut {
+				fmt.Println(i18n.T("cli.admin.logged_out", args[0]))
+			} else {
+				fmt.Println(i18n.T("cli.admin.not_logged_in", args[0]))
+			}
+
+			return nil
+		})
+	},
+}
+
+var adminPurgeCmd = &cobra.Command{
+	Use:   "purge <days>",
+	Short: "Deletes the Slack views nobody displayed for the given number of days",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		days, err := strconv.Atoi(args[0])
+
+		if err != nil {
+			return err
+		}
+
+		return withService(func(s *services.SlackService) error {
+			purged, err := s.PurgeStates(days)
+
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(i18n.T("cli.admin.purged", purged, days))
+
+			return nil
+		})
+	},
+}
+
+var adminRoomsCmd = &cobra.Command{
+	Use:   "rooms",
+	Short: "Fetches the rooms from Cosoft again, and lists what changed",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withService(func(s *services.SlackService) error {
+			changes, err := s.SyncRooms(context.Background())
+
+			if err != nil {
+				return err
+			}
+
+			printRoomChanges(changes)
+
+			return nil
+		})
+	},
+}
+
+var adminExportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Writes a copy of the database to a new file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := openStore()
+
+		if err != nil {
+			return err
+		}
+
+		defer store.Close()
+
+		err = store.Export(args[0])
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(i18n.T("cli.admin.exported", args[0]))
+
+		return nil
+	},
+}
+
+var adminImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Replaces the database with an exported one, the bot must be stopped",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := storage.Import(args[0], databasePath())
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(i18n.T("cli.admin.imported", args[0]))
+
+		return nil
+	},
+}
+
+// printRoomChanges prints what fetching the rooms again changed.
+func printRoomChanges(changes []storage.RoomChange) {
+	l := i18n.Default()
+
+	if len(changes) == 0 {
+		fmt.Println(l.T("cli.rooms.unchanged"))
+		return
+	}
+
+	for _, c := range changes {
+		fmt.Println(cliservices.DescribeRoomChange(c, l))
+	}
+}
+
+func init() {
+	adminCmd.AddCommand(adminUsersCmd, adminLogoutCmd, adminPurgeCmd, adminRoomsCmd, adminExportCmd, adminImportCmd)
+	rootCmd.AddCommand(adminCmd)
+}
@@ -1,41 +1,153 @@
 package main
 
 import (
+	"context"
+	"cosoft-cli/internal/api"
+	"cosoft-cli/internal/api/sandbox"
 	"cosoft-cli/internal/slackbot"
+	"cosoft-cli/internal/slackbot/metrics"
 	"cosoft-cli/internal/slackbot/services"
 	"cosoft-cli/internal/storage"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
 	_ "time/tzdata"
 
 	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
 )
 
 func main() {
+	// Never let credentials reach the logs.
+	options := &slog.HandlerOptions{ReplaceAttr: slackbot.RedactAttr}
+	var handler slog.Handler = slog.NewTextHandler(os.Stderr, options)
+
+	if os.Getenv("LOG_FORMAT") == "json" {
+		handler = slog.NewJSONHandler(os.Stderr, options)
+	}
+
+	slog.SetDefault(slog.New(handler))
+
 	err := godotenv.Load()
 	if err != nil {
-		log.Println("Error loading .env file")
+		slog.Info("no .env file loaded")
 	}
 
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+var rootCmd = &cobra.Command{
+	Use:   "cosoft-bot",
+	Short: "Serves the Cosoft Slack bot",
+	Args:  cobra.NoArgs,
+	// Errors aren't about how the commands are used.
+	SilenceUsage: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		serve()
+	},
+}
+
+// databasePath returns where the bot's database is. The sandbox gets its
+// own database, next to the real one, so that the real users' tokens are
+// left alone.
+func databasePath() string {
 	dbPath := os.Getenv("DB_PATH")
 	if dbPath == "" {
 		dbPath = "./slack/database.db"
 	}
 
+	if sandbox.Enabled() {
+		dbPath = filepath.Join(filepath.Dir(dbPath), "sandbox.db")
+	}
+
+	return dbPath
+}
+
+// openStore opens the bot's database, migrated, starting the simulated
+// Cosoft API in sandbox mode. The sandbox's bookings and credits are kept in
+// sandbox.json, next to the database.
+func openStore() (*storage.Store, error) {
+	dbPath := databasePath()
+
+	if sandbox.Enabled() {
+		backend, err := sandbox.Persisted(filepath.Join(filepath.Dir(dbPath), "sandbox.json"))
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := sandbox.Start(backend); err != nil {
+			return nil, err
+		}
+
+		slog.Warn("sandbox mode, the Cosoft API is simulated", "db", dbPath)
+	}
+
 	store, err := storage.NewStore(dbPath)
 
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	// Ensure database exists and is migrated
 	err = store.SetupDatabase()
 
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func serve() {
+	store, err := openStore()
+
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	// Record the latency and errors of every request sent to Cosoft.
+	api.SetTransport(metrics.Transport(http.DefaultTransport))
+
+	config, err := slackbot.ServerConfigFromEnv()
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Without it, anyone could send commands on behalf of any Slack user.
+	signingSecret := os.Getenv("SLACK_SIGNING_SECRET")
+
+	if signingSecret == "" {
+		log.Fatal("SLACK_SIGNING_SECRET is not set")
+	}
+
+	// Docker sends SIGTERM to stop the container.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	service := services.NewSlackService(store)
-	bot := slackbot.NewBot(service)
-	bot.StartServer()
+
+	// Reminders are sent by direct message, which requires a bot token.
+	if service.HasBotToken() {
+		go service.StartReminders(ctx, time.Minute)
+	}
+
+	go service.StartRoomRefresh(ctx, time.Hour)
+
+	bot := slackbot.NewBot(service, signingSecret)
+
+	err = bot.StartServer(ctx, config)
+	store.Close()
+
+	if err != nil {
+		log.Fatal(err)
+	}
 }